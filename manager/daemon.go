@@ -0,0 +1,95 @@
+// +build !windows
+
+package manager
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/runner"
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// how long to wait to hear from the runner
+const readyTimeout = 30 * time.Second
+
+// daemon starts the runner in a session of its own, with no terminal,
+// stdio to /dev/null, the workdir (or /) as its working directory, and
+// a umask of 022. It hands the runner a pipe (as fd 3) on which to say
+// that it's ready, or why it couldn't start.
+func daemon(conf *service.Service, bin string, args ...string) (int, error) {
+	r, w, err := os.Pipe()
+	if nil != err {
+		return 0, err
+	}
+	defer r.Close()
+	devnull, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
+	if nil != err {
+		_ = w.Close()
+		return 0, err
+	}
+
+	cmd := exec.Command(bin, args...)
+	cmd.Dir = "/"
+	if "" != conf.Workdir {
+		cmd.Dir = conf.Workdir
+	}
+	cmd.Stdin = devnull
+	cmd.Stdout = devnull
+	cmd.Stderr = devnull
+	cmd.ExtraFiles = []*os.File{w}
+	cmd.Env = append(os.Environ(), runner.ReadyFDEnv+"=3")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+
+	// the umask is inherited, and there's no other way to set it for the child
+	umask := syscall.Umask(022)
+	err = cmd.Start()
+	syscall.Umask(umask)
+	_ = w.Close()
+	_ = devnull.Close()
+	if nil != err {
+		return 0, err
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+	lines := make(chan string, 1)
+	go func() {
+		line, _ := bufio.NewReader(r).ReadString('\n')
+		lines <- strings.TrimSpace(line)
+	}()
+
+	select {
+	case line := <-lines:
+		return readyLine(cmd.Process.Pid, line, exited)
+	case <-time.After(readyTimeout):
+		return cmd.Process.Pid, fmt.Errorf("the runner (pid %d) didn't say whether it started within %s", cmd.Process.Pid, readyTimeout)
+	}
+}
+
+// readyLine is "ready <pid>", "error <why>", or nothing at all,
+// if the runner exited without a word
+func readyLine(pid int, line string, exited chan error) (int, error) {
+	switch {
+	case strings.HasPrefix(line, "ready "):
+		return strconv.Atoi(strings.TrimPrefix(line, "ready "))
+	case strings.HasPrefix(line, "error "):
+		return pid, fmt.Errorf("%s", strings.TrimPrefix(line, "error "))
+	}
+	select {
+	case err := <-exited:
+		if nil != err {
+			return pid, fmt.Errorf("the runner (pid %d) exited before it was ready: %s", pid, err)
+		}
+	case <-time.After(time.Second):
+	}
+	return pid, fmt.Errorf("the runner (pid %d) exited before it was ready", pid)
+}
@@ -0,0 +1,57 @@
+package manager
+
+import (
+	"fmt"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/runner"
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// how long to wait to hear from the runner
+const readyTimeout = 30 * time.Second
+
+const (
+	createNewProcessGroup = 0x00000200
+	detachedProcess       = 0x00000008
+)
+
+// daemon starts the runner without a console, and waits until it answers
+// on its control pipe (as there are no fds to hand down to it)
+func daemon(conf *service.Service, bin string, args ...string) (int, error) {
+	cmd := exec.Command(bin, args...)
+	if "" != conf.Workdir {
+		cmd.Dir = conf.Workdir
+	}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		HideWindow:    true,
+		CreationFlags: createNewProcessGroup | detachedProcess,
+	}
+	if err := cmd.Start(); nil != err {
+		return 0, err
+	}
+	pid := cmd.Process.Pid
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+	deadline := time.After(readyTimeout)
+	for {
+		if runner.Running(conf) {
+			return pid, nil
+		}
+		select {
+		case err := <-exited:
+			if nil != err {
+				return pid, fmt.Errorf("the runner (pid %d) exited before it was ready: %s", pid, err)
+			}
+			return pid, fmt.Errorf("the runner (pid %d) exited before it was ready", pid)
+		case <-deadline:
+			return pid, fmt.Errorf("the runner (pid %d) didn't start within %s", pid, readyTimeout)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"git.rootprojects.org/root/serviceman/runner"
 	"git.rootprojects.org/root/serviceman/service"
 )
 
@@ -30,17 +31,15 @@ func Install(c *service.Service) (string, error) {
 		}
 	}
 
-	name, err := install(c)
-	if nil != err {
-		return "", err
-	}
-
-	err = os.MkdirAll(c.Logdir, 0755)
-	if nil != err {
-		return "", err
+	// the log files have to have somewhere to go before the service starts
+	for _, dir := range []string{c.Logdir, filepath.Dir(c.StdoutPath()), filepath.Dir(c.StderrPath())} {
+		err := os.MkdirAll(dir, 0755)
+		if nil != err {
+			return "", err
+		}
 	}
 
-	return name, nil
+	return install(c)
 }
 
 func Start(conf *service.Service) error {
@@ -51,10 +50,71 @@ func Stop(conf *service.Service) error {
 	return stop(conf)
 }
 
+// Status is how an installed service is doing. A runner answers for itself
+// on its control socket (whether or not serviceman start started it),
+// and otherwise it's up to systemd or launchd.
+func Status(conf *service.Service) ([]runner.InstanceStatus, error) {
+	if st, err := runner.Status(conf); runner.ErrNotRunning != err {
+		return st, err
+	}
+	return status(conf)
+}
+
+// History is the record of an installed service's runs, oldest first.
+// The runner keeps its own (whether or not serviceman start started it),
+// and otherwise, with systemd, it's read from the journal.
+func History(conf *service.Service) ([]runner.Run, error) {
+	runs, err := runner.History(conf)
+	if nil == err || !os.IsNotExist(err) {
+		return runs, err
+	}
+	return history(conf)
+}
+
 func List(conf *service.Service) ([]string, []string, []error) {
 	return list(conf)
 }
 
+// Security scores how exposed an installed service is, based on its sandboxing
+func Security(conf *service.Service) (*SecurityReport, error) {
+	return security(conf)
+}
+
+// MissingDependencies are the services (or units) that the service depends on,
+// but which aren't installed
+func MissingDependencies(conf *service.Service) []string {
+	missing := []string{}
+	deps := conf.Dependencies()
+	for i := range deps {
+		if !hasDependency(conf, deps[i]) {
+			missing = append(missing, deps[i])
+		}
+	}
+	return missing
+}
+
+// LogFiles are the log files of an installed service, if it logs to files
+func LogFiles(conf *service.Service) ([]string, error) {
+	return logFiles(conf)
+}
+
+// Dependencies are what an installed service depends on
+func Dependencies(conf *service.Service) ([]string, error) {
+	return dependencies(conf)
+}
+
+// Instances are the installed instances of a service that can be started
+// and stopped one at a time (i.e. foo@1, foo@2), if any
+func Instances(conf *service.Service) []string {
+	return instances(conf)
+}
+
+// StartMode is how an installed service starts (boot, login, or manual),
+// as read back from its service file
+func StartMode(conf *service.Service) (string, error) {
+	return startMode(conf)
+}
+
 // IsPrivileged returns true if we suspect that the current user (or process) will be able
 // to write to system folders, bind to privileged ports, and otherwise
 // successfully run a system service.
@@ -71,6 +131,23 @@ func WhereIs(exe string) (string, error) {
 	return filepath.Abs(filepath.ToSlash(exepath))
 }
 
+// writeFile is ioutil.WriteFile, except that it also sets the mode of an existing file
+func writeFile(filename string, data []byte, perm os.FileMode) error {
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if nil != err {
+		return err
+	}
+	if err := f.Chmod(perm); nil != err {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(data); nil != err {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
 type ManageError struct {
 	Name   string
 	Hint   string
@@ -3,12 +3,18 @@ package manager
 import (
 	"bytes"
 	"fmt"
+	"html"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"text/template"
 
 	"git.rootprojects.org/root/serviceman/manager/static"
+	"git.rootprojects.org/root/serviceman/runner"
 	"git.rootprojects.org/root/serviceman/service"
 )
 
@@ -16,6 +22,9 @@ const (
 	srvExt      = ".plist"
 	srvSysPath  = "/Library/LaunchDaemons"
 	srvUserPath = "Library/LaunchAgents"
+
+	wrapperSysPath  = "/opt/serviceman/libexec"
+	wrapperUserPath = ".local/opt/serviceman/libexec"
 )
 
 var srvLen int
@@ -46,6 +55,12 @@ func start(conf *service.Service) error {
 			Must:     true,
 			Badwords: []string{"No such file or directory", "service already loaded"},
 		},
+		// RunAtLoad already started it, unless it's manual
+		Runnable{
+			Exec: "launchctl",
+			Args: []string{"start", rdns},
+			Must: false,
+		},
 	}
 
 	cmds = adjustPrivs(system, cmds)
@@ -108,8 +123,198 @@ func stop(conf *service.Service) error {
 	return nil
 }
 
+var (
+	launchdPID      = regexp.MustCompile(`"PID" = (\d+);`)
+	launchdLastExit = regexp.MustCompile(`"LastExitStatus" = (\d+);`)
+)
+
+// status is what launchctl list says of the job (or the instance's job)
+func status(conf *service.Service) ([]runner.InstanceStatus, error) {
+	if _, err := getService(conf.System, conf.Home, conf.ReverseDNS); nil != err {
+		return nil, err
+	}
+
+	st := runner.InstanceStatus{Name: conf.InstanceName(), State: runner.StateStopped}
+	list := adjustPrivs(conf.System, []Runnable{
+		Runnable{Exec: "launchctl", Args: []string{"list", conf.ReverseDNS}},
+	})[0]
+	out, err := exec.Command(list.Exec, list.Args...).Output()
+	if nil != err {
+		// it isn't loaded
+		return []runner.InstanceStatus{st}, nil
+	}
+
+	if m := launchdPID.FindSubmatch(out); nil != m {
+		st.State = runner.StateRunning
+		st.PID, _ = strconv.Atoi(string(m[1]))
+	} else {
+		// loaded, but not running (KeepAlive may yet start it again)
+		st.State = runner.StateExited
+	}
+	if m := launchdLastExit.FindSubmatch(out); nil != m && "0" != string(m[1]) {
+		st.LastExit = "exit status " + string(m[1])
+	}
+	return []runner.InstanceStatus{st}, nil
+}
+
+// history is only kept by the runner, as launchd keeps none
+func history(conf *service.Service) ([]runner.Run, error) {
+	if _, err := getService(conf.System, conf.Home, conf.ReverseDNS); nil != err {
+		return nil, err
+	}
+	return nil, fmt.Errorf("%q has no history: launchd doesn't keep one, and it hasn't been run by the runner", conf.InstanceName())
+}
+
+// plist is what the .plist template is rendered with
+type plist struct {
+	*service.Service
+	// Wrapper is the script that launchd should run in place of Exec, if any
+	Wrapper string
+	// Stdout and Stderr are the files that launchd should log to
+	Stdout string
+	Stderr string
+}
+
+// hasDependency is true when the dependency is another installed service
+// (there are no targets or other kinds of units to depend on)
+func hasDependency(c *service.Service, unit string) bool {
+	name := service.ServiceName(unit)
+	if "" == name {
+		return false
+	}
+	managed, others, _ := list(c)
+	for _, installed := range append(managed, others...) {
+		if strings.ToLower(name) == strings.ToLower(installed) {
+			return true
+		}
+	}
+	return false
+}
+
+// startMode reads the start mode back from RunAtLoad in the installed plist
+func startMode(conf *service.Service) (string, error) {
+	plistPath, err := getService(conf.System, conf.Home, conf.ReverseDNS)
+	if nil != err {
+		return "", err
+	}
+	b, err := ioutil.ReadFile(plistPath)
+	if nil != err {
+		return "", err
+	}
+	runAtLoad := regexp.MustCompile(`<key>RunAtLoad</key>\s*<true/>`)
+	if !runAtLoad.Match(b) {
+		return service.StartManual, nil
+	}
+	if conf.System {
+		return service.StartBoot, nil
+	}
+	return service.StartLogin, nil
+}
+
+// instances are the installed instances of a service, from their plists
+func instances(conf *service.Service) []string {
+	plistDir := srvSysPath
+	if !conf.System {
+		plistDir = filepath.Join(conf.Home, srvUserPath)
+	}
+	plists, _ := filepath.Glob(filepath.Join(plistDir, conf.ReverseDNS+"@*.plist"))
+	names := []string{}
+	for i := range plists {
+		_, instance := service.ParseInstance(strings.TrimSuffix(filepath.Base(plists[i]), srvExt))
+		names = append(names, instance)
+	}
+	return names
+}
+
+// logFiles are the StandardOutPath and StandardErrorPath of the installed plist
+// (or of each instance's plist)
+func logFiles(conf *service.Service) ([]string, error) {
+	names := []string{conf.ReverseDNS}
+	for _, instance := range instances(conf) {
+		names = append(names, conf.ReverseDNS+"@"+instance)
+	}
+
+	logPath := regexp.MustCompile(`<key>Standard(?:Out|Error)Path</key>\s*<string>([^<]*)</string>`)
+	files := []string{}
+	seen := map[string]bool{}
+	var err error
+	for _, name := range names {
+		plistPath, e := getService(conf.System, conf.Home, name)
+		if nil != e {
+			err = e
+			continue
+		}
+		b, e := ioutil.ReadFile(plistPath)
+		if nil != e {
+			err = e
+			continue
+		}
+		for _, m := range logPath.FindAllSubmatch(b, -1) {
+			path := html.UnescapeString(string(m[1]))
+			if "/dev/null" == path || seen[path] {
+				continue
+			}
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+	if 0 == len(files) && nil != err {
+		return nil, err
+	}
+	return files, nil
+}
+
+// i.e. <!-- Dependencies (...): postgresql.service network-online.target -->
+var launchdDependencies = regexp.MustCompile(`<!-- Dependencies [^:]*:(.*?) -->`)
+
+// dependencies are read back from the comment that the plist keeps them in
+// (launchd has no notion of dependencies, but serviceman start and stop do),
+// or from the first instance's plist, if the service has instances
+func dependencies(conf *service.Service) ([]string, error) {
+	plistPath, err := getService(conf.System, conf.Home, conf.ReverseDNS)
+	if nil != err {
+		names := instances(conf)
+		if 0 == len(names) {
+			return nil, err
+		}
+		plistPath, err = getService(conf.System, conf.Home, conf.ReverseDNS+"@"+names[0])
+		if nil != err {
+			return nil, err
+		}
+	}
+	b, err := ioutil.ReadFile(plistPath)
+	if nil != err {
+		return nil, err
+	}
+	m := launchdDependencies.FindSubmatch(b)
+	if nil == m {
+		return []string{}, nil
+	}
+	return strings.Fields(string(m[1])), nil
+}
+
+func security(conf *service.Service) (*SecurityReport, error) {
+	return nil, fmt.Errorf("sandboxing is only supported by systemd")
+}
+
 // Render will create a launchd .plist file using the simple internal template
 func Render(c *service.Service) ([]byte, error) {
+	defaultLimits(c)
+	p := plist{Service: withDirectoryEnvs(c)}
+	switch c.LogDestination() {
+	case "", service.LogFile:
+		p.Stdout = c.StdoutPath()
+		p.Stderr = c.StderrPath()
+	case service.LogNone:
+		p.Stdout = "/dev/null"
+		p.Stderr = "/dev/null"
+	default:
+		return nil, fmt.Errorf("launchd can only log to a file (or none), not %s", c.LogDestination())
+	}
+	if needsWrapper(c) {
+		p.Wrapper = wrapperPath(c)
+	}
+
 	// Create service file from template
 	b, err := static.ReadFile("dist/Library/LaunchDaemons/_rdns_.plist.tmpl")
 	if err != nil {
@@ -122,7 +327,7 @@ func Render(c *service.Service) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	err = tmpl.Execute(rw, c)
+	err = tmpl.Execute(rw, p)
 	if nil != err {
 		return nil, err
 	}
@@ -130,6 +335,120 @@ func Render(c *service.Service) ([]byte, error) {
 	return rw.Bytes(), nil
 }
 
+// defaultLimits keeps the limits that Production has always meant
+func defaultLimits(c *service.Service) {
+	if c.Production && 0 == c.Limits.OpenFiles {
+		c.Limits.OpenFiles = 8192
+	}
+}
+
+// launchd doesn't know about hooks, and always stops with SIGTERM,
+// so those need a shell script in between
+func needsWrapper(c *service.Service) bool {
+	if "" != c.StopSignal && "SIGTERM" != c.StopSignal {
+		return true
+	}
+	// launchd doesn't read env files, or have anywhere to keep secrets
+	if len(c.EnvFiles) > 0 || len(c.Secrets) > 0 {
+		return true
+	}
+	return !c.Hooks.Empty()
+}
+
+func wrapperPath(c *service.Service) string {
+	dir := wrapperSysPath
+	if !c.System {
+		dir = filepath.Join(c.Home, wrapperUserPath)
+	}
+	return filepath.Join(dir, c.ReverseDNS+".sh")
+}
+
+// renderWrapper will create the shell script that launchd runs in place of the service
+func renderWrapper(c *service.Service) ([]byte, error) {
+	b, err := static.ReadFile("dist/opt/serviceman/libexec/_rdns_.sh.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	rw := &bytes.Buffer{}
+	tmpl, err := template.New("wrapper").Funcs(template.FuncMap{
+		"sh":      shQuote,
+		"shcmd":   shCommand,
+		"signame": shSignal,
+		"envfile": shEnvFile,
+	}).Parse(string(b))
+	if err != nil {
+		return nil, err
+	}
+	err = tmpl.Execute(rw, withDirectoryEnvs(c))
+	if nil != err {
+		return nil, err
+	}
+
+	return rw.Bytes(), nil
+}
+
+// withDirectoryEnvs sets STATE_DIRECTORY and friends, as systemd would,
+// unless Envs already has them
+func withDirectoryEnvs(c *service.Service) *service.Service {
+	if !c.HasDirectories() {
+		return c
+	}
+	dc := *c
+	dc.Envs = c.DirectoryEnvs()
+	for k, v := range c.Envs {
+		dc.Envs[k] = v
+	}
+	return &dc
+}
+
+// shQuote single-quotes a string for /bin/sh
+func shQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// shSignal is the name that kill -s expects (TERM rather than SIGTERM)
+func shSignal(sig string) string {
+	if "" == sig {
+		return "TERM"
+	}
+	return strings.TrimPrefix(sig, "SIG")
+}
+
+// shEnvFile loads an env file with the wrapper's load_env, which reads it as the runner would
+// (a missing file is an error, unless it's optional)
+func shEnvFile(path string) string {
+	path, optional := service.EnvFile(path)
+	if optional {
+		return "[ ! -e " + shQuote(path) + " ] || load_env " + shQuote(path) + " || exit 1"
+	}
+	return "load_env " + shQuote(path) + " || exit 1"
+}
+
+func shCommand(h service.Hook) string {
+	args := []string{shQuote(h.Exec)}
+	for i := range h.Argv {
+		args = append(args, shQuote(h.Argv[i]))
+	}
+	return strings.Join(args, " ")
+}
+
+func writeWrapper(c *service.Service) error {
+	b, err := renderWrapper(c)
+	if nil != err {
+		return err
+	}
+
+	wrapper := wrapperPath(c)
+	err = os.MkdirAll(filepath.Dir(wrapper), 0755)
+	if nil != err {
+		return err
+	}
+	if err := ioutil.WriteFile(wrapper, b, 0755); err != nil {
+		return fmt.Errorf("Error writing %s: %v", wrapper, err)
+	}
+	return nil
+}
+
 func install(c *service.Service) (string, error) {
 	// Darwin-specific config options
 	if c.PrivilegedPorts {
@@ -137,6 +456,14 @@ func install(c *service.Service) (string, error) {
 			return "", fmt.Errorf("You must use root-owned LaunchDaemons (not user-owned LaunchAgents) to use priveleged ports on OS X")
 		}
 	}
+	if c.Notify {
+		// sd_notify does nothing without NOTIFY_SOCKET, so the service still runs
+		fmt.Fprintf(os.Stderr, "Warning: launchd doesn't support sd_notify, so %q won't have a NOTIFY_SOCKET or a watchdog\n", c.Name)
+	}
+	if "" != c.Metrics {
+		// launchd runs the service itself, rather than the runner
+		fmt.Fprintf(os.Stderr, "Warning: only the runner serves metrics, so %q won't have any under launchd\n", c.Name)
+	}
 	plistDir := srvSysPath
 	if !c.System {
 		plistDir = filepath.Join(c.Home, srvUserPath)
@@ -148,6 +475,66 @@ func install(c *service.Service) (string, error) {
 		return "", err
 	}
 
+	// the user has to exist before it can own anything
+	err = provision(c)
+	if nil != err {
+		return "", err
+	}
+
+	err = writeSecrets(c)
+	if nil != err {
+		return "", err
+	}
+
+	if !c.Multi() {
+		return installPlist(c, plistDir)
+	}
+
+	// a plist for each instance, i.e. com.example.foo@1.plist
+	keep := map[string]bool{}
+	for _, instance := range c.InstanceNames() {
+		ic, err := c.ForInstance(instance)
+		if nil != err {
+			return "", err
+		}
+		keep[filepath.Join(plistDir, ic.ReverseDNS+".plist")] = true
+		if _, err := installPlist(ic, plistDir); nil != err {
+			return "", err
+		}
+	}
+
+	// unload and remove the instances that there are no longer
+	olds, _ := filepath.Glob(filepath.Join(plistDir, c.ReverseDNS+"@*.plist"))
+	for _, old := range olds {
+		if keep[old] {
+			continue
+		}
+		unload := adjustPrivs(c.System, []Runnable{
+			Runnable{
+				Exec: "launchctl",
+				Args: []string{"unload", "-w", old},
+				Must: false,
+			},
+		})
+		fmt.Println("\t" + unload[0].String())
+		_ = unload[0].Run()
+		_ = os.Remove(old)
+	}
+
+	return "launchd", nil
+}
+
+// installPlist writes the plist (and wrapper) for a service, or for one of its instances,
+// and loads it
+func installPlist(c *service.Service, plistDir string) (string, error) {
+	var err error
+	if needsWrapper(c) {
+		err = writeWrapper(c)
+		if nil != err {
+			return "", err
+		}
+	}
+
 	b, err := Render(c)
 	if nil != err {
 		return "", err
@@ -161,7 +548,30 @@ func install(c *service.Service) (string, error) {
 		return "", fmt.Errorf("Error writing %s: %v", plistPath, err)
 	}
 
-	// TODO --no-start
+	if service.StartManual == c.Mode() {
+		// load it, so that it can be started, but don't start it
+		cmds := adjustPrivs(c.System, []Runnable{
+			Runnable{
+				Exec: "launchctl",
+				Args: []string{"unload", "-w", plistPath},
+				Must: false,
+			},
+			Runnable{
+				Exec:     "launchctl",
+				Args:     []string{"load", "-w", plistPath},
+				Must:     true,
+				Badwords: []string{"No such file or directory", "service already loaded"},
+			},
+		})
+		for i := range cmds {
+			fmt.Println("\t" + cmds[i].String())
+			if err := cmds[i].Run(); nil != err {
+				return "", err
+			}
+		}
+		return "launchd", nil
+	}
+
 	err = start(c)
 	if nil != err {
 		fmt.Printf("If things don't go well you should be able to get additional logging from launchctl:\n")
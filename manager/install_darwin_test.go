@@ -0,0 +1,105 @@
+package manager
+
+import (
+	"strings"
+	"testing"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+func TestRenderLimits(t *testing.T) {
+	b, err := Render(&service.Service{
+		Name:       "foo",
+		ReverseDNS: "com.example.foo",
+		Exec:       "/usr/local/bin/foo",
+		Limits: service.Limits{
+			OpenFiles: 65536,
+			Processes: 64,
+			MemoryMax: "512M",
+			CoreSize:  "1M",
+		},
+	})
+	if nil != err {
+		t.Fatal(err)
+	}
+	plist := string(b)
+
+	// launchd has a soft and a hard limit for each, which are the same
+	limits := strings.Join([]string{
+		"\t<dict>",
+		"\t\t<key>NumberOfFiles</key>",
+		"\t\t<integer>65536</integer>",
+		"\t\t<key>NumberOfProcesses</key>",
+		"\t\t<integer>64</integer>",
+		"\t\t<key>Core</key>",
+		"\t\t<integer>1048576</integer>",
+		"\t\t<key>ResidentSetSize</key>",
+		"\t\t<integer>536870912</integer>",
+		"\t</dict>",
+	}, "\n")
+	for _, key := range []string{"SoftResourceLimits", "HardResourceLimits"} {
+		if !strings.Contains(plist, "<key>"+key+"</key>\n"+limits+"\n") {
+			t.Errorf("expected %s to be\n%s\nin:\n%s", key, limits, plist)
+		}
+	}
+
+	b, err = Render(&service.Service{Name: "foo", ReverseDNS: "com.example.foo", Exec: "/usr/local/bin/foo"})
+	if nil != err {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "ResourceLimits") {
+		t.Errorf("expected no limits without any being set:\n%s", b)
+	}
+}
+
+func TestRenderWrapperHooks(t *testing.T) {
+	b, err := renderWrapper(&service.Service{
+		Name:       "foo",
+		ReverseDNS: "com.example.foo",
+		Exec:       "/usr/local/bin/foo",
+		StopSignal: "SIGQUIT",
+		Hooks: service.Hooks{
+			PreStart: []service.Hook{
+				{Exec: "/usr/local/bin/migrate", Argv: []string{"--to", "latest"}},
+				{Exec: "/bin/echo", Argv: []string{"it's"}, IgnoreFailure: true},
+			},
+			PostStart: []service.Hook{{Exec: "/usr/local/bin/warm"}},
+			PreStop:   []service.Hook{{Exec: "/usr/local/bin/drain"}},
+			PostStop:  []service.Hook{{Exec: "/usr/local/bin/cleanup", IgnoreFailure: true}},
+		},
+	})
+	if nil != err {
+		t.Fatal(err)
+	}
+	wrapper := string(b)
+
+	// the pre-stop hooks run before the stop signal is sent, and the post-stop hooks
+	// run whether or not the service started (but a failed pre-start hook keeps it from starting)
+	for _, lines := range [][]string{
+		{
+			"on_stop() {",
+			"\t'/usr/local/bin/drain' || true",
+			"\tif [ -n \"$child\" ]; then",
+			"\t\tkill -s QUIT \"$child\" 2>/dev/null",
+		},
+		{
+			"post_stop() {",
+			"\t:",
+			"\t'/usr/local/bin/cleanup' || true",
+			"}",
+		},
+		{
+			"'/usr/local/bin/migrate' '--to' 'latest' || { post_stop; exit 1; }",
+			`'/bin/echo' 'it'\''s' || true`,
+			"'/usr/local/bin/foo' &",
+			"child=$!",
+			"",
+			"'/usr/local/bin/warm' || on_stop",
+		},
+	} {
+		expected := strings.Join(lines, "\n")
+		if !strings.Contains(wrapper, "\n"+expected+"\n") {
+			t.Errorf("expected\n%s\nin:\n%s", expected, wrapper)
+		}
+	}
+}
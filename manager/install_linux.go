@@ -5,10 +5,16 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"text/template"
+	"time"
 
 	"git.rootprojects.org/root/serviceman/manager/static"
+	"git.rootprojects.org/root/serviceman/runner"
 	"git.rootprojects.org/root/serviceman/service"
 )
 
@@ -29,12 +35,203 @@ func init() {
 	srvLen = len(srvExt)
 }
 
+// unitSysPaths and unitUserPaths are where systemd looks for units,
+// in addition to srvSysPath and srvUserPath
+var (
+	unitSysPaths  = []string{"/run/systemd/system", "/lib/systemd/system", "/usr/lib/systemd/system"}
+	unitUserPaths = []string{"/etc/systemd/user", "/usr/lib/systemd/user", "/lib/systemd/user"}
+)
+
+// hasDependency is true when the unit is installed where systemd would find it
+func hasDependency(c *service.Service, unit string) bool {
+	dirs := append([]string{srvSysPath}, unitSysPaths...)
+	if !c.System {
+		dirs = append([]string{filepath.Join(c.Home, srvUserPath)}, unitUserPaths...)
+	}
+
+	unit = service.UnitName(unit)
+	names := []string{unit}
+	// foo@bar.service comes from the template foo@.service
+	if i := strings.Index(unit, "@"); i > 0 {
+		names = append(names, unit[:i+1]+filepath.Ext(unit))
+	}
+	for _, dir := range dirs {
+		for _, name := range names {
+			if _, err := os.Stat(filepath.Join(dir, name)); nil == err {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// startMode reads the start mode back from WantedBy= in the installed unit
+func startMode(conf *service.Service) (string, error) {
+	servicePath, err := unitFile(conf.System, conf.Home, conf.Name)
+	if nil != err {
+		return "", err
+	}
+	b, err := ioutil.ReadFile(servicePath)
+	if nil != err {
+		return "", err
+	}
+
+	targets := []string{}
+	for _, line := range strings.Split(string(b), "\n") {
+		kv := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if 2 == len(kv) && "WantedBy" == kv[0] {
+			targets = append(targets, strings.Fields(kv[1])...)
+		}
+	}
+
+	installed := &service.Service{System: conf.System, StartMode: service.StartBoot}
+	if 0 == len(targets) {
+		return service.StartManual, nil
+	}
+	for i := range targets {
+		if "graphical-session.target" == targets[i] {
+			installed.StartMode = service.StartLogin
+		}
+	}
+	if strings.Join(installed.Targets(), " ") == strings.Join(targets, " ") {
+		return installed.StartMode, nil
+	}
+	return "WantedBy=" + strings.Join(targets, " "), nil
+}
+
+// dependencies are the After=, Requires=, and Wants= of an installed service
+func dependencies(conf *service.Service) ([]string, error) {
+	servicePath, err := unitFile(conf.System, conf.Home, conf.Name)
+	if nil != err {
+		return nil, err
+	}
+	b, err := ioutil.ReadFile(servicePath)
+	if nil != err {
+		return nil, err
+	}
+
+	deps := []string{}
+	for _, line := range strings.Split(string(b), "\n") {
+		kv := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if 2 != len(kv) {
+			continue
+		}
+		switch kv[0] {
+		case "After", "Requires", "Wants":
+			deps = append(deps, strings.Fields(kv[1])...)
+		}
+	}
+	return deps, nil
+}
+
+// logFiles are the files from StandardOutput=append: and StandardError=append:
+// in the installed unit (and none if it logs to the journal)
+func logFiles(conf *service.Service) ([]string, error) {
+	servicePath, err := unitFile(conf.System, conf.Home, conf.Name)
+	if nil != err {
+		return nil, err
+	}
+	b, err := ioutil.ReadFile(servicePath)
+	if nil != err {
+		return nil, err
+	}
+
+	files := []string{}
+	seen := map[string]bool{}
+	for _, line := range strings.Split(string(b), "\n") {
+		kv := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if 2 != len(kv) || ("StandardOutput" != kv[0] && "StandardError" != kv[0]) {
+			continue
+		}
+		parts := strings.SplitN(kv[1], ":", 2)
+		if 2 != len(parts) || ("append" != parts[0] && "file" != parts[0]) {
+			continue
+		}
+		paths := []string{parts[1]}
+		if strings.Contains(parts[1], "%i") {
+			paths = nil
+			for _, instance := range instances(conf) {
+				paths = append(paths, strings.Replace(parts[1], "%i", instance, -1))
+			}
+		}
+		for _, path := range paths {
+			if !seen[path] {
+				seen[path] = true
+				files = append(files, path)
+			}
+		}
+	}
+	return files, nil
+}
+
+// unitFile finds the installed unit, which for an instance (foo@1),
+// or a service with instances (foo), is the template unit (foo@.service)
+func unitFile(system bool, home string, name string) (string, error) {
+	base, instance := service.ParseInstance(name)
+	if "" != instance {
+		return getService(system, home, base+"@")
+	}
+	servicePath, err := getService(system, home, name)
+	if nil != err {
+		if templatePath, err := getService(system, home, name+"@"); nil == err {
+			return templatePath, nil
+		}
+	}
+	return servicePath, err
+}
+
+// enable reloads systemd, and enables the service to start (unless it's manual)
+func enable(conf *service.Service) error {
+	name := conf.ReverseDNS + ".service"
+	user := []string{}
+	if !conf.System {
+		user = []string{"--user"}
+	}
+
+	cmds := []Runnable{
+		Runnable{
+			Exec: "systemctl",
+			Args: append(user, "daemon-reload"),
+			Must: false,
+		},
+	}
+	if service.StartManual == conf.Mode() {
+		// in case it was enabled before
+		cmds = append(cmds, Runnable{
+			Exec: "systemctl",
+			Args: append(user, "disable", name),
+			Must: false,
+		})
+	} else {
+		cmds = append(cmds, Runnable{
+			Exec:     "systemctl",
+			Args:     append(user, "enable", name),
+			Badwords: []string{"not found", "failed"},
+			Must:     true,
+		})
+	}
+	cmds = adjustPrivs(conf.System, cmds)
+
+	fmt.Printf("Installing systemd service unit...\n\n")
+	for i := range cmds {
+		exe := cmds[i]
+		fmt.Println("\t" + exe.String())
+		err := exe.Run()
+		if nil != err {
+			return err
+		}
+	}
+	fmt.Println()
+
+	return nil
+}
+
 func start(conf *service.Service) error {
 	system := conf.System
 	home := conf.Home
 	name := conf.ReverseDNS
 
-	_, err := getService(system, home, name)
+	_, err := unitFile(system, home, name)
 	if nil != err {
 		return err
 	}
@@ -52,12 +249,6 @@ func start(conf *service.Service) error {
 				Args: []string{"stop", name + ".service"},
 				Must: false,
 			},
-			Runnable{
-				Exec:     "systemctl",
-				Args:     []string{"enable", name + ".service"},
-				Badwords: []string{"not found", "failed"},
-				Must:     true,
-			},
 			Runnable{
 				Exec:     "systemctl",
 				Args:     []string{"start", name + ".service"},
@@ -111,7 +302,7 @@ func stop(conf *service.Service) error {
 	home := conf.Home
 	name := conf.ReverseDNS
 
-	_, err := getService(system, home, name)
+	_, err := unitFile(system, home, name)
 	if nil != err {
 		return err
 	}
@@ -159,9 +350,103 @@ func stop(conf *service.Service) error {
 	return nil
 }
 
-// Render will create a systemd .service file using the simple internal template
+// status is what systemctl show says of the unit (or the instance's unit)
+func status(conf *service.Service) ([]runner.InstanceStatus, error) {
+	args := []string{"show", "-p", "LoadState", "-p", "ActiveState", "-p", "SubState", "-p", "MainPID",
+		"-p", "NRestarts", "-p", "Result", "-p", "StateChangeTimestamp", "-p", "StatusText", conf.ReverseDNS + ".service"}
+	if !conf.System {
+		args = append([]string{"--user"}, args...)
+	}
+	out, err := exec.Command("systemctl", args...).Output()
+	if nil != err {
+		return nil, fmt.Errorf("systemctl %s: %s", strings.Join(args, " "), err)
+	}
+
+	props := map[string]string{}
+	for _, line := range strings.Split(string(out), "\n") {
+		if i := strings.Index(line, "="); i > 0 {
+			props[line[:i]] = line[i+1:]
+		}
+	}
+	if "not-found" == props["LoadState"] {
+		return nil, fmt.Errorf("%q is not installed", conf.InstanceName())
+	}
+
+	st := runner.InstanceStatus{Name: conf.InstanceName()}
+	switch props["ActiveState"] {
+	case "active":
+		st.State = runner.StateRunning
+		if "exited" == props["SubState"] {
+			st.State = runner.StateExited
+		}
+	case "activating":
+		st.State = runner.StateStarting
+		if "auto-restart" == props["SubState"] {
+			st.State = runner.StateRestarting
+		}
+	case "deactivating":
+		st.State = runner.StateStopping
+	case "inactive":
+		st.State = runner.StateStopped
+	default:
+		// i.e. failed
+		st.State = props["ActiveState"]
+	}
+	st.PID, _ = strconv.Atoi(props["MainPID"])
+	st.Restarts, _ = strconv.Atoi(props["NRestarts"])
+	st.Status = props["StatusText"]
+	if result := props["Result"]; "" != result && "success" != result {
+		st.LastExit = result
+	}
+	if t, err := time.Parse("Mon 2006-01-02 15:04:05 MST", props["StateChangeTimestamp"]); nil == err {
+		st.Since = t
+	}
+	return []runner.InstanceStatus{st}, nil
+}
+
+// history is what the journal says of each invocation of the unit (or the instance's unit)
+func history(conf *service.Service) ([]runner.Run, error) {
+	unit := "--unit"
+	if !conf.System {
+		unit = "--user-unit"
+	}
+	args := []string{"--no-pager", "-o", "json", unit, conf.ReverseDNS + ".service"}
+	out, err := exec.Command("journalctl", args...).Output()
+	if nil != err {
+		return nil, fmt.Errorf("journalctl %s: %s", strings.Join(args, " "), err)
+	}
+	return parseJournal(conf.InstanceName(), bytes.NewReader(out))
+}
+
+func security(conf *service.Service) (*SecurityReport, error) {
+	servicePath, err := unitFile(conf.System, conf.Home, conf.ReverseDNS)
+	if nil != err {
+		return nil, err
+	}
+
+	b, err := ioutil.ReadFile(servicePath)
+	if nil != err {
+		return nil, err
+	}
+
+	return AnalyzeUnit(filepath.Base(servicePath), b), nil
+}
+
+// Render will create a systemd .service file using the simple internal template,
+// with any secret values masked
 func Render(c *service.Service) ([]byte, error) {
+	return render(c.Masked())
+}
+
+func render(c *service.Service) ([]byte, error) {
 	defaultUserGroup(c)
+	defaultLimits(c)
+	if c.Multi() {
+		// each instance logs to its own file, i.e. foo@%i.log
+		ic := *c
+		ic.Instance = "%i"
+		c = &ic
+	}
 
 	// Create service file from template
 	b, err := static.ReadFile("dist/etc/systemd/system/_name_.service.tmpl")
@@ -171,7 +456,11 @@ func Render(c *service.Service) ([]byte, error) {
 	s := string(b)
 	rw := &bytes.Buffer{}
 	// not sure what the template name does, but whatever
-	tmpl, err := template.New("service").Parse(s)
+	tmpl, err := template.New("service").Funcs(template.FuncMap{
+		"env":        systemdEnv,
+		"credential": systemdCredential,
+		"unit":       service.UnitName,
+	}).Parse(s)
 	if err != nil {
 		return nil, err
 	}
@@ -183,8 +472,35 @@ func Render(c *service.Service) ([]byte, error) {
 	return rw.Bytes(), nil
 }
 
+// systemdEnv quotes a variable for Environment=, which splits on spaces,
+// understands C-style escapes, and expands %-specifiers
+func systemdEnv(key, value string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\t", `\t`,
+		"%", "%%",
+	)
+	return `"` + r.Replace(key+"="+value) + `"`
+}
+
+// systemdCredential escapes a value for SetCredential=, which understands
+// C-style escapes and expands %-specifiers
+func systemdCredential(value string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		"%", "%%",
+	)
+	return r.Replace(value)
+}
+
 func install(c *service.Service) (string, error) {
 	defaultUserGroup(c)
+	if "" != c.Metrics {
+		// systemd runs the service itself, rather than the runner
+		fmt.Fprintf(os.Stderr, "Warning: only the runner serves metrics, so %q won't have any under systemd\n", c.Name)
+	}
 
 	// Check paths first
 	serviceDir := srvSysPath
@@ -196,19 +512,44 @@ func install(c *service.Service) (string, error) {
 		}
 	}
 
-	b, err := Render(c)
+	b, err := render(c)
 	if nil != err {
 		return "", err
 	}
 
-	// Write the file out
+	// Write the file out, readable only by root if it holds secret values
 	serviceName := c.Name + ".service"
+	if c.Multi() {
+		// a template unit, for foo@1.service, foo@2.service, etc
+		serviceName = c.Name + "@.service"
+	}
 	servicePath := filepath.Join(serviceDir, serviceName)
-	if err := ioutil.WriteFile(servicePath, b, 0644); err != nil {
+	mode := os.FileMode(0644)
+	if c.HasSecretValues() {
+		mode = 0600
+	}
+	if err := writeFile(servicePath, b, mode); err != nil {
 		return "", fmt.Errorf("Error writing %s: %v", servicePath, err)
 	}
 
-	// TODO --no-start
+	// the user and directories have to exist before the service starts
+	err = provision(c)
+	if nil != err {
+		return "", err
+	}
+
+	if c.Multi() {
+		return installInstances(c, serviceDir)
+	}
+
+	err = enable(c)
+	if nil != err {
+		return "", err
+	}
+	if service.StartManual == c.Mode() {
+		return "systemd", nil
+	}
+
 	err = start(c)
 	if nil != err {
 		sudo := ""
@@ -226,6 +567,88 @@ func install(c *service.Service) (string, error) {
 	return "systemd", nil
 }
 
+// installInstances writes a drop-in for each instance, with its environment,
+// and then enables and starts each one
+func installInstances(c *service.Service, serviceDir string) (string, error) {
+	keys := []string{}
+	for k := range c.Instances.Envs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	keep := map[string]bool{}
+	instances := []*service.Service{}
+	for _, instance := range c.InstanceNames() {
+		ic, err := c.ForInstance(instance)
+		if nil != err {
+			return "", err
+		}
+		instances = append(instances, ic)
+
+		dropin := filepath.Join(serviceDir, c.Name+"@"+instance+".service.d")
+		keep[dropin] = true
+		if err := os.MkdirAll(dropin, 0755); nil != err {
+			return "", err
+		}
+		b := &bytes.Buffer{}
+		fmt.Fprintf(b, "# Generated for serviceman. Edit as you wish, but leave this line.\n")
+		fmt.Fprintf(b, "# The environment for %s@%s\n[Service]\n", c.Name, instance)
+		for _, k := range keys {
+			fmt.Fprintf(b, "Environment=%s\n", systemdEnv(k, ic.Envs[k]))
+		}
+		confPath := filepath.Join(dropin, "instance.conf")
+		if err := writeFile(confPath, b.Bytes(), 0644); nil != err {
+			return "", fmt.Errorf("Error writing %s: %v", confPath, err)
+		}
+	}
+
+	// stop and disable the instances that there are no longer
+	olds, _ := filepath.Glob(filepath.Join(serviceDir, c.Name+"@*.service.d"))
+	for _, old := range olds {
+		if keep[old] {
+			continue
+		}
+		_, instance := service.ParseInstance(strings.TrimSuffix(filepath.Base(old), ".service.d"))
+		ic := *c
+		ic.Instance = instance
+		ic.ReverseDNS = c.ReverseDNS + "@" + instance
+		ic.StartMode = service.StartManual
+		_ = stop(&ic)
+		_ = enable(&ic)
+		_ = os.Remove(filepath.Join(old, "instance.conf"))
+		_ = os.Remove(old)
+	}
+
+	for _, ic := range instances {
+		if err := enable(ic); nil != err {
+			return "", err
+		}
+		if service.StartManual == ic.Mode() {
+			continue
+		}
+		if err := start(ic); nil != err {
+			return "", err
+		}
+	}
+
+	return "systemd", nil
+}
+
+// instances are the installed instances of a service, from their drop-ins
+func instances(conf *service.Service) []string {
+	serviceDir := srvSysPath
+	if !conf.System {
+		serviceDir = filepath.Join(conf.Home, srvUserPath)
+	}
+	dropins, _ := filepath.Glob(filepath.Join(serviceDir, conf.Name+"@*.service.d"))
+	names := []string{}
+	for i := range dropins {
+		_, instance := service.ParseInstance(strings.TrimSuffix(filepath.Base(dropins[i]), ".service.d"))
+		names = append(names, instance)
+	}
+	return names
+}
+
 func defaultUserGroup(c *service.Service) {
 	// Linux-specific config options
 	if c.System {
@@ -237,3 +660,17 @@ func defaultUserGroup(c *service.Service) {
 		c.Group = c.User
 	}
 }
+
+// defaultLimits keeps the limits that Production has always meant
+func defaultLimits(c *service.Service) {
+	if !c.Production {
+		return
+	}
+	// These are reasonable defaults for a production system.
+	if 0 == c.Limits.OpenFiles {
+		c.Limits.OpenFiles = 1048576
+	}
+	if 0 == c.Limits.Processes {
+		c.Limits.Processes = 64
+	}
+}
@@ -0,0 +1,426 @@
+package manager
+
+import (
+	"strings"
+	"testing"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+func renderUnit(t *testing.T, c *service.Service) string {
+	b, err := Render(c)
+	if nil != err {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+// hasLine is true if the unit has the line, as a whole line
+func hasLine(unit string, line string) bool {
+	for _, l := range strings.Split(unit, "\n") {
+		if line == l {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSystemdEnv(t *testing.T) {
+	tests := []struct {
+		key, value string
+		line       string
+	}{
+		{"FOO", "bar", `"FOO=bar"`},
+		{"FOO", "two words", `"FOO=two words"`},
+		{"FOO", `say "hi"`, `"FOO=say \"hi\""`},
+		{"FOO", `C:\temp`, `"FOO=C:\\temp"`},
+		{"FOO", "a\nb\tc", `"FOO=a\nb\tc"`},
+		{"FOO", "100%", `"FOO=100%%"`},
+		{"FOO", "$HOME", `"FOO=$HOME"`},
+	}
+	for _, tt := range tests {
+		if line := systemdEnv(tt.key, tt.value); tt.line != line {
+			t.Errorf("expected %s=%q to be %s, not %s", tt.key, tt.value, tt.line, line)
+		}
+	}
+}
+
+func TestSystemdCredential(t *testing.T) {
+	if s := systemdCredential(`50% C:\temp`); `50%% C:\\temp` != s {
+		t.Errorf("expected %% and \\ to be escaped, not %s", s)
+	}
+}
+
+func TestRenderLimits(t *testing.T) {
+	unit := renderUnit(t, &service.Service{
+		Name:   "foo",
+		Exec:   "/usr/bin/foo",
+		System: true,
+		Limits: service.Limits{
+			OpenFiles: 65536,
+			Processes: 64,
+			MemoryMax: "512M",
+			CPUQuota:  150,
+			CoreSize:  "infinity",
+			Tasks:     512,
+		},
+	})
+	for _, line := range []string{
+		"LimitNOFILE=65536",
+		"LimitNPROC=64",
+		"MemoryMax=512M",
+		"CPUQuota=150%",
+		"LimitCORE=infinity",
+		"TasksMax=512",
+	} {
+		if !hasLine(unit, line) {
+			t.Errorf("expected %s in:\n%s", line, unit)
+		}
+	}
+
+	// only what's set is limited
+	unit = renderUnit(t, &service.Service{
+		Name:   "foo",
+		Exec:   "/usr/bin/foo",
+		System: true,
+		Limits: service.Limits{MemoryMax: "1G"},
+	})
+	if !hasLine(unit, "MemoryMax=1G") {
+		t.Errorf("expected MemoryMax=1G in:\n%s", unit)
+	}
+	for _, key := range []string{"LimitNOFILE=", "LimitNPROC=", "CPUQuota=", "LimitCORE=", "TasksMax="} {
+		if strings.Contains(unit, key) {
+			t.Errorf("expected no %s in:\n%s", key, unit)
+		}
+	}
+
+	unit = renderUnit(t, &service.Service{Name: "foo", Exec: "/usr/bin/foo", System: true})
+	if strings.Contains(unit, "# Limit the number") {
+		t.Errorf("expected no limits without any being set:\n%s", unit)
+	}
+}
+
+func TestRenderHooks(t *testing.T) {
+	unit := renderUnit(t, &service.Service{
+		Name:   "foo",
+		Exec:   "/usr/bin/foo",
+		System: true,
+		Hooks: service.Hooks{
+			PreStart: []service.Hook{
+				{Exec: "/usr/bin/migrate", Argv: []string{"--to", "latest"}},
+				{Exec: "/bin/echo", Argv: []string{"two words"}, IgnoreFailure: true},
+			},
+			PostStart: []service.Hook{{Exec: "/usr/bin/warm"}},
+			PreStop:   []service.Hook{{Exec: "/usr/bin/drain", Argv: []string{`say "bye"`}}},
+			PostStop:  []service.Hook{{Exec: "/usr/bin/cleanup", IgnoreFailure: true}},
+		},
+	})
+
+	// in order, around ExecStart (and a failure is ignored with a leading -)
+	lines := []string{
+		"ExecStartPre=/usr/bin/migrate --to latest",
+		`ExecStartPre=-/bin/echo "two words"`,
+		"ExecStart=/usr/bin/foo",
+		"ExecStartPost=/usr/bin/warm",
+		`ExecStop=/usr/bin/drain "say \"bye\""`,
+		"ExecStopPost=-/usr/bin/cleanup",
+	}
+	last := -1
+	for n, line := range lines {
+		i := strings.Index(unit, "\n"+line+"\n")
+		if i < 0 {
+			t.Errorf("expected %s in:\n%s", line, unit)
+			continue
+		}
+		if i < last {
+			t.Errorf("expected %s to come after %s", line, lines[n-1])
+		}
+		last = i
+	}
+}
+
+func TestRenderStartModes(t *testing.T) {
+	tests := []struct {
+		name     string
+		system   bool
+		mode     string
+		wantedBy []string
+		line     string
+	}{
+		{"system", true, "", nil, "WantedBy=multi-user.target"},
+		{"system boot", true, service.StartBoot, nil, "WantedBy=multi-user.target"},
+		{"user", false, "", nil, "WantedBy=graphical-session.target"},
+		{"user login", false, service.StartLogin, nil, "WantedBy=graphical-session.target"},
+		{"user boot", false, service.StartBoot, nil, "WantedBy=default.target"},
+		{"wanted-by", true, "", []string{"foo.target", "bar"}, "WantedBy=foo.target bar.service"},
+		{"system manual", true, service.StartManual, nil, ""},
+		{"user manual", false, service.StartManual, []string{"foo.target"}, ""},
+	}
+	for _, tt := range tests {
+		unit := renderUnit(t, &service.Service{
+			Name:      "foo",
+			Exec:      "/usr/bin/foo",
+			System:    tt.system,
+			Home:      "/home/me",
+			StartMode: tt.mode,
+			WantedBy:  tt.wantedBy,
+		})
+		if "" == tt.line {
+			if strings.Contains(unit, "\n[Install]\n") || strings.Contains(unit, "WantedBy=") {
+				t.Errorf("%s: expected no [Install] section in:\n%s", tt.name, unit)
+			}
+			continue
+		}
+		install := strings.Index(unit, "\n[Install]\n")
+		if install < 0 || !hasLine(unit[install:], tt.line) {
+			t.Errorf("%s: expected [Install] to have %s in:\n%s", tt.name, tt.line, unit)
+		}
+		// (lingering starts the user's service manager on boot, but not the graphical session)
+		if linger := strings.Contains(unit, "enable-linger"); linger != ("user boot" == tt.name) {
+			t.Errorf("%s: expected enable-linger to be mentioned only for user boot, in:\n%s", tt.name, unit)
+		}
+	}
+}
+
+// section is the part of the unit from the line that starts with prefix
+// to the blank line after it
+func section(unit string, prefix string) string {
+	i := strings.Index(unit, "\n"+prefix)
+	if i < 0 {
+		return ""
+	}
+	s := unit[i+1:]
+	if j := strings.Index(s, "\n\n"); j >= 0 {
+		s = s[:j+1]
+	}
+	return s
+}
+
+func TestRenderSandbox(t *testing.T) {
+	basic := `# Sandboxing (basic profile); see ` + "`man systemd.exec`" + `.
+# To see what's still exposed, run: serviceman security foo
+# Use private /tmp and /var/tmp, which are discarded after the service stops.
+PrivateTmp=true
+# Use a minimal /dev
+PrivateDevices=true
+# Hide /home, /root, and /run/user. Nobody will steal your SSH-keys.
+ProtectHome=true
+# Make /usr, /boot, /etc (and, if strict, everything else) read-only.
+ProtectSystem=full
+# These merely retain r/w access rights, they do not add any new.
+# Must still be writable on the host!
+ReadWritePaths=-/opt/foo -/var/log/foo
+NoNewPrivileges=true
+`
+	strict := `# Sandboxing (strict profile); see ` + "`man systemd.exec`" + `.
+# To see what's still exposed, run: serviceman security foo
+# Use private /tmp and /var/tmp, which are discarded after the service stops.
+PrivateTmp=true
+# Use a minimal /dev
+PrivateDevices=true
+# Hide /home, /root, and /run/user. Nobody will steal your SSH-keys.
+ProtectHome=true
+# Make /usr, /boot, /etc (and, if strict, everything else) read-only.
+ProtectSystem=strict
+# These merely retain r/w access rights, they do not add any new.
+# Must still be writable on the host!
+ReadWritePaths=-/opt/foo -/var/log/foo
+NoNewPrivileges=true
+ProtectKernelTunables=true
+ProtectKernelModules=true
+ProtectControlGroups=true
+RestrictNamespaces=true
+RestrictRealtime=true
+RestrictSUIDSGID=true
+LockPersonality=true
+RestrictAddressFamilies=AF_UNIX AF_INET AF_INET6
+SystemCallFilter=@system-service
+`
+	// what's set adds to (or overrides) the profile
+	no := false
+	overridden := `# Sandboxing (strict profile); see ` + "`man systemd.exec`" + `.
+# To see what's still exposed, run: serviceman security foo
+# Use private /tmp and /var/tmp, which are discarded after the service stops.
+PrivateTmp=false
+# Use a minimal /dev
+PrivateDevices=true
+# Hide /home, /root, and /run/user. Nobody will steal your SSH-keys.
+ProtectHome=read-only
+# Make /usr, /boot, /etc (and, if strict, everything else) read-only.
+ProtectSystem=full
+# These merely retain r/w access rights, they do not add any new.
+# Must still be writable on the host!
+ReadWritePaths=-/opt/foo -/var/log/foo -/srv/foo
+ReadOnlyPaths=/etc/foo
+NoNewPrivileges=true
+ProtectKernelTunables=true
+ProtectKernelModules=true
+ProtectControlGroups=true
+RestrictNamespaces=true
+RestrictRealtime=true
+RestrictSUIDSGID=true
+LockPersonality=true
+RestrictAddressFamilies=AF_UNIX
+SystemCallFilter=@system-service
+`
+
+	tests := []struct {
+		name    string
+		sandbox service.Sandbox
+		golden  string
+	}{
+		{"none", service.Sandbox{Profile: service.SandboxNone}, ""},
+		{"basic", service.Sandbox{Profile: service.SandboxBasic}, basic},
+		{"strict", service.Sandbox{Profile: service.SandboxStrict}, strict},
+		{"overridden", service.Sandbox{
+			Profile:                 service.SandboxStrict,
+			PrivateTmp:              &no,
+			ProtectHome:             "read-only",
+			ProtectSystem:           "full",
+			ReadWritePaths:          []string{"/srv/foo"},
+			ReadOnlyPaths:           []string{"/etc/foo"},
+			RestrictAddressFamilies: []string{"AF_UNIX"},
+		}, overridden},
+	}
+	for _, tt := range tests {
+		unit := renderUnit(t, &service.Service{
+			Name:    "foo",
+			Exec:    "/usr/bin/foo",
+			System:  true,
+			User:    "foo",
+			Logdir:  "/var/log/foo",
+			Sandbox: tt.sandbox,
+		})
+		if s := section(unit, "# Sandboxing"); tt.golden != s {
+			t.Errorf("%s: expected the sandbox to be\n%s\nnot\n%s", tt.name, tt.golden, s)
+		}
+	}
+}
+
+func TestRenderCapabilities(t *testing.T) {
+	unit := renderUnit(t, &service.Service{
+		Name:            "foo",
+		Exec:            "/usr/bin/foo",
+		System:          true,
+		User:            "foo",
+		PrivilegedPorts: true,
+		Capabilities:    []string{"net_raw", "CAP_NET_BIND_SERVICE", "CAP_CHOWN"},
+	})
+	// each only once, with its full name, and both bounded and ambient
+	for _, line := range []string{
+		"CapabilityBoundingSet=CAP_NET_BIND_SERVICE CAP_NET_RAW CAP_CHOWN",
+		"AmbientCapabilities=CAP_NET_BIND_SERVICE CAP_NET_RAW CAP_CHOWN",
+		// (the capabilities it's given are all it gets)
+		"NoNewPrivileges=true",
+	} {
+		if !hasLine(unit, line) {
+			t.Errorf("expected %s in:\n%s", line, unit)
+		}
+	}
+
+	unit = renderUnit(t, &service.Service{Name: "foo", Exec: "/usr/bin/foo", System: true, User: "foo"})
+	if strings.Contains(unit, "CapabilityBoundingSet=") || strings.Contains(unit, "AmbientCapabilities=") {
+		t.Errorf("expected no capabilities without any being given:\n%s", unit)
+	}
+}
+
+func TestRenderDependencies(t *testing.T) {
+	deps := func(system bool) *service.Service {
+		return &service.Service{
+			Name:     "foo",
+			Exec:     "/usr/bin/foo",
+			System:   system,
+			Requires: []string{"db", "cache.service"},
+			Wants:    []string{"redis"},
+			After:    []string{"syslog.target", "db"},
+		}
+	}
+
+	// a system unit keeps waiting for the network, along with what it depends on
+	unit := renderUnit(t, deps(true))
+	for _, line := range []string{
+		"After=network-online.target db.service cache.service redis.service syslog.target",
+		"Wants=network-online.target systemd-networkd-wait-online.service redis.service",
+		"Requires=db.service cache.service",
+	} {
+		if !hasLine(unit, line) {
+			t.Errorf("expected %s in:\n%s", line, unit)
+		}
+	}
+
+	// a user unit has no network-online.target to wait for
+	unit = renderUnit(t, deps(false))
+	for _, line := range []string{
+		"After=db.service cache.service redis.service syslog.target",
+		"Wants=redis.service",
+		"Requires=db.service cache.service",
+	} {
+		if !hasLine(unit, line) {
+			t.Errorf("expected %s in:\n%s", line, unit)
+		}
+	}
+	if strings.Contains(unit, "network-online.target") {
+		t.Errorf("expected a user unit not to wait for the network:\n%s", unit)
+	}
+
+	unit = renderUnit(t, &service.Service{Name: "foo", Exec: "/usr/bin/foo", System: true})
+	for _, line := range []string{
+		"After=network-online.target",
+		"Wants=network-online.target systemd-networkd-wait-online.service",
+	} {
+		if !hasLine(unit, line) {
+			t.Errorf("expected %s in:\n%s", line, unit)
+		}
+	}
+	if strings.Contains(unit, "Requires=") {
+		t.Errorf("expected no Requires= without any dependencies:\n%s", unit)
+	}
+}
+
+func TestRenderLogFiles(t *testing.T) {
+	unit := renderUnit(t, &service.Service{
+		Name:    "foo",
+		Exec:    "/usr/bin/foo",
+		System:  true,
+		Logdir:  "/var/log/foo",
+		Logging: service.Logging{Destination: service.LogFile},
+	})
+	// (append: needs systemd v240, which the unit says)
+	lines := strings.Join([]string{
+		"# append: only works with systemd v240 or later; older versions ignore it",
+		"# (with a warning) and log to the journal, as with --log journal.",
+		"StandardOutput=append:/var/log/foo/foo.log",
+		"StandardError=append:/var/log/foo/foo.log",
+	}, "\n")
+	if !strings.Contains(unit, "\n"+lines+"\n") {
+		t.Errorf("expected\n%s\nin:\n%s", lines, unit)
+	}
+
+	unit = renderUnit(t, &service.Service{
+		Name:    "foo",
+		Exec:    "/usr/bin/foo",
+		System:  true,
+		Logging: service.Logging{Stdout: "/srv/foo/out.log", Stderr: "/srv/foo/err.log"},
+	})
+	for _, line := range []string{
+		"StandardOutput=append:/srv/foo/out.log",
+		"StandardError=append:/srv/foo/err.log",
+	} {
+		if !hasLine(unit, line) {
+			t.Errorf("expected %s in:\n%s", line, unit)
+		}
+	}
+
+	// and each instance has its own
+	unit = renderUnit(t, &service.Service{
+		Name:      "foo",
+		Exec:      "/usr/bin/foo",
+		System:    true,
+		Logdir:    "/var/log/foo",
+		Logging:   service.Logging{Destination: service.LogFile},
+		Instances: service.Instances{Count: 2},
+	})
+	if !hasLine(unit, "StandardOutput=append:/var/log/foo/foo@%i.log") {
+		t.Errorf("expected each instance to log to its own file:\n%s", unit)
+	}
+}
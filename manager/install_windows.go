@@ -99,6 +99,12 @@ func install(c *service.Service) (string, error) {
 		regSZ := bin + setArgs + strings.Join(c.Argv, " ")
 	*/
 
+	if service.StartManual == c.Mode() {
+		// in case it was set to start on login before
+		_ = k.DeleteValue(c.Title)
+		return "serviceman", nil
+	}
+
 	regSZ := fmt.Sprintf(`"%s" %s`, args[0], strings.Join(args[1:], " "))
 	if len(regSZ) > 260 {
 		return "", fmt.Errorf("data value is too long for registry entry")
@@ -113,24 +119,159 @@ func install(c *service.Service) (string, error) {
 	return "serviceman", err
 }
 
+// hasDependency is true when the dependency is another installed service
+// (there are no targets or other kinds of units to depend on)
+func hasDependency(c *service.Service, unit string) bool {
+	name := service.ServiceName(unit)
+	if "" == name {
+		return false
+	}
+	managed, others, _ := list(c)
+	for _, installed := range append(managed, others...) {
+		if strings.ToLower(name) == strings.ToLower(installed) {
+			return true
+		}
+	}
+	return false
+}
+
+// installed reads the runner's JSON config
+func installed(conf *service.Service) (*service.Service, error) {
+	args := getRunnerArgs(conf)
+	b, err := ioutil.ReadFile(args[len(args)-1])
+	if nil != err {
+		return nil, err
+	}
+	c := &service.Service{}
+	if err := json.Unmarshal(b, c); nil != err {
+		return nil, err
+	}
+	return c, nil
+}
+
+// dependencies are those in the runner's JSON config
+func dependencies(conf *service.Service) ([]string, error) {
+	c, err := installed(conf)
+	if nil != err {
+		return nil, err
+	}
+	return c.Dependencies(), nil
+}
+
+// logFiles are the runner's log files, from its JSON config
+func logFiles(conf *service.Service) ([]string, error) {
+	c, err := installed(conf)
+	if nil != err {
+		return nil, err
+	}
+	confs := []*service.Service{c}
+	if c.Multi() {
+		confs = nil
+		for _, instance := range c.InstanceNames() {
+			ic, err := c.ForInstance(instance)
+			if nil != err {
+				return nil, err
+			}
+			confs = append(confs, ic)
+		}
+	}
+
+	files := []string{}
+	seen := map[string]bool{}
+	for _, c := range confs {
+		// (there's no syslog on Windows, so the runner logs to files regardless)
+		paths := []string{c.StdoutPath(), c.StderrPath()}
+		if service.LogNone == c.LogDestination() {
+			// the runner still logs when it starts and stops the service
+			paths = []string{filepath.Join(c.Logdir, c.InstanceName()+".log")}
+		}
+		for _, path := range paths {
+			if !seen[path] {
+				seen[path] = true
+				files = append(files, path)
+			}
+		}
+	}
+	return files, nil
+}
+
+// instances are those in the runner's JSON config, which the runner
+// can start and stop one at a time
+func instances(conf *service.Service) []string {
+	c, err := installed(conf)
+	if nil != err {
+		return nil
+	}
+	return c.InstanceNames()
+}
+
+// startMode is the one in the runner's JSON config
+// (anything but manual means that it starts on login)
+func startMode(conf *service.Service) (string, error) {
+	c, err := installed(conf)
+	if nil != err {
+		return "", err
+	}
+	if service.StartManual == c.Mode() {
+		return service.StartManual, nil
+	}
+	return service.StartLogin, nil
+}
+
+// history is only kept by the runner, which hasn't run the service yet
+func history(conf *service.Service) ([]runner.Run, error) {
+	if _, err := installed(conf); nil != err {
+		return nil, err
+	}
+	return []runner.Run{}, nil
+}
+
+func security(conf *service.Service) (*SecurityReport, error) {
+	return nil, fmt.Errorf("sandboxing is only supported by systemd")
+}
+
+// Render will create the runner's JSON config, with any secret values masked
+// (the runner reads them from the secrets file instead)
 func Render(c *service.Service) ([]byte, error) {
-	b, err := json.Marshal(c)
+	b, err := json.Marshal(c.Masked())
 	if nil != err {
 		return nil, err
 	}
 	return b, nil
 }
 
+// start restarts the service if its runner is already running (or starts
+// the instance again, if it was stopped), or else starts a runner in the background
 func start(conf *service.Service) error {
+	if runner.Running(conf) {
+		return runner.Resume(conf)
+	}
 	args := getRunnerArgs(conf)
-	args = append(args, "--daemon")
-	return Run(args[0], args[1:]...)
+	pid, err := Daemon(conf, args[0], args[1:]...)
+	if nil != err {
+		return err
+	}
+	fmt.Printf("Started %q (runner pid %d)\n", conf.Name, pid)
+	return nil
 }
 
+// stop asks the runner to stop the service, and then to exit
 func stop(conf *service.Service) error {
 	return runner.Stop(conf)
 }
 
+// status is whatever the runner says, as the runner is all there is
+func status(conf *service.Service) ([]runner.InstanceStatus, error) {
+	st, err := runner.Status(conf)
+	if runner.ErrNotRunning == err {
+		if _, err := installed(conf); nil != err {
+			return nil, err
+		}
+		return []runner.InstanceStatus{{Name: conf.InstanceName(), State: runner.StateStopped}}, nil
+	}
+	return st, err
+}
+
 func list(c *service.Service) ([]string, []string, []error) {
 	var errs []error
 
@@ -311,6 +452,10 @@ func installServiceman(c *service.Service) ([]string, error) {
 		// this should be impossible, so we'll just panic
 		panic(err)
 	}
+	err = writeSecrets(c)
+	if nil != err {
+		return nil, err
+	}
 	err = os.MkdirAll(filepath.Dir(conffile), 0755)
 	if nil != err {
 		return nil, err
@@ -0,0 +1,139 @@
+package manager
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/runner"
+)
+
+// the MESSAGE_IDs of what systemd says of a unit
+// (see the systemd catalog, i.e. journalctl --catalog)
+const (
+	journalStarting     = "7d4958e842da4a758f6c1cdc7b36dcc5"
+	journalProcessExit  = "98e322203f7a4ed290d09fe03c09fe15"
+	journalRestartSched = "5eb03494b6584870a536b337290809b3"
+)
+
+// i.e. "Main process exited, code=killed, status=9/KILL"
+var journalExit = regexp.MustCompile(`Main process exited, code=(\w+), status=(\d+)(?:/(\w+))?`)
+
+// parseJournal turns the journal of a unit (as journalctl -o json writes it) into
+// a record of each of its runs, oldest first. Each invocation of the unit is a run,
+// and one that systemd scheduled a restart after is one that took the backoff path.
+func parseJournal(service string, r io.Reader) ([]runner.Run, error) {
+	runs := []runner.Run{}
+	byID := map[string]int{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		entry := map[string]interface{}{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); nil != err {
+			continue
+		}
+		// (a field that isn't valid UTF-8 is an array of bytes, and isn't of use here)
+		field := func(key string) string {
+			s, _ := entry[key].(string)
+			return s
+		}
+
+		// systemd tags what it says of the unit with the unit's invocation,
+		// and the journal tags what the service itself says with it
+		id, fromSystemd := field("INVOCATION_ID"), true
+		if "" == id {
+			id = field("USER_INVOCATION_ID")
+		}
+		if "" == id {
+			id, fromSystemd = field("_SYSTEMD_INVOCATION_ID"), false
+		}
+		// (the next run's "Starting..." may yet be tagged with the last one)
+		if "" == id || journalStarting == field("MESSAGE_ID") {
+			continue
+		}
+		usec, err := strconv.ParseInt(field("__REALTIME_TIMESTAMP"), 10, 64)
+		if nil != err {
+			continue
+		}
+		at := time.Unix(0, usec*int64(time.Microsecond))
+
+		i, ok := byID[id]
+		if !ok {
+			i = len(runs)
+			byID[id] = i
+			runs = append(runs, runner.Run{Service: service, RunID: id, Start: at, ExitCode: -1})
+		}
+		run := &runs[i]
+		if at.Before(run.Start) {
+			run.Start = at
+		}
+		// it ends when its process exits, whatever systemd says of it after
+		if "" == run.Exit && at.After(run.End) {
+			run.End = at
+		}
+
+		if !fromSystemd {
+			if 0 == run.PID {
+				run.PID, _ = strconv.Atoi(field("_PID"))
+			}
+			continue
+		}
+		if journalRestartSched == field("MESSAGE_ID") {
+			run.Backoff = true
+			continue
+		}
+		code, status, signal := field("EXIT_CODE"), field("EXIT_STATUS"), ""
+		if m := journalExit.FindStringSubmatch(field("MESSAGE")); nil != m {
+			code, status, signal = m[1], m[2], m[3]
+		} else if journalProcessExit != field("MESSAGE_ID") || "" == code {
+			continue
+		}
+		setExit(run, code, status, signal)
+		run.End = at
+	}
+	if err := scanner.Err(); nil != err {
+		return nil, err
+	}
+
+	for i := range runs {
+		runs[i].Duration = runs[i].End.Sub(runs[i].Start).Seconds()
+		if "" == runs[i].Exit {
+			// it's still running, or it was never started
+			runs[i].Exit = "no exit recorded"
+		}
+	}
+	return runs, nil
+}
+
+// setExit describes the exit of a run as the runner would,
+// from what systemd says of it (i.e. code=exited, status=1)
+func setExit(run *runner.Run, code, status, signal string) {
+	n, _ := strconv.Atoi(status)
+	switch code {
+	case "exited":
+		run.ExitCode = n
+		run.Exit = "exited cleanly"
+		if 0 != n {
+			run.Exit = "exit status " + status
+		}
+	case "killed", "dumped":
+		run.ExitCode = 128 + n
+		if "" != signal {
+			run.Signal = "SIG" + strings.TrimPrefix(signal, "SIG")
+		}
+		run.Exit = "signal: " + run.Signal
+		if "" == run.Signal {
+			run.Exit = "signal: " + status
+		}
+		if "dumped" == code {
+			run.Exit += " (core dumped)"
+		}
+	default:
+		run.Exit = code + " " + status
+	}
+}
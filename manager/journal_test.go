@@ -0,0 +1,64 @@
+package manager
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseJournal(t *testing.T) {
+	// two runs: one killed, which systemd restarted, and one still running
+	journal := strings.Join([]string{
+		`{"__REALTIME_TIMESTAMP":"1700000000000000","MESSAGE_ID":"7d4958e842da4a758f6c1cdc7b36dcc5","INVOCATION_ID":"aaa","MESSAGE":"Starting foo..."}`,
+		`{"__REALTIME_TIMESTAMP":"1700000000100000","MESSAGE_ID":"39f53479d3a045ac8e11786248231fbf","INVOCATION_ID":"aaa","MESSAGE":"Started foo."}`,
+		`{"__REALTIME_TIMESTAMP":"1700000000200000","_SYSTEMD_INVOCATION_ID":"aaa","_PID":"1234","MESSAGE":"listening on :3000"}`,
+		`{"__REALTIME_TIMESTAMP":"1700000002100000","MESSAGE_ID":"98e322203f7a4ed290d09fe03c09fe15","INVOCATION_ID":"aaa","EXIT_CODE":"killed","EXIT_STATUS":"9","MESSAGE":"foo.service: Main process exited, code=killed, status=9/KILL"}`,
+		`{"__REALTIME_TIMESTAMP":"1700000002200000","INVOCATION_ID":"aaa","MESSAGE":"foo.service: Failed with result 'signal'."}`,
+		`{"__REALTIME_TIMESTAMP":"1700000002300000","MESSAGE_ID":"5eb03494b6584870a536b337290809b3","INVOCATION_ID":"aaa","MESSAGE":"foo.service: Scheduled restart job, restart counter is at 1."}`,
+		`{"__REALTIME_TIMESTAMP":"1700000002300001","MESSAGE_ID":"7d4958e842da4a758f6c1cdc7b36dcc5","INVOCATION_ID":"aaa","MESSAGE":"Starting foo..."}`,
+		`{"__REALTIME_TIMESTAMP":"1700000002400000","MESSAGE_ID":"39f53479d3a045ac8e11786248231fbf","USER_INVOCATION_ID":"bbb","MESSAGE":"Started foo."}`,
+		`{"__REALTIME_TIMESTAMP":"1700000002500000","_SYSTEMD_INVOCATION_ID":"bbb","_PID":"1240","MESSAGE":[109,111,111]}`,
+		`not json`,
+	}, "\n")
+
+	runs, err := parseJournal("foo", strings.NewReader(journal))
+	if nil != err {
+		t.Fatal(err)
+	}
+	if 2 != len(runs) {
+		t.Fatalf("expected 2 runs, not %d: %#v", len(runs), runs)
+	}
+
+	killed := runs[0]
+	if "aaa" != killed.RunID || 1234 != killed.PID || "foo" != killed.Service {
+		t.Errorf("expected run aaa of foo, with pid 1234, not %#v", killed)
+	}
+	if "SIGKILL" != killed.Signal || 137 != killed.ExitCode || "signal: SIGKILL" != killed.Exit {
+		t.Errorf("expected it to have been killed, not %q (%d, %q)", killed.Exit, killed.ExitCode, killed.Signal)
+	}
+	if !killed.Backoff {
+		t.Errorf("expected the scheduled restart to count as backing off")
+	}
+	if 2.0 != killed.Duration {
+		t.Errorf("expected it to have run from Started to its exit (2s), not %fs", killed.Duration)
+	}
+
+	running := runs[1]
+	if "bbb" != running.RunID || 1240 != running.PID || running.Backoff {
+		t.Errorf("expected run bbb, with pid 1240, not %#v", running)
+	}
+	if -1 != running.ExitCode || "no exit recorded" != running.Exit {
+		t.Errorf("expected no exit yet, not %q (%d)", running.Exit, running.ExitCode)
+	}
+}
+
+func TestParseJournalExit(t *testing.T) {
+	// older versions of systemd only say so in the message
+	journal := `{"__REALTIME_TIMESTAMP":"1700000000000000","INVOCATION_ID":"ccc","MESSAGE":"foo.service: Main process exited, code=exited, status=3/NOTIMPLEMENTED"}`
+	runs, err := parseJournal("foo", strings.NewReader(journal))
+	if nil != err {
+		t.Fatal(err)
+	}
+	if 1 != len(runs) || 3 != runs[0].ExitCode || "exit status 3" != runs[0].Exit || "" != runs[0].Signal {
+		t.Fatalf("expected exit status 3, not %#v", runs)
+	}
+}
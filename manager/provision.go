@@ -0,0 +1,62 @@
+// +build !windows
+
+package manager
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// provisionDirs creates /opt/<name>, the log directory, and any other directories
+// that the service asks for, all owned by the service's user
+// (this is what tmpfiles.d does, where there's systemd)
+func provisionDirs(c *service.Service, dirs []string) error {
+	uid, gid, err := lookupOwner(c)
+	if nil != err {
+		return err
+	}
+
+	fmt.Printf("Creating directories for %s:%s...\n\n", c.User, c.Group)
+	for _, dir := range dirs {
+		fmt.Println("\t" + dir)
+		if err := os.MkdirAll(dir, 0755); nil != err {
+			return err
+		}
+		if err := os.Chown(dir, uid, gid); nil != err {
+			return err
+		}
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// ownedDirs are the directories that the service's user should own:
+// its home, its logs, and its state, cache, runtime, and logs directories
+func ownedDirs(c *service.Service) []string {
+	dirs := []string{filepath.Join("/opt", c.Name), c.Logdir}
+	return append(dirs, c.DirectoryPaths()...)
+}
+
+// lookupOwner finds the uid and gid of the service's user and group
+func lookupOwner(c *service.Service) (int, int, error) {
+	u, err := user.Lookup(c.User)
+	if nil != err {
+		return 0, 0, err
+	}
+	uid, _ := strconv.Atoi(u.Uid)
+	gid, _ := strconv.Atoi(u.Gid)
+	if "" != c.Group && c.Group != c.User {
+		g, err := user.LookupGroup(c.Group)
+		if nil != err {
+			return 0, 0, err
+		}
+		gid, _ = strconv.Atoi(g.Gid)
+	}
+	return uid, gid, nil
+}
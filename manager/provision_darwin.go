@@ -0,0 +1,114 @@
+package manager
+
+import (
+	"fmt"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// provision creates the user, group, and directories for a system service
+// that doesn't run as root (there's no sysusers.d or tmpfiles.d on macOS)
+func provision(c *service.Service) error {
+	if !c.Provisioned() {
+		return nil
+	}
+	if "" == c.Group {
+		c.Group = c.User
+	}
+
+	if err := addUser(c); nil != err {
+		return err
+	}
+	return provisionDirs(c, ownedDirs(c))
+}
+
+// addUser creates a hidden system user (and group) with dscl,
+// with an id from the range that macOS leaves for daemons
+func addUser(c *service.Service) error {
+	cmds := []Runnable{}
+
+	_, gerr := user.LookupGroup(c.Group)
+	_, uerr := user.Lookup(c.User)
+	if nil == gerr && nil == uerr {
+		return nil
+	}
+
+	id, err := freeID()
+	if nil != err {
+		return err
+	}
+	gid := strconv.Itoa(id)
+	if nil == gerr {
+		g, _ := user.LookupGroup(c.Group)
+		gid = g.Gid
+	} else {
+		group := "/Groups/" + c.Group
+		cmds = append(cmds,
+			Runnable{Exec: "dscl", Args: []string{".", "-create", group}, Must: true},
+			Runnable{Exec: "dscl", Args: []string{".", "-create", group, "PrimaryGroupID", gid}, Must: true},
+			Runnable{Exec: "dscl", Args: []string{".", "-create", group, "Password", "*"}, Must: true},
+		)
+	}
+	if nil != uerr {
+		u := "/Users/" + c.User
+		cmds = append(cmds,
+			Runnable{Exec: "dscl", Args: []string{".", "-create", u}, Must: true},
+			Runnable{Exec: "dscl", Args: []string{".", "-create", u, "UniqueID", strconv.Itoa(id)}, Must: true},
+			Runnable{Exec: "dscl", Args: []string{".", "-create", u, "PrimaryGroupID", gid}, Must: true},
+			Runnable{Exec: "dscl", Args: []string{".", "-create", u, "RealName", c.Title}, Must: true},
+			Runnable{Exec: "dscl", Args: []string{".", "-create", u, "NFSHomeDirectory", filepath.Join("/opt", c.Name)}, Must: true},
+			Runnable{Exec: "dscl", Args: []string{".", "-create", u, "UserShell", "/usr/bin/false"}, Must: true},
+			Runnable{Exec: "dscl", Args: []string{".", "-create", u, "Password", "*"}, Must: true},
+			Runnable{Exec: "dscl", Args: []string{".", "-create", u, "IsHidden", "1"}, Must: true},
+		)
+	}
+	cmds = adjustPrivs(true, cmds)
+
+	fmt.Printf("Creating user %s:%s...\n\n", c.User, c.Group)
+	for i := range cmds {
+		exe := cmds[i]
+		fmt.Println("\t" + exe.String())
+		err := exe.Run()
+		if nil != err {
+			return err
+		}
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// freeID finds an id, below 500, that's neither a uid nor a gid
+func freeID() (int, error) {
+	used := map[int]bool{}
+	for _, list := range [][]string{
+		[]string{"/Users", "UniqueID"},
+		[]string{"/Groups", "PrimaryGroupID"},
+	} {
+		out, err := exec.Command("dscl", ".", "-list", list[0], list[1]).Output()
+		if nil != err {
+			return 0, err
+		}
+		for _, line := range strings.Split(string(out), "\n") {
+			fields := strings.Fields(line)
+			if 2 != len(fields) {
+				continue
+			}
+			if n, err := strconv.Atoi(fields[1]); nil == err {
+				used[n] = true
+			}
+		}
+	}
+
+	for id := 499; id >= 300; id-- {
+		if !used[id] {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("there are no free user ids left below 500")
+}
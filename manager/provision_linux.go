@@ -0,0 +1,156 @@
+package manager
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"git.rootprojects.org/root/serviceman/manager/static"
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+var (
+	sysusersPath = "/etc/sysusers.d"
+	tmpfilesPath = "/etc/tmpfiles.d"
+)
+
+// provision creates the user, group, and directories for a system service
+// that doesn't run as root, with systemd-sysusers and systemd-tmpfiles
+// (or, where systemd is too old for those, with useradd and chown)
+func provision(c *service.Service) error {
+	if !c.Provisioned() {
+		return nil
+	}
+
+	sysusersConf := filepath.Join(sysusersPath, c.Name+".conf")
+	if err := writeTemplate("dist/etc/sysusers.d/_name_.conf.tmpl", sysusersConf, c); nil != err {
+		return err
+	}
+	tmpfilesConf := filepath.Join(tmpfilesPath, c.Name+".conf")
+	if err := writeTemplate("dist/etc/tmpfiles.d/_name_.conf.tmpl", tmpfilesConf, c); nil != err {
+		return err
+	}
+
+	if _, err := exec.LookPath("systemd-sysusers"); nil != err {
+		if err := addUser(c); nil != err {
+			return err
+		}
+		// systemd creates the state, cache, runtime, and logs directories itself
+		return provisionDirs(c, []string{filepath.Join("/opt", c.Name), c.Logdir})
+	}
+
+	cmds := adjustPrivs(true, []Runnable{
+		Runnable{
+			Exec: "systemd-sysusers",
+			Args: []string{sysusersConf},
+			Must: true,
+		},
+		Runnable{
+			Exec: "systemd-tmpfiles",
+			Args: []string{"--create", tmpfilesConf},
+			Must: true,
+		},
+	})
+
+	fmt.Printf("Creating user and directories for %s...\n\n", c.Name)
+	for i := range cmds {
+		exe := cmds[i]
+		fmt.Println("\t" + exe.String())
+		err := exe.Run()
+		if nil != err {
+			return err
+		}
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// addUser creates a system user (and group), as sysusers.d would
+func addUser(c *service.Service) error {
+	cmds := []Runnable{}
+	if _, err := user.LookupGroup(c.Group); nil != err {
+		cmds = append(cmds, Runnable{
+			Exec: "groupadd",
+			Args: []string{"--system", c.Group},
+			Must: true,
+		})
+	}
+	if _, err := user.Lookup(c.User); nil != err {
+		cmds = append(cmds, Runnable{
+			Exec: "useradd",
+			Args: []string{
+				"--system", "--gid", c.Group, "--no-create-home",
+				"--home-dir", filepath.Join("/opt", c.Name),
+				"--shell", "/usr/sbin/nologin",
+				"--comment", gecos(c.Title),
+				c.User,
+			},
+			Must: true,
+		})
+	}
+	cmds = adjustPrivs(true, cmds)
+
+	for i := range cmds {
+		exe := cmds[i]
+		fmt.Println("\t" + exe.String())
+		err := exe.Run()
+		if nil != err {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// gecos is the user's description (its title), without what can't be in
+// a passwd entry (: and newlines) or in a quoted sysusers.d field (" and \)
+func gecos(title string) string {
+	return strings.Map(func(r rune) rune {
+		if ':' == r || '"' == r || '\\' == r || unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, title)
+}
+
+// renderTemplate renders one of the internal templates
+func renderTemplate(tmplPath string, c *service.Service) ([]byte, error) {
+	b, err := static.ReadFile(tmplPath)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New(filepath.Base(tmplPath)).Funcs(template.FuncMap{
+		"gecos": gecos,
+	}).Parse(string(b))
+	if err != nil {
+		return nil, err
+	}
+	rw := &bytes.Buffer{}
+	if err := tmpl.Execute(rw, c); nil != err {
+		return nil, err
+	}
+	return rw.Bytes(), nil
+}
+
+// writeTemplate renders one of the internal templates out to a file
+func writeTemplate(tmplPath string, filename string, c *service.Service) error {
+	b, err := renderTemplate(tmplPath, c)
+	if nil != err {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); nil != err {
+		return err
+	}
+	if err := writeFile(filename, b, 0644); nil != err {
+		return fmt.Errorf("Error writing %s: %v", filename, err)
+	}
+	return nil
+}
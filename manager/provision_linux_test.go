@@ -0,0 +1,59 @@
+package manager
+
+import (
+	"testing"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+func TestRenderTmpfiles(t *testing.T) {
+	b, err := renderTemplate("dist/etc/tmpfiles.d/_name_.conf.tmpl", &service.Service{
+		Name:   "foo",
+		User:   "foo",
+		Group:  "www",
+		Logdir: "/var/log/foo",
+	})
+	if nil != err {
+		t.Fatal(err)
+	}
+	// (the same modes that provisionDirs uses, where there's no systemd-tmpfiles)
+	for _, line := range []string{
+		"d /opt/foo 0755 foo www -",
+		"d /var/log/foo 0755 foo www -",
+	} {
+		if !hasLine(string(b), line) {
+			t.Errorf("expected %s in:\n%s", line, b)
+		}
+	}
+}
+
+func TestRenderSysusers(t *testing.T) {
+	tests := []struct {
+		group string
+		title string
+		lines []string
+	}{
+		{"foo", "Foo", []string{`u foo - "Foo" /opt/foo`}},
+		{"www", "Foo", []string{"g www -", `u foo - "Foo" /opt/foo`, "m foo www"}},
+		{"foo", `Foo "the" \server: v2` + "\n", []string{`u foo - "Foo the server v2" /opt/foo`}},
+	}
+	for _, tt := range tests {
+		b, err := renderTemplate("dist/etc/sysusers.d/_name_.conf.tmpl", &service.Service{
+			Name:  "foo",
+			Title: tt.title,
+			User:  "foo",
+			Group: tt.group,
+		})
+		if nil != err {
+			t.Fatal(err)
+		}
+		for _, line := range tt.lines {
+			if !hasLine(string(b), line) {
+				t.Errorf("expected %s in:\n%s", line, b)
+			}
+		}
+		if "foo" == tt.group && (hasLine(string(b), "g foo -") || hasLine(string(b), "m foo foo")) {
+			t.Errorf("expected the user's own group to come with it, not:\n%s", b)
+		}
+	}
+}
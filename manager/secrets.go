@@ -0,0 +1,66 @@
+// +build !linux
+
+package manager
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// writeSecrets puts the secret values in an env file that only the service's user
+// can read, since (without systemd) there's nowhere else safe to keep them
+func writeSecrets(c *service.Service) error {
+	if !c.HasSecretValues() {
+		return nil
+	}
+
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "# Generated for serviceman. Secret values for %s.\n", c.Name)
+	for i := range c.Secrets {
+		secret := c.Secrets[i]
+		if "" == secret.Value {
+			continue
+		}
+		fmt.Fprintf(b, "%s=%s\n", secret.Name, quoteEnv(secret.Value))
+	}
+
+	secretsPath := c.SecretsPath()
+	err := os.MkdirAll(filepath.Dir(secretsPath), 0755)
+	if nil != err {
+		return err
+	}
+	if err := writeFile(secretsPath, b.Bytes(), 0600); nil != err {
+		return fmt.Errorf("Error writing %s: %v", secretsPath, err)
+	}
+
+	if !c.System || "" == c.User || "root" == c.User {
+		return nil
+	}
+	u, err := user.Lookup(c.User)
+	if nil != err {
+		return err
+	}
+	uid, _ := strconv.Atoi(u.Uid)
+	gid, _ := strconv.Atoi(u.Gid)
+	return os.Chown(secretsPath, uid, gid)
+}
+
+// quoteEnv double-quotes a value so that the runner (and launchd's wrapper)
+// read it back as-is, as ParseEnvFile would
+func quoteEnv(v string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		`$`, `\$`,
+		"`", "\\`",
+		"\n", `\n`,
+	)
+	return `"` + r.Replace(v) + `"`
+}
@@ -0,0 +1,29 @@
+// +build !linux
+
+package manager
+
+import (
+	"strings"
+	"testing"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+func TestQuoteEnv(t *testing.T) {
+	values := []string{
+		"plain",
+		"two words # not a comment",
+		`say "hi" to $USER with a \ and a ` + "`tick`",
+		"it's\non two lines",
+	}
+	for _, v := range values {
+		envs, err := service.ParseEnvFile(strings.NewReader("SECRET=" + quoteEnv(v) + "\n"))
+		if nil != err {
+			t.Errorf("expected %s to parse: %s", quoteEnv(v), err)
+			continue
+		}
+		if v != envs["SECRET"] {
+			t.Errorf("expected %q to be read back as-is, not %q", v, envs["SECRET"])
+		}
+	}
+}
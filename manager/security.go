@@ -0,0 +1,250 @@
+package manager
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// SecurityReport is an offline estimate of how exposed a systemd service is,
+// in the spirit of `systemd-analyze security` (which needs a running systemd).
+// Exposure goes from 0.0 (locked down) to 10.0 (anything goes).
+type SecurityReport struct {
+	Name     string
+	Checks   []SecurityCheck
+	Exposure float64
+}
+
+// SecurityCheck is one directive, what it protects against,
+// and how much it adds to the overall exposure
+type SecurityCheck struct {
+	Directive string
+	Value     string
+	Exposure  float64
+	// Description explains what is (or isn't) exposed
+	Description string
+}
+
+// Rating is the same word that systemd-analyze would use for the exposure
+func (r *SecurityReport) Rating() string {
+	switch {
+	case r.Exposure >= 10:
+		return "DANGEROUS"
+	case r.Exposure >= 9:
+		return "UNSAFE"
+	case r.Exposure >= 7.5:
+		return "EXPOSED"
+	case r.Exposure >= 5:
+		return "MEDIUM"
+	case r.Exposure >= 1:
+		return "OK"
+	case r.Exposure > 0:
+		return "SAFE"
+	default:
+		return "PERFECT"
+	}
+}
+
+func (r *SecurityReport) String() string {
+	var b strings.Builder
+	for i := range r.Checks {
+		c := r.Checks[i]
+		mark := "✓"
+		exposure := ""
+		if c.Exposure > 0 {
+			mark = "✗"
+			exposure = fmt.Sprintf("%.1f", c.Exposure)
+		}
+		fmt.Fprintf(&b, "  %s %-24s %-74s %s\n", mark, c.Directive+"=", c.Description, exposure)
+	}
+	fmt.Fprintf(&b, "\n→ Overall exposure level for %s: %.1f %s\n", r.Name, r.Exposure, r.Rating())
+	return b.String()
+}
+
+// securityCheck looks at the [Service] directives for one thing
+type securityCheck struct {
+	directive string
+	weight    int
+	protected string
+	exposed   string
+	// exposure is 0.0 when protected, 1.0 when not, or somewhere in between
+	exposure func(directives map[string]string) float64
+}
+
+var securityChecks = []securityCheck{
+	{"User", 10,
+		"Service runs as an unprivileged (or dynamic) user",
+		"Service runs as root, with access to everything",
+		func(d map[string]string) float64 {
+			if isYes(d["DynamicUser"]) {
+				return 0
+			}
+			if "" == d["User"] || "root" == d["User"] || "0" == d["User"] {
+				return 1
+			}
+			return 0
+		}},
+	{"CapabilityBoundingSet", 8,
+		"Service can't acquire capabilities outside of its bounding set",
+		"Service may acquire any capability, including CAP_SYS_ADMIN",
+		func(d map[string]string) float64 {
+			return exposedUnless("" != d["CapabilityBoundingSet"])
+		}},
+	{"NoNewPrivileges", 5,
+		"Service can't gain privileges through setuid binaries or file capabilities",
+		"Service can gain privileges through setuid binaries or file capabilities",
+		yesOrExposed("NoNewPrivileges")},
+	{"ProtectSystem", 6,
+		"Service has read-only access to the OS file hierarchy",
+		"Service has full access to the OS file hierarchy",
+		func(d map[string]string) float64 {
+			switch strings.ToLower(d["ProtectSystem"]) {
+			case "strict":
+				return 0
+			case "full":
+				return 0.2
+			case "yes", "true", "on", "1":
+				return 0.5
+			}
+			return 1
+		}},
+	{"ProtectHome", 5,
+		"Service has no access to home directories",
+		"Service has full access to home directories (and SSH keys)",
+		func(d map[string]string) float64 {
+			switch strings.ToLower(d["ProtectHome"]) {
+			case "yes", "true", "on", "1", "tmpfs":
+				return 0
+			case "read-only":
+				return 0.5
+			}
+			return 1
+		}},
+	{"PrivateTmp", 3,
+		"Service has no access to other software's temporary files",
+		"Service has access to other software's temporary files",
+		yesOrExposed("PrivateTmp")},
+	{"PrivateDevices", 5,
+		"Service has no access to hardware devices",
+		"Service potentially has access to hardware devices",
+		yesOrExposed("PrivateDevices")},
+	{"ProtectKernelTunables", 4,
+		"Service cannot alter kernel tunables (/proc/sys, …)",
+		"Service may alter kernel tunables",
+		yesOrExposed("ProtectKernelTunables")},
+	{"ProtectKernelModules", 4,
+		"Service cannot load or read kernel modules",
+		"Service may load or read kernel modules",
+		yesOrExposed("ProtectKernelModules")},
+	{"ProtectControlGroups", 3,
+		"Service cannot modify the control group file system",
+		"Service may modify the control group file system",
+		yesOrExposed("ProtectControlGroups")},
+	{"RestrictNamespaces", 4,
+		"Service cannot create namespaces",
+		"Service may create namespaces (and so containers)",
+		yesOrExposed("RestrictNamespaces")},
+	{"RestrictRealtime", 1,
+		"Service cannot acquire realtime scheduling",
+		"Service may acquire realtime scheduling and starve the system",
+		yesOrExposed("RestrictRealtime")},
+	{"RestrictSUIDSGID", 2,
+		"Service cannot create SUID/SGID files",
+		"Service may create SUID/SGID files",
+		yesOrExposed("RestrictSUIDSGID")},
+	{"LockPersonality", 1,
+		"Service cannot change the ABI personality",
+		"Service may change the ABI personality",
+		yesOrExposed("LockPersonality")},
+	{"RestrictAddressFamilies", 4,
+		"Service may only use the listed socket address families",
+		"Service may allocate sockets of any address family (netlink, packet, …)",
+		func(d map[string]string) float64 {
+			return exposedUnless("" != d["RestrictAddressFamilies"])
+		}},
+	{"SystemCallFilter", 6,
+		"Service may only use the listed system calls",
+		"Service may use any system call, including obscure and dangerous ones",
+		func(d map[string]string) float64 {
+			return exposedUnless("" != d["SystemCallFilter"])
+		}},
+	{"IPAddressDeny", 3,
+		"Service may only talk to the allowed IP addresses",
+		"Service may talk to any IP address",
+		func(d map[string]string) float64 {
+			return exposedUnless("" != d["IPAddressDeny"])
+		}},
+}
+
+func isYes(v string) bool {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "yes", "true", "on", "1":
+		return true
+	}
+	return false
+}
+
+func exposedUnless(protected bool) float64 {
+	if protected {
+		return 0
+	}
+	return 1
+}
+
+func yesOrExposed(directive string) func(map[string]string) float64 {
+	return func(d map[string]string) float64 {
+		return exposedUnless(isYes(d[directive]))
+	}
+}
+
+// AnalyzeUnit scores the [Service] section of a systemd unit file
+func AnalyzeUnit(name string, unit []byte) *SecurityReport {
+	directives := map[string]string{}
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(unit))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if "" == line || '#' == line[0] || ';' == line[0] {
+			continue
+		}
+		if '[' == line[0] {
+			section = line
+			continue
+		}
+		if "[Service]" != section {
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		if 2 != len(kv) {
+			continue
+		}
+		// as with systemd, the last one wins
+		directives[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+
+	var total float64
+	for _, sc := range securityChecks {
+		total += float64(sc.weight)
+	}
+
+	r := &SecurityReport{Name: name}
+	for _, sc := range securityChecks {
+		exposure := sc.exposure(directives)
+		c := SecurityCheck{
+			Directive:   sc.directive,
+			Value:       directives[sc.directive],
+			Exposure:    10 * exposure * float64(sc.weight) / total,
+			Description: sc.protected,
+		}
+		if exposure > 0 {
+			c.Description = sc.exposed
+		}
+		r.Exposure += c.Exposure
+		r.Checks = append(r.Checks, c)
+	}
+	r.Exposure = math.Round(10*r.Exposure) / 10
+
+	return r
+}
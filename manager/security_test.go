@@ -0,0 +1,48 @@
+package manager
+
+import (
+	"testing"
+)
+
+func TestAnalyzeUnit(t *testing.T) {
+	exposed := AnalyzeUnit("exposed.service", []byte(`
+[Service]
+ExecStart=/usr/bin/foo
+`))
+	if 10 != exposed.Exposure {
+		t.Fatalf("an unsandboxed root service should be fully exposed, not %.1f", exposed.Exposure)
+	}
+	if "DANGEROUS" != exposed.Rating() {
+		t.Fatalf("expected DANGEROUS, not %s", exposed.Rating())
+	}
+
+	sandboxed := AnalyzeUnit("sandboxed.service", []byte(`
+[Unit]
+# ProtectSystem isn't a [Unit] directive, so this shouldn't count
+ProtectSystem=strict
+
+[Service]
+User=foo
+ExecStart=/usr/bin/foo
+PrivateTmp=yes
+ProtectHome=read-only
+ProtectSystem=full
+; a later directive wins
+ProtectSystem=strict
+`))
+	if sandboxed.Exposure >= exposed.Exposure {
+		t.Fatalf("sandboxing should lower the exposure (%.1f >= %.1f)", sandboxed.Exposure, exposed.Exposure)
+	}
+	for _, c := range sandboxed.Checks {
+		switch c.Directive {
+		case "User", "PrivateTmp", "ProtectSystem":
+			if 0 != c.Exposure {
+				t.Errorf("%s=%s should not be exposed", c.Directive, c.Value)
+			}
+		case "ProtectHome":
+			if 0 == c.Exposure {
+				t.Errorf("ProtectHome=read-only should be partially exposed")
+			}
+		}
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"git.rootprojects.org/root/serviceman/service"
 )
 
 func getService(system bool, home string, name string) (string, error) {
@@ -234,6 +236,12 @@ func adjustPrivs(system bool, cmds []Runnable) []Runnable {
 	return cmds
 }
 
+// Daemon starts the runner (bin with args) in the background, detached from the terminal,
+// and waits for it to say that it has started (or why it couldn't), returning its pid
+func Daemon(conf *service.Service, bin string, args ...string) (int, error) {
+	return daemon(conf, bin, args...)
+}
+
 func Run(bin string, args ...string) error {
 	cmd := exec.Command(bin, args...)
 	// for debugging
@@ -1,5 +1,5 @@
-// Code generated by fileb0x at "2020-12-02 16:04:18.643924 -0700 MST m=+0.006447308" from config file "b0x.toml" DO NOT EDIT.
-// modification hash(9da9f7fe309e1c91778a98db0f1f7c61.acdb557394f98d3c09c0bb4d4b9142f8)
+// Code generated by fileb0x at "2026-10-19 11:23:55.014373567 +0000 UTC m=+0.002387542" from config file "b0x.toml" DO NOT EDIT.
+// modification hash(e040466ba07f9f3aa4ae10a1a1856a67.acdb557394f98d3c09c0bb4d4b9142f8)
 
 package static
 
@@ -36,10 +36,19 @@ type HTTPFS struct {
 }
 
 // FileDistLibraryLaunchDaemonsRdnsPlistTmpl is "dist/Library/LaunchDaemons/_rdns_.plist.tmpl"
-var FileDistLibraryLaunchDaemonsRdnsPlistTmpl = []byte("\x3c\x3f\x78\x6d\x6c\x20\x76\x65\x72\x73\x69\x6f\x6e\x3d\x22\x31\x2e\x30\x22\x20\x65\x6e\x63\x6f\x64\x69\x6e\x67\x3d\x22\x55\x54\x46\x2d\x38\x22\x3f\x3e\x0a\x3c\x21\x2d\x2d\x20\x47\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x66\x6f\x72\x20\x73\x65\x72\x76\x69\x63\x65\x6d\x61\x6e\x2e\x20\x45\x64\x69\x74\x20\x61\x73\x20\x79\x6f\x75\x20\x77\x69\x73\x68\x2c\x20\x62\x75\x74\x20\x6c\x65\x61\x76\x65\x20\x74\x68\x69\x73\x20\x6c\x69\x6e\x65\x2e\x20\x2d\x2d\x3e\x0a\x3c\x21\x44\x4f\x43\x54\x59\x50\x45\x20\x70\x6c\x69\x73\x74\x20\x50\x55\x42\x4c\x49\x43\x20\x22\x2d\x2f\x2f\x41\x70\x70\x6c\x65\x2f\x2f\x44\x54\x44\x20\x50\x4c\x49\x53\x54\x20\x31\x2e\x30\x2f\x2f\x45\x4e\x22\x20\x22\x68\x74\x74\x70\x3a\x2f\x2f\x77\x77\x77\x2e\x61\x70\x70\x6c\x65\x2e\x63\x6f\x6d\x2f\x44\x54\x44\x73\x2f\x50\x72\x6f\x70\x65\x72\x74\x79\x4c\x69\x73\x74\x2d\x31\x2e\x30\x2e\x64\x74\x64\x22\x3e\x0a\x3c\x70\x6c\x69\x73\x74\x20\x76\x65\x72\x73\x69\x6f\x6e\x3d\x22\x31\x2e\x30\x22\x3e\x0a\x3c\x64\x69\x63\x74\x3e\x0a\x09\x3c\x6b\x65\x79\x3e\x4c\x61\x62\x65\x6c\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x2e\x52\x65\x76\x65\x72\x73\x65\x44\x4e\x53\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x3c\x6b\x65\x79\x3e\x50\x72\x6f\x67\x72\x61\x6d\x41\x72\x67\x75\x6d\x65\x6e\x74\x73\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x61\x72\x72\x61\x79\x3e\x0a\x09\x09\x7b\x7b\x2d\x20\x69\x66\x20\x2e\x49\x6e\x74\x65\x72\x70\x72\x65\x74\x65\x72\x20\x7d\x7d\x0a\x09\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x2e\x49\x6e\x74\x65\x72\x70\x72\x65\x74\x65\x72\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x09\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x09\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x2e\x45\x78\x65\x63\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x09\x7b\x7b\x2d\x20\x72\x61\x6e\x67\x65\x20\x24\x61\x72\x67\x20\x3a\x3d\x20\x2e\x41\x72\x67\x76\x20\x7d\x7d\x0a\x09\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x24\x61\x72\x67\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x20\x20\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x09\x3c\x2f\x61\x72\x72\x61\x79\x3e\x0a\x09\x7b\x7b\x2d\x20\x69\x66\x20\x2e\x45\x6e\x76\x73\x20\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x45\x6e\x76\x69\x72\x6f\x6e\x6d\x65\x6e\x74\x56\x61\x72\x69\x61\x62\x6c\x65\x73\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x64\x69\x63\x74\x3e\x0a\x09\x09\x7b\x7b\x2d\x20\x72\x61\x6e\x67\x65\x20\x24\x6b\x65\x79\x2c\x20\x24\x76\x61\x6c\x75\x65\x20\x3a\x3d\x20\x2e\x45\x6e\x76\x73\x20\x7d\x7d\x0a\x09\x09\x3c\x6b\x65\x79\x3e\x7b\x7b\x20\x24\x6b\x65\x79\x20\x7d\x7d\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x24\x76\x61\x6c\x75\x65\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x09\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x09\x3c\x2f\x64\x69\x63\x74\x3e\x0a\x09\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x0a\x09\x7b\x7b\x69\x66\x20\x2e\x55\x73\x65\x72\x20\x2d\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x55\x73\x65\x72\x4e\x61\x6d\x65\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x2e\x55\x73\x65\x72\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x7b\x7b\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x09\x7b\x7b\x69\x66\x20\x2e\x47\x72\x6f\x75\x70\x20\x2d\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x47\x72\x6f\x75\x70\x4e\x61\x6d\x65\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x2e\x47\x72\x6f\x75\x70\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x3c\x6b\x65\x79\x3e\x49\x6e\x69\x74\x47\x72\x6f\x75\x70\x73\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x74\x72\x75\x65\x2f\x3e\x0a\x0a\x09\x7b\x7b\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x52\x75\x6e\x41\x74\x4c\x6f\x61\x64\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x74\x72\x75\x65\x2f\x3e\x0a\x09\x7b\x7b\x20\x69\x66\x20\x2e\x52\x65\x73\x74\x61\x72\x74\x20\x2d\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x4b\x65\x65\x70\x41\x6c\x69\x76\x65\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x74\x72\x75\x65\x2f\x3e\x0a\x09\x3c\x21\x2d\x2d\x64\x69\x63\x74\x3e\x0a\x09\x09\x3c\x6b\x65\x79\x3e\x43\x72\x61\x73\x68\x65\x64\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x09\x3c\x74\x72\x75\x65\x2f\x3e\x0a\x09\x09\x3c\x6b\x65\x79\x3e\x4e\x65\x74\x77\x6f\x72\x6b\x53\x74\x61\x74\x65\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x09\x3c\x74\x72\x75\x65\x2f\x3e\x0a\x09\x09\x3c\x6b\x65\x79\x3e\x53\x75\x63\x63\x65\x73\x73\x66\x75\x6c\x45\x78\x69\x74\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x09\x3c\x66\x61\x6c\x73\x65\x2f\x3e\x0a\x09\x3c\x2f\x64\x69\x63\x74\x2d\x2d\x3e\x0a\x0a\x09\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x09\x7b\x7b\x20\x69\x66\x20\x2e\x50\x72\x6f\x64\x75\x63\x74\x69\x6f\x6e\x20\x2d\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x53\x6f\x66\x74\x52\x65\x73\x6f\x75\x72\x63\x65\x4c\x69\x6d\x69\x74\x73\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x64\x69\x63\x74\x3e\x0a\x09\x09\x3c\x6b\x65\x79\x3e\x4e\x75\x6d\x62\x65\x72\x4f\x66\x46\x69\x6c\x65\x73\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x09\x3c\x69\x6e\x74\x65\x67\x65\x72\x3e\x38\x31\x39\x32\x3c\x2f\x69\x6e\x74\x65\x67\x65\x72\x3e\x0a\x09\x3c\x2f\x64\x69\x63\x74\x3e\x0a\x09\x3c\x6b\x65\x79\x3e\x48\x61\x72\x64\x52\x65\x73\x6f\x75\x72\x63\x65\x4c\x69\x6d\x69\x74\x73\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x64\x69\x63\x74\x2f\x3e\x0a\x0a\x09\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x09\x7b\x7b\x20\x69\x66\x20\x2e\x57\x6f\x72\x6b\x64\x69\x72\x20\x2d\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x57\x6f\x72\x6b\x69\x6e\x67\x44\x69\x72\x65\x63\x74\x6f\x72\x79\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x2e\x57\x6f\x72\x6b\x64\x69\x72\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x0a\x09\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x53\x74\x61\x6e\x64\x61\x72\x64\x45\x72\x72\x6f\x72\x50\x61\x74\x68\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x2e\x4c\x6f\x67\x64\x69\x72\x20\x7d\x7d\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x2e\x6c\x6f\x67\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x3c\x6b\x65\x79\x3e\x53\x74\x61\x6e\x64\x61\x72\x64\x4f\x75\x74\x50\x61\x74\x68\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x2e\x4c\x6f\x67\x64\x69\x72\x20\x7d\x7d\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x2e\x6c\x6f\x67\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x3c\x2f\x64\x69\x63\x74\x3e\x0a\x3c\x2f\x70\x6c\x69\x73\x74\x3e\x0a")
+var FileDistLibraryLaunchDaemonsRdnsPlistTmpl = []byte("\x3c\x3f\x78\x6d\x6c\x20\x76\x65\x72\x73\x69\x6f\x6e\x3d\x22\x31\x2e\x30\x22\x20\x65\x6e\x63\x6f\x64\x69\x6e\x67\x3d\x22\x55\x54\x46\x2d\x38\x22\x3f\x3e\x0a\x3c\x21\x2d\x2d\x20\x47\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x66\x6f\x72\x20\x73\x65\x72\x76\x69\x63\x65\x6d\x61\x6e\x2e\x20\x45\x64\x69\x74\x20\x61\x73\x20\x79\x6f\x75\x20\x77\x69\x73\x68\x2c\x20\x62\x75\x74\x20\x6c\x65\x61\x76\x65\x20\x74\x68\x69\x73\x20\x6c\x69\x6e\x65\x2e\x20\x2d\x2d\x3e\x0a\x3c\x21\x44\x4f\x43\x54\x59\x50\x45\x20\x70\x6c\x69\x73\x74\x20\x50\x55\x42\x4c\x49\x43\x20\x22\x2d\x2f\x2f\x41\x70\x70\x6c\x65\x2f\x2f\x44\x54\x44\x20\x50\x4c\x49\x53\x54\x20\x31\x2e\x30\x2f\x2f\x45\x4e\x22\x20\x22\x68\x74\x74\x70\x3a\x2f\x2f\x77\x77\x77\x2e\x61\x70\x70\x6c\x65\x2e\x63\x6f\x6d\x2f\x44\x54\x44\x73\x2f\x50\x72\x6f\x70\x65\x72\x74\x79\x4c\x69\x73\x74\x2d\x31\x2e\x30\x2e\x64\x74\x64\x22\x3e\x0a\x3c\x70\x6c\x69\x73\x74\x20\x76\x65\x72\x73\x69\x6f\x6e\x3d\x22\x31\x2e\x30\x22\x3e\x0a\x3c\x64\x69\x63\x74\x3e\x0a\x09\x7b\x7b\x2d\x20\x77\x69\x74\x68\x20\x2e\x44\x65\x70\x65\x6e\x64\x65\x6e\x63\x69\x65\x73\x20\x7d\x7d\x0a\x09\x3c\x21\x2d\x2d\x20\x44\x65\x70\x65\x6e\x64\x65\x6e\x63\x69\x65\x73\x20\x28\x77\x68\x69\x63\x68\x20\x73\x65\x72\x76\x69\x63\x65\x6d\x61\x6e\x20\x73\x74\x61\x72\x74\x20\x61\x6e\x64\x20\x73\x74\x6f\x70\x20\x67\x6f\x20\x62\x79\x2c\x20\x61\x73\x20\x6c\x61\x75\x6e\x63\x68\x64\x20\x68\x61\x73\x20\x6e\x6f\x6e\x65\x29\x3a\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x2e\x20\x7d\x7d\x20\x7b\x7b\x20\x2e\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x20\x2d\x2d\x3e\x0a\x09\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x4c\x61\x62\x65\x6c\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x2e\x52\x65\x76\x65\x72\x73\x65\x44\x4e\x53\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x3c\x6b\x65\x79\x3e\x50\x72\x6f\x67\x72\x61\x6d\x41\x72\x67\x75\x6d\x65\x6e\x74\x73\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x61\x72\x72\x61\x79\x3e\x0a\x09\x09\x7b\x7b\x2d\x20\x69\x66\x20\x2e\x57\x72\x61\x70\x70\x65\x72\x20\x7d\x7d\x0a\x09\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x2f\x62\x69\x6e\x2f\x73\x68\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x2e\x57\x72\x61\x70\x70\x65\x72\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x09\x7b\x7b\x2d\x20\x65\x6c\x73\x65\x20\x7d\x7d\x0a\x09\x09\x7b\x7b\x2d\x20\x69\x66\x20\x2e\x49\x6e\x74\x65\x72\x70\x72\x65\x74\x65\x72\x20\x7d\x7d\x0a\x09\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x2e\x49\x6e\x74\x65\x72\x70\x72\x65\x74\x65\x72\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x09\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x09\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x2e\x45\x78\x65\x63\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x09\x7b\x7b\x2d\x20\x72\x61\x6e\x67\x65\x20\x24\x61\x72\x67\x20\x3a\x3d\x20\x2e\x41\x72\x67\x76\x20\x7d\x7d\x0a\x09\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x24\x61\x72\x67\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x20\x20\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x09\x09\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x09\x3c\x2f\x61\x72\x72\x61\x79\x3e\x0a\x09\x7b\x7b\x2d\x20\x69\x66\x20\x2e\x45\x6e\x76\x73\x20\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x45\x6e\x76\x69\x72\x6f\x6e\x6d\x65\x6e\x74\x56\x61\x72\x69\x61\x62\x6c\x65\x73\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x64\x69\x63\x74\x3e\x0a\x09\x09\x7b\x7b\x2d\x20\x72\x61\x6e\x67\x65\x20\x24\x6b\x65\x79\x2c\x20\x24\x76\x61\x6c\x75\x65\x20\x3a\x3d\x20\x2e\x45\x6e\x76\x73\x20\x7d\x7d\x0a\x09\x09\x3c\x6b\x65\x79\x3e\x7b\x7b\x20\x24\x6b\x65\x79\x20\x7c\x20\x68\x74\x6d\x6c\x20\x7d\x7d\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x24\x76\x61\x6c\x75\x65\x20\x7c\x20\x68\x74\x6d\x6c\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x09\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x09\x3c\x2f\x64\x69\x63\x74\x3e\x0a\x09\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x0a\x09\x7b\x7b\x69\x66\x20\x2e\x55\x73\x65\x72\x20\x2d\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x55\x73\x65\x72\x4e\x61\x6d\x65\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x2e\x55\x73\x65\x72\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x7b\x7b\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x09\x7b\x7b\x69\x66\x20\x2e\x47\x72\x6f\x75\x70\x20\x2d\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x47\x72\x6f\x75\x70\x4e\x61\x6d\x65\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x2e\x47\x72\x6f\x75\x70\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x3c\x6b\x65\x79\x3e\x49\x6e\x69\x74\x47\x72\x6f\x75\x70\x73\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x74\x72\x75\x65\x2f\x3e\x0a\x0a\x09\x7b\x7b\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x09\x7b\x7b\x20\x69\x66\x20\x65\x71\x20\x2e\x4d\x6f\x64\x65\x20\x22\x6d\x61\x6e\x75\x61\x6c\x22\x20\x2d\x7d\x7d\x0a\x09\x3c\x21\x2d\x2d\x20\x53\x74\x61\x72\x74\x65\x64\x20\x6d\x61\x6e\x75\x61\x6c\x6c\x79\x2c\x20\x77\x69\x74\x68\x3a\x20\x73\x65\x72\x76\x69\x63\x65\x6d\x61\x6e\x20\x73\x74\x61\x72\x74\x20\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x20\x2d\x2d\x3e\x0a\x09\x3c\x6b\x65\x79\x3e\x52\x75\x6e\x41\x74\x4c\x6f\x61\x64\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x66\x61\x6c\x73\x65\x2f\x3e\x0a\x09\x7b\x7b\x20\x69\x66\x20\x2e\x52\x65\x73\x74\x61\x72\x74\x20\x2d\x7d\x7d\x0a\x09\x3c\x21\x2d\x2d\x20\x52\x65\x73\x74\x61\x72\x74\x20\x6f\x6e\x20\x66\x61\x69\x6c\x75\x72\x65\x2c\x20\x62\x75\x74\x20\x6f\x6e\x6c\x79\x20\x6f\x6e\x63\x65\x20\x69\x74\x20\x68\x61\x73\x20\x62\x65\x65\x6e\x20\x73\x74\x61\x72\x74\x65\x64\x20\x2d\x2d\x3e\x0a\x09\x3c\x6b\x65\x79\x3e\x4b\x65\x65\x70\x41\x6c\x69\x76\x65\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x64\x69\x63\x74\x3e\x0a\x09\x09\x3c\x6b\x65\x79\x3e\x53\x75\x63\x63\x65\x73\x73\x66\x75\x6c\x45\x78\x69\x74\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x09\x3c\x66\x61\x6c\x73\x65\x2f\x3e\x0a\x09\x3c\x2f\x64\x69\x63\x74\x3e\x0a\x0a\x09\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x09\x7b\x7b\x20\x65\x6c\x73\x65\x20\x2d\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x52\x75\x6e\x41\x74\x4c\x6f\x61\x64\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x74\x72\x75\x65\x2f\x3e\x0a\x09\x7b\x7b\x20\x69\x66\x20\x2e\x52\x65\x73\x74\x61\x72\x74\x20\x2d\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x4b\x65\x65\x70\x41\x6c\x69\x76\x65\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x74\x72\x75\x65\x2f\x3e\x0a\x09\x3c\x21\x2d\x2d\x64\x69\x63\x74\x3e\x0a\x09\x09\x3c\x6b\x65\x79\x3e\x43\x72\x61\x73\x68\x65\x64\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x09\x3c\x74\x72\x75\x65\x2f\x3e\x0a\x09\x09\x3c\x6b\x65\x79\x3e\x4e\x65\x74\x77\x6f\x72\x6b\x53\x74\x61\x74\x65\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x09\x3c\x74\x72\x75\x65\x2f\x3e\x0a\x09\x09\x3c\x6b\x65\x79\x3e\x53\x75\x63\x63\x65\x73\x73\x66\x75\x6c\x45\x78\x69\x74\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x09\x3c\x66\x61\x6c\x73\x65\x2f\x3e\x0a\x09\x3c\x2f\x64\x69\x63\x74\x2d\x2d\x3e\x0a\x0a\x09\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x09\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x09\x7b\x7b\x20\x69\x66\x20\x2e\x53\x74\x6f\x70\x54\x69\x6d\x65\x6f\x75\x74\x20\x2d\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x45\x78\x69\x74\x54\x69\x6d\x65\x4f\x75\x74\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x69\x6e\x74\x65\x67\x65\x72\x3e\x7b\x7b\x20\x2e\x53\x74\x6f\x70\x54\x69\x6d\x65\x6f\x75\x74\x20\x7d\x7d\x3c\x2f\x69\x6e\x74\x65\x67\x65\x72\x3e\x0a\x0a\x09\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x09\x7b\x7b\x20\x69\x66\x20\x6e\x6f\x74\x20\x2e\x4c\x69\x6d\x69\x74\x73\x2e\x45\x6d\x70\x74\x79\x20\x2d\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x53\x6f\x66\x74\x52\x65\x73\x6f\x75\x72\x63\x65\x4c\x69\x6d\x69\x74\x73\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x64\x69\x63\x74\x3e\x0a\x09\x09\x7b\x7b\x2d\x20\x74\x65\x6d\x70\x6c\x61\x74\x65\x20\x22\x6c\x69\x6d\x69\x74\x73\x22\x20\x2e\x4c\x69\x6d\x69\x74\x73\x20\x7d\x7d\x0a\x09\x3c\x2f\x64\x69\x63\x74\x3e\x0a\x09\x3c\x6b\x65\x79\x3e\x48\x61\x72\x64\x52\x65\x73\x6f\x75\x72\x63\x65\x4c\x69\x6d\x69\x74\x73\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x64\x69\x63\x74\x3e\x0a\x09\x09\x7b\x7b\x2d\x20\x74\x65\x6d\x70\x6c\x61\x74\x65\x20\x22\x6c\x69\x6d\x69\x74\x73\x22\x20\x2e\x4c\x69\x6d\x69\x74\x73\x20\x7d\x7d\x0a\x09\x3c\x2f\x64\x69\x63\x74\x3e\x0a\x0a\x09\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x09\x7b\x7b\x20\x69\x66\x20\x2e\x57\x6f\x72\x6b\x64\x69\x72\x20\x2d\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x57\x6f\x72\x6b\x69\x6e\x67\x44\x69\x72\x65\x63\x74\x6f\x72\x79\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x2e\x57\x6f\x72\x6b\x64\x69\x72\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x0a\x09\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x09\x3c\x6b\x65\x79\x3e\x53\x74\x61\x6e\x64\x61\x72\x64\x45\x72\x72\x6f\x72\x50\x61\x74\x68\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x2e\x53\x74\x64\x65\x72\x72\x20\x7c\x20\x68\x74\x6d\x6c\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x09\x3c\x6b\x65\x79\x3e\x53\x74\x61\x6e\x64\x61\x72\x64\x4f\x75\x74\x50\x61\x74\x68\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x3c\x73\x74\x72\x69\x6e\x67\x3e\x7b\x7b\x20\x2e\x53\x74\x64\x6f\x75\x74\x20\x7c\x20\x68\x74\x6d\x6c\x20\x7d\x7d\x3c\x2f\x73\x74\x72\x69\x6e\x67\x3e\x0a\x3c\x2f\x64\x69\x63\x74\x3e\x0a\x3c\x2f\x70\x6c\x69\x73\x74\x3e\x0a\x7b\x7b\x2d\x20\x64\x65\x66\x69\x6e\x65\x20\x22\x6c\x69\x6d\x69\x74\x73\x22\x20\x7d\x7d\x0a\x09\x09\x7b\x7b\x2d\x20\x69\x66\x20\x2e\x4f\x70\x65\x6e\x46\x69\x6c\x65\x73\x20\x7d\x7d\x0a\x09\x09\x3c\x6b\x65\x79\x3e\x4e\x75\x6d\x62\x65\x72\x4f\x66\x46\x69\x6c\x65\x73\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x09\x3c\x69\x6e\x74\x65\x67\x65\x72\x3e\x7b\x7b\x20\x2e\x4f\x70\x65\x6e\x46\x69\x6c\x65\x73\x20\x7d\x7d\x3c\x2f\x69\x6e\x74\x65\x67\x65\x72\x3e\x0a\x09\x09\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x09\x09\x7b\x7b\x2d\x20\x69\x66\x20\x2e\x50\x72\x6f\x63\x65\x73\x73\x65\x73\x20\x7d\x7d\x0a\x09\x09\x3c\x6b\x65\x79\x3e\x4e\x75\x6d\x62\x65\x72\x4f\x66\x50\x72\x6f\x63\x65\x73\x73\x65\x73\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x09\x3c\x69\x6e\x74\x65\x67\x65\x72\x3e\x7b\x7b\x20\x2e\x50\x72\x6f\x63\x65\x73\x73\x65\x73\x20\x7d\x7d\x3c\x2f\x69\x6e\x74\x65\x67\x65\x72\x3e\x0a\x09\x09\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x09\x09\x7b\x7b\x2d\x20\x69\x66\x20\x61\x6e\x64\x20\x2e\x43\x6f\x72\x65\x53\x69\x7a\x65\x20\x28\x6e\x65\x20\x2e\x43\x6f\x72\x65\x53\x69\x7a\x65\x20\x22\x69\x6e\x66\x69\x6e\x69\x74\x79\x22\x29\x20\x7d\x7d\x0a\x09\x09\x3c\x6b\x65\x79\x3e\x43\x6f\x72\x65\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x09\x3c\x69\x6e\x74\x65\x67\x65\x72\x3e\x7b\x7b\x20\x2e\x43\x6f\x72\x65\x53\x69\x7a\x65\x42\x79\x74\x65\x73\x20\x7d\x7d\x3c\x2f\x69\x6e\x74\x65\x67\x65\x72\x3e\x0a\x09\x09\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x09\x09\x7b\x7b\x2d\x20\x69\x66\x20\x61\x6e\x64\x20\x2e\x4d\x65\x6d\x6f\x72\x79\x4d\x61\x78\x20\x28\x6e\x65\x20\x2e\x4d\x65\x6d\x6f\x72\x79\x4d\x61\x78\x20\x22\x69\x6e\x66\x69\x6e\x69\x74\x79\x22\x29\x20\x7d\x7d\x0a\x09\x09\x3c\x6b\x65\x79\x3e\x52\x65\x73\x69\x64\x65\x6e\x74\x53\x65\x74\x53\x69\x7a\x65\x3c\x2f\x6b\x65\x79\x3e\x0a\x09\x09\x3c\x69\x6e\x74\x65\x67\x65\x72\x3e\x7b\x7b\x20\x2e\x4d\x65\x6d\x6f\x72\x79\x4d\x61\x78\x42\x79\x74\x65\x73\x20\x7d\x7d\x3c\x2f\x69\x6e\x74\x65\x67\x65\x72\x3e\x0a\x09\x09\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a")
 
 // FileDistEtcSystemdSystemNameServiceTmpl is "dist/etc/systemd/system/_name_.service.tmpl"
-var FileDistEtcSystemdSystemNameServiceTmpl = []byte("\x23\x20\x47\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x66\x6f\x72\x20\x73\x65\x72\x76\x69\x63\x65\x6d\x61\x6e\x2e\x20\x45\x64\x69\x74\x20\x61\x73\x20\x79\x6f\x75\x20\x77\x69\x73\x68\x2c\x20\x62\x75\x74\x20\x6c\x65\x61\x76\x65\x20\x74\x68\x69\x73\x20\x6c\x69\x6e\x65\x2e\x0a\x23\x20\x50\x72\x65\x2d\x72\x65\x71\x0a\x23\x20\x73\x75\x64\x6f\x20\x6d\x6b\x64\x69\x72\x20\x2d\x70\x20\x7b\x7b\x20\x2e\x4c\x6f\x63\x61\x6c\x20\x7d\x7d\x2f\x6f\x70\x74\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x2f\x20\x7b\x7b\x20\x2e\x4c\x6f\x63\x61\x6c\x20\x7d\x7d\x2f\x76\x61\x72\x2f\x6c\x6f\x67\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x53\x79\x73\x74\x65\x6d\x20\x2d\x7d\x7d\x0a\x7b\x7b\x2d\x20\x69\x66\x20\x61\x6e\x64\x20\x2e\x55\x73\x65\x72\x20\x28\x20\x6e\x65\x20\x22\x72\x6f\x6f\x74\x22\x20\x2e\x55\x73\x65\x72\x20\x29\x20\x2d\x7d\x7d\x0a\x23\x20\x73\x75\x64\x6f\x20\x61\x64\x64\x75\x73\x65\x72\x20\x7b\x7b\x20\x2e\x55\x73\x65\x72\x20\x7d\x7d\x20\x2d\x2d\x68\x6f\x6d\x65\x20\x2f\x6f\x70\x74\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x0a\x23\x20\x73\x75\x64\x6f\x20\x63\x68\x6f\x77\x6e\x20\x2d\x52\x20\x7b\x7b\x20\x2e\x55\x73\x65\x72\x20\x7d\x7d\x3a\x7b\x7b\x20\x2e\x47\x72\x6f\x75\x70\x20\x7d\x7d\x20\x2f\x6f\x70\x74\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x2f\x20\x2f\x76\x61\x72\x2f\x6c\x6f\x67\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x0a\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x23\x20\x50\x6f\x73\x74\x2d\x69\x6e\x73\x74\x61\x6c\x6c\x0a\x23\x20\x73\x75\x64\x6f\x20\x73\x79\x73\x74\x65\x6d\x63\x74\x6c\x20\x7b\x7b\x20\x69\x66\x20\x6e\x6f\x74\x20\x2e\x53\x79\x73\x74\x65\x6d\x20\x2d\x7d\x7d\x20\x2d\x2d\x75\x73\x65\x72\x20\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x20\x64\x61\x65\x6d\x6f\x6e\x2d\x72\x65\x6c\x6f\x61\x64\x0a\x23\x20\x73\x75\x64\x6f\x20\x73\x79\x73\x74\x65\x6d\x63\x74\x6c\x20\x7b\x7b\x20\x69\x66\x20\x6e\x6f\x74\x20\x2e\x53\x79\x73\x74\x65\x6d\x20\x2d\x7d\x7d\x20\x2d\x2d\x75\x73\x65\x72\x20\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x20\x72\x65\x73\x74\x61\x72\x74\x20\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x2e\x73\x65\x72\x76\x69\x63\x65\x0a\x23\x20\x73\x75\x64\x6f\x20\x6a\x6f\x75\x72\x6e\x61\x6c\x63\x74\x6c\x20\x7b\x7b\x20\x69\x66\x20\x6e\x6f\x74\x20\x2e\x53\x79\x73\x74\x65\x6d\x20\x2d\x7d\x7d\x20\x2d\x2d\x75\x73\x65\x72\x20\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x20\x2d\x78\x65\x66\x75\x20\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x0a\x0a\x5b\x55\x6e\x69\x74\x5d\x0a\x44\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x3d\x7b\x7b\x20\x2e\x54\x69\x74\x6c\x65\x20\x7d\x7d\x20\x7b\x7b\x20\x69\x66\x20\x2e\x44\x65\x73\x63\x20\x7d\x7d\x2d\x20\x7b\x7b\x20\x2e\x44\x65\x73\x63\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x55\x52\x4c\x20\x2d\x7d\x7d\x0a\x44\x6f\x63\x75\x6d\x65\x6e\x74\x61\x74\x69\x6f\x6e\x3d\x7b\x7b\x20\x2e\x55\x52\x4c\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x53\x79\x73\x74\x65\x6d\x20\x2d\x7d\x7d\x0a\x41\x66\x74\x65\x72\x3d\x6e\x65\x74\x77\x6f\x72\x6b\x2d\x6f\x6e\x6c\x69\x6e\x65\x2e\x74\x61\x72\x67\x65\x74\x0a\x57\x61\x6e\x74\x73\x3d\x6e\x65\x74\x77\x6f\x72\x6b\x2d\x6f\x6e\x6c\x69\x6e\x65\x2e\x74\x61\x72\x67\x65\x74\x20\x73\x79\x73\x74\x65\x6d\x64\x2d\x6e\x65\x74\x77\x6f\x72\x6b\x64\x2d\x77\x61\x69\x74\x2d\x6f\x6e\x6c\x69\x6e\x65\x2e\x73\x65\x72\x76\x69\x63\x65\x0a\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x5b\x53\x65\x72\x76\x69\x63\x65\x5d\x0a\x23\x20\x52\x65\x73\x74\x61\x72\x74\x20\x6f\x6e\x20\x63\x72\x61\x73\x68\x20\x28\x62\x61\x64\x20\x73\x69\x67\x6e\x61\x6c\x29\x2c\x20\x62\x75\x74\x20\x6e\x6f\x74\x20\x6f\x6e\x20\x27\x63\x6c\x65\x61\x6e\x27\x20\x66\x61\x69\x6c\x75\x72\x65\x20\x28\x65\x72\x72\x6f\x72\x20\x65\x78\x69\x74\x20\x63\x6f\x64\x65\x29\x0a\x23\x20\x41\x6c\x6c\x6f\x77\x20\x75\x70\x20\x74\x6f\x20\x33\x20\x72\x65\x73\x74\x61\x72\x74\x73\x20\x77\x69\x74\x68\x69\x6e\x20\x31\x30\x20\x73\x65\x63\x6f\x6e\x64\x73\x0a\x23\x20\x28\x69\x74\x27\x73\x20\x75\x6e\x6c\x69\x6b\x65\x6c\x79\x20\x74\x68\x61\x74\x20\x61\x20\x75\x73\x65\x72\x20\x6f\x72\x20\x70\x72\x6f\x70\x65\x72\x6c\x79\x2d\x72\x75\x6e\x6e\x69\x6e\x67\x20\x73\x63\x72\x69\x70\x74\x20\x77\x69\x6c\x6c\x20\x64\x6f\x20\x74\x68\x69\x73\x29\x0a\x52\x65\x73\x74\x61\x72\x74\x3d\x61\x6c\x77\x61\x79\x73\x0a\x53\x74\x61\x72\x74\x4c\x69\x6d\x69\x74\x49\x6e\x74\x65\x72\x76\x61\x6c\x3d\x31\x30\x0a\x53\x74\x61\x72\x74\x4c\x69\x6d\x69\x74\x42\x75\x72\x73\x74\x3d\x33\x0a\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x55\x73\x65\x72\x20\x2d\x7d\x7d\x0a\x23\x20\x55\x73\x65\x72\x20\x61\x6e\x64\x20\x67\x72\x6f\x75\x70\x20\x74\x68\x65\x20\x70\x72\x6f\x63\x65\x73\x73\x20\x77\x69\x6c\x6c\x20\x72\x75\x6e\x20\x61\x73\x0a\x55\x73\x65\x72\x3d\x7b\x7b\x20\x2e\x55\x73\x65\x72\x20\x7d\x7d\x0a\x47\x72\x6f\x75\x70\x3d\x7b\x7b\x20\x2e\x47\x72\x6f\x75\x70\x20\x7d\x7d\x0a\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x2d\x20\x69\x66\x20\x2e\x45\x6e\x76\x73\x20\x7d\x7d\x0a\x45\x6e\x76\x69\x72\x6f\x6e\x6d\x65\x6e\x74\x3d\x22\x7b\x7b\x2d\x20\x72\x61\x6e\x67\x65\x20\x24\x6b\x65\x79\x2c\x20\x24\x76\x61\x6c\x75\x65\x20\x3a\x3d\x20\x2e\x45\x6e\x76\x73\x20\x7d\x7d\x7b\x7b\x20\x24\x6b\x65\x79\x20\x7d\x7d\x3d\x7b\x7b\x20\x24\x76\x61\x6c\x75\x65\x20\x7d\x7d\x3b\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x22\x0a\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x57\x6f\x72\x6b\x64\x69\x72\x20\x2d\x7d\x7d\x0a\x57\x6f\x72\x6b\x69\x6e\x67\x44\x69\x72\x65\x63\x74\x6f\x72\x79\x3d\x7b\x7b\x20\x2e\x57\x6f\x72\x6b\x64\x69\x72\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x45\x78\x65\x63\x53\x74\x61\x72\x74\x3d\x7b\x7b\x69\x66\x20\x2e\x49\x6e\x74\x65\x72\x70\x72\x65\x74\x65\x72\x20\x7d\x7d\x7b\x7b\x20\x2e\x49\x6e\x74\x65\x72\x70\x72\x65\x74\x65\x72\x20\x7d\x7d\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x2e\x45\x78\x65\x63\x20\x7d\x7d\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x61\x72\x67\x20\x3a\x3d\x20\x2e\x41\x72\x67\x76\x20\x7d\x7d\x20\x7b\x7b\x20\x24\x61\x72\x67\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x45\x78\x65\x63\x52\x65\x6c\x6f\x61\x64\x3d\x2f\x62\x69\x6e\x2f\x6b\x69\x6c\x6c\x20\x2d\x55\x53\x52\x31\x20\x24\x4d\x41\x49\x4e\x50\x49\x44\x0a\x0a\x7b\x7b\x69\x66\x20\x2e\x50\x72\x6f\x64\x75\x63\x74\x69\x6f\x6e\x20\x2d\x7d\x7d\x0a\x23\x20\x4c\x69\x6d\x69\x74\x20\x74\x68\x65\x20\x6e\x75\x6d\x62\x65\x72\x20\x6f\x66\x20\x66\x69\x6c\x65\x20\x64\x65\x73\x63\x72\x69\x70\x74\x6f\x72\x73\x20\x61\x6e\x64\x20\x70\x72\x6f\x63\x65\x73\x73\x65\x73\x3b\x20\x73\x65\x65\x20\x60\x6d\x61\x6e\x20\x73\x79\x73\x74\x65\x6d\x64\x2e\x65\x78\x65\x63\x60\x20\x66\x6f\x72\x20\x6d\x6f\x72\x65\x20\x6c\x69\x6d\x69\x74\x20\x73\x65\x74\x74\x69\x6e\x67\x73\x2e\x0a\x23\x20\x54\x68\x65\x73\x65\x20\x61\x72\x65\x20\x72\x65\x61\x73\x6f\x6e\x61\x62\x6c\x65\x20\x64\x65\x66\x61\x75\x6c\x74\x73\x20\x66\x6f\x72\x20\x61\x20\x70\x72\x6f\x64\x75\x63\x74\x69\x6f\x6e\x20\x73\x79\x73\x74\x65\x6d\x2e\x0a\x23\x20\x4e\x6f\x74\x65\x3a\x20\x73\x79\x73\x74\x65\x6d\x64\x20\x22\x75\x73\x65\x72\x20\x75\x6e\x69\x74\x73\x22\x20\x64\x6f\x20\x6e\x6f\x74\x20\x73\x75\x70\x70\x6f\x72\x74\x20\x74\x68\x69\x73\x0a\x4c\x69\x6d\x69\x74\x4e\x4f\x46\x49\x4c\x45\x3d\x31\x30\x34\x38\x35\x37\x36\x0a\x4c\x69\x6d\x69\x74\x4e\x50\x52\x4f\x43\x3d\x36\x34\x0a\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x69\x66\x20\x2e\x4d\x75\x6c\x74\x69\x75\x73\x65\x72\x50\x72\x6f\x74\x65\x63\x74\x69\x6f\x6e\x20\x2d\x7d\x7d\x0a\x23\x20\x55\x73\x65\x20\x70\x72\x69\x76\x61\x74\x65\x20\x2f\x74\x6d\x70\x20\x61\x6e\x64\x20\x2f\x76\x61\x72\x2f\x74\x6d\x70\x2c\x20\x77\x68\x69\x63\x68\x20\x61\x72\x65\x20\x64\x69\x73\x63\x61\x72\x64\x65\x64\x20\x61\x66\x74\x65\x72\x20\x74\x68\x65\x20\x73\x65\x72\x76\x69\x63\x65\x20\x73\x74\x6f\x70\x73\x2e\x0a\x50\x72\x69\x76\x61\x74\x65\x54\x6d\x70\x3d\x74\x72\x75\x65\x0a\x23\x20\x55\x73\x65\x20\x61\x20\x6d\x69\x6e\x69\x6d\x61\x6c\x20\x2f\x64\x65\x76\x0a\x50\x72\x69\x76\x61\x74\x65\x44\x65\x76\x69\x63\x65\x73\x3d\x74\x72\x75\x65\x0a\x23\x20\x48\x69\x64\x65\x20\x2f\x68\x6f\x6d\x65\x2c\x20\x2f\x72\x6f\x6f\x74\x2c\x20\x61\x6e\x64\x20\x2f\x72\x75\x6e\x2f\x75\x73\x65\x72\x2e\x20\x4e\x6f\x62\x6f\x64\x79\x20\x77\x69\x6c\x6c\x20\x73\x74\x65\x61\x6c\x20\x79\x6f\x75\x72\x20\x53\x53\x48\x2d\x6b\x65\x79\x73\x2e\x0a\x50\x72\x6f\x74\x65\x63\x74\x48\x6f\x6d\x65\x3d\x74\x72\x75\x65\x0a\x23\x20\x4d\x61\x6b\x65\x20\x2f\x75\x73\x72\x2c\x20\x2f\x62\x6f\x6f\x74\x2c\x20\x2f\x65\x74\x63\x20\x61\x6e\x64\x20\x70\x6f\x73\x73\x69\x62\x6c\x79\x20\x73\x6f\x6d\x65\x20\x6d\x6f\x72\x65\x20\x66\x6f\x6c\x64\x65\x72\x73\x20\x72\x65\x61\x64\x2d\x6f\x6e\x6c\x79\x2e\x0a\x50\x72\x6f\x74\x65\x63\x74\x53\x79\x73\x74\x65\x6d\x3d\x66\x75\x6c\x6c\x0a\x23\x20\x2e\x2e\x2e\x20\x65\x78\x63\x65\x70\x74\x20\x2f\x6f\x70\x74\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x20\x62\x65\x63\x61\x75\x73\x65\x20\x77\x65\x20\x77\x61\x6e\x74\x20\x61\x20\x70\x6c\x61\x63\x65\x20\x66\x6f\x72\x20\x74\x68\x65\x20\x64\x61\x74\x61\x62\x61\x73\x65\x0a\x23\x20\x61\x6e\x64\x20\x2f\x76\x61\x72\x2f\x6c\x6f\x67\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x20\x62\x65\x63\x61\x75\x73\x65\x20\x77\x65\x20\x77\x61\x6e\x74\x20\x61\x20\x70\x6c\x61\x63\x65\x20\x77\x68\x65\x72\x65\x20\x6c\x6f\x67\x73\x20\x63\x61\x6e\x20\x67\x6f\x2e\x0a\x23\x20\x54\x68\x69\x73\x20\x6d\x65\x72\x65\x6c\x79\x20\x72\x65\x74\x61\x69\x6e\x73\x20\x72\x2f\x77\x20\x61\x63\x63\x65\x73\x73\x20\x72\x69\x67\x68\x74\x73\x2c\x20\x69\x74\x20\x64\x6f\x65\x73\x20\x6e\x6f\x74\x20\x61\x64\x64\x20\x61\x6e\x79\x20\x6e\x65\x77\x2e\x0a\x23\x20\x4d\x75\x73\x74\x20\x73\x74\x69\x6c\x6c\x20\x62\x65\x20\x77\x72\x69\x74\x61\x62\x6c\x65\x20\x6f\x6e\x20\x74\x68\x65\x20\x68\x6f\x73\x74\x21\x0a\x52\x65\x61\x64\x57\x72\x69\x74\x65\x44\x69\x72\x65\x63\x74\x6f\x72\x69\x65\x73\x3d\x2f\x6f\x70\x74\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x20\x2f\x76\x61\x72\x2f\x6c\x6f\x67\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x0a\x0a\x23\x20\x4e\x6f\x74\x65\x3a\x20\x69\x6e\x20\x76\x32\x33\x31\x20\x61\x6e\x64\x20\x61\x62\x6f\x76\x65\x20\x52\x65\x61\x64\x57\x72\x69\x74\x65\x50\x61\x74\x68\x73\x20\x68\x61\x73\x20\x62\x65\x65\x6e\x20\x72\x65\x6e\x61\x6d\x65\x64\x20\x74\x6f\x20\x52\x65\x61\x64\x57\x72\x69\x74\x65\x44\x69\x72\x65\x63\x74\x6f\x72\x69\x65\x73\x0a\x3b\x20\x52\x65\x61\x64\x57\x72\x69\x74\x65\x50\x61\x74\x68\x73\x3d\x2f\x6f\x70\x74\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x20\x2f\x76\x61\x72\x2f\x6c\x6f\x67\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x0a\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x69\x66\x20\x2e\x50\x72\x69\x76\x69\x6c\x65\x67\x65\x64\x50\x6f\x72\x74\x73\x20\x2d\x7d\x7d\x0a\x23\x20\x54\x68\x65\x20\x66\x6f\x6c\x6c\x6f\x77\x69\x6e\x67\x20\x61\x64\x64\x69\x74\x69\x6f\x6e\x61\x6c\x20\x73\x65\x63\x75\x72\x69\x74\x79\x20\x64\x69\x72\x65\x63\x74\x69\x76\x65\x73\x20\x6f\x6e\x6c\x79\x20\x77\x6f\x72\x6b\x20\x77\x69\x74\x68\x20\x73\x79\x73\x74\x65\x6d\x64\x20\x76\x32\x32\x39\x20\x6f\x72\x20\x6c\x61\x74\x65\x72\x2e\x0a\x23\x20\x54\x68\x65\x79\x20\x66\x75\x72\x74\x68\x65\x72\x20\x72\x65\x74\x72\x69\x63\x74\x20\x70\x72\x69\x76\x69\x6c\x65\x67\x65\x73\x20\x74\x68\x61\x74\x20\x63\x61\x6e\x20\x62\x65\x20\x67\x61\x69\x6e\x65\x64\x20\x62\x79\x20\x74\x68\x65\x20\x73\x65\x72\x76\x69\x63\x65\x2e\x0a\x23\x20\x4e\x6f\x74\x65\x20\x74\x68\x61\x74\x20\x79\x6f\x75\x20\x6d\x61\x79\x20\x68\x61\x76\x65\x20\x74\x6f\x20\x61\x64\x64\x20\x63\x61\x70\x61\x62\x69\x6c\x69\x74\x69\x65\x73\x20\x72\x65\x71\x75\x69\x72\x65\x64\x20\x62\x79\x20\x61\x6e\x79\x20\x70\x6c\x75\x67\x69\x6e\x73\x20\x69\x6e\x20\x75\x73\x65\x2e\x0a\x43\x61\x70\x61\x62\x69\x6c\x69\x74\x79\x42\x6f\x75\x6e\x64\x69\x6e\x67\x53\x65\x74\x3d\x43\x41\x50\x5f\x4e\x45\x54\x5f\x42\x49\x4e\x44\x5f\x53\x45\x52\x56\x49\x43\x45\x0a\x41\x6d\x62\x69\x65\x6e\x74\x43\x61\x70\x61\x62\x69\x6c\x69\x74\x69\x65\x73\x3d\x43\x41\x50\x5f\x4e\x45\x54\x5f\x42\x49\x4e\x44\x5f\x53\x45\x52\x56\x49\x43\x45\x0a\x4e\x6f\x4e\x65\x77\x50\x72\x69\x76\x69\x6c\x65\x67\x65\x73\x3d\x74\x72\x75\x65\x0a\x0a\x23\x20\x43\x61\x76\x65\x61\x74\x3a\x20\x53\x6f\x6d\x65\x20\x66\x65\x61\x74\x75\x72\x65\x73\x20\x6d\x61\x79\x20\x6e\x65\x65\x64\x20\x61\x64\x64\x69\x74\x69\x6f\x6e\x61\x6c\x20\x63\x61\x70\x61\x62\x69\x6c\x69\x74\x69\x65\x73\x2e\x0a\x23\x20\x46\x6f\x72\x20\x65\x78\x61\x6d\x70\x6c\x65\x20\x61\x6e\x20\x22\x75\x70\x6c\x6f\x61\x64\x22\x20\x6d\x61\x79\x20\x6e\x65\x65\x64\x20\x43\x41\x50\x5f\x4c\x45\x41\x53\x45\x0a\x3b\x20\x43\x61\x70\x61\x62\x69\x6c\x69\x74\x79\x42\x6f\x75\x6e\x64\x69\x6e\x67\x53\x65\x74\x3d\x43\x41\x50\x5f\x4e\x45\x54\x5f\x42\x49\x4e\x44\x5f\x53\x45\x52\x56\x49\x43\x45\x20\x43\x41\x50\x5f\x4c\x45\x41\x53\x45\x0a\x3b\x20\x41\x6d\x62\x69\x65\x6e\x74\x43\x61\x70\x61\x62\x69\x6c\x69\x74\x69\x65\x73\x3d\x43\x41\x50\x5f\x4e\x45\x54\x5f\x42\x49\x4e\x44\x5f\x53\x45\x52\x56\x49\x43\x45\x20\x43\x41\x50\x5f\x4c\x45\x41\x53\x45\x0a\x3b\x20\x4e\x6f\x4e\x65\x77\x50\x72\x69\x76\x69\x6c\x65\x67\x65\x73\x3d\x74\x72\x75\x65\x0a\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x5b\x49\x6e\x73\x74\x61\x6c\x6c\x5d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x53\x79\x73\x74\x65\x6d\x20\x2d\x7d\x7d\x0a\x57\x61\x6e\x74\x65\x64\x42\x79\x3d\x6d\x75\x6c\x74\x69\x2d\x75\x73\x65\x72\x2e\x74\x61\x72\x67\x65\x74\x0a\x7b\x7b\x2d\x20\x65\x6c\x73\x65\x20\x2d\x7d\x7d\x0a\x57\x61\x6e\x74\x65\x64\x42\x79\x3d\x64\x65\x66\x61\x75\x6c\x74\x2e\x74\x61\x72\x67\x65\x74\x0a\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a")
+var FileDistEtcSystemdSystemNameServiceTmpl = []byte("\x23\x20\x47\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x66\x6f\x72\x20\x73\x65\x72\x76\x69\x63\x65\x6d\x61\x6e\x2e\x20\x45\x64\x69\x74\x20\x61\x73\x20\x79\x6f\x75\x20\x77\x69\x73\x68\x2c\x20\x62\x75\x74\x20\x6c\x65\x61\x76\x65\x20\x74\x68\x69\x73\x20\x6c\x69\x6e\x65\x2e\x0a\x23\x20\x50\x72\x65\x2d\x72\x65\x71\x0a\x23\x20\x73\x75\x64\x6f\x20\x6d\x6b\x64\x69\x72\x20\x2d\x70\x20\x7b\x7b\x20\x2e\x4c\x6f\x63\x61\x6c\x20\x7d\x7d\x2f\x6f\x70\x74\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x2f\x20\x7b\x7b\x20\x2e\x4c\x6f\x63\x61\x6c\x20\x7d\x7d\x2f\x76\x61\x72\x2f\x6c\x6f\x67\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x50\x72\x6f\x76\x69\x73\x69\x6f\x6e\x65\x64\x20\x2d\x7d\x7d\x0a\x23\x20\x54\x68\x65\x20\x75\x73\x65\x72\x20\x61\x6e\x64\x20\x64\x69\x72\x65\x63\x74\x6f\x72\x69\x65\x73\x20\x61\x72\x65\x20\x66\x72\x6f\x6d\x20\x28\x73\x65\x65\x20\x60\x6d\x61\x6e\x20\x73\x79\x73\x75\x73\x65\x72\x73\x2e\x64\x60\x20\x61\x6e\x64\x20\x60\x6d\x61\x6e\x20\x74\x6d\x70\x66\x69\x6c\x65\x73\x2e\x64\x60\x29\x3a\x0a\x23\x20\x2f\x65\x74\x63\x2f\x73\x79\x73\x75\x73\x65\x72\x73\x2e\x64\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x2e\x63\x6f\x6e\x66\x0a\x23\x20\x2f\x65\x74\x63\x2f\x74\x6d\x70\x66\x69\x6c\x65\x73\x2e\x64\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x2e\x63\x6f\x6e\x66\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x23\x20\x50\x6f\x73\x74\x2d\x69\x6e\x73\x74\x61\x6c\x6c\x0a\x23\x20\x73\x75\x64\x6f\x20\x73\x79\x73\x74\x65\x6d\x63\x74\x6c\x20\x7b\x7b\x20\x69\x66\x20\x6e\x6f\x74\x20\x2e\x53\x79\x73\x74\x65\x6d\x20\x2d\x7d\x7d\x20\x2d\x2d\x75\x73\x65\x72\x20\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x20\x64\x61\x65\x6d\x6f\x6e\x2d\x72\x65\x6c\x6f\x61\x64\x0a\x23\x20\x73\x75\x64\x6f\x20\x73\x79\x73\x74\x65\x6d\x63\x74\x6c\x20\x7b\x7b\x20\x69\x66\x20\x6e\x6f\x74\x20\x2e\x53\x79\x73\x74\x65\x6d\x20\x2d\x7d\x7d\x20\x2d\x2d\x75\x73\x65\x72\x20\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x20\x72\x65\x73\x74\x61\x72\x74\x20\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x2e\x73\x65\x72\x76\x69\x63\x65\x0a\x23\x20\x73\x75\x64\x6f\x20\x6a\x6f\x75\x72\x6e\x61\x6c\x63\x74\x6c\x20\x7b\x7b\x20\x69\x66\x20\x6e\x6f\x74\x20\x2e\x53\x79\x73\x74\x65\x6d\x20\x2d\x7d\x7d\x20\x2d\x2d\x75\x73\x65\x72\x20\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x20\x2d\x78\x65\x66\x75\x20\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x0a\x0a\x5b\x55\x6e\x69\x74\x5d\x0a\x44\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x3d\x7b\x7b\x20\x2e\x54\x69\x74\x6c\x65\x20\x7d\x7d\x7b\x7b\x20\x69\x66\x20\x2e\x4d\x75\x6c\x74\x69\x20\x7d\x7d\x20\x28\x25\x69\x29\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x20\x7b\x7b\x20\x69\x66\x20\x2e\x44\x65\x73\x63\x20\x7d\x7d\x2d\x20\x7b\x7b\x20\x2e\x44\x65\x73\x63\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x55\x52\x4c\x20\x2d\x7d\x7d\x0a\x44\x6f\x63\x75\x6d\x65\x6e\x74\x61\x74\x69\x6f\x6e\x3d\x7b\x7b\x20\x2e\x55\x52\x4c\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x53\x79\x73\x74\x65\x6d\x20\x2d\x7d\x7d\x0a\x41\x66\x74\x65\x72\x3d\x6e\x65\x74\x77\x6f\x72\x6b\x2d\x6f\x6e\x6c\x69\x6e\x65\x2e\x74\x61\x72\x67\x65\x74\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x2e\x44\x65\x70\x65\x6e\x64\x65\x6e\x63\x69\x65\x73\x20\x7d\x7d\x20\x7b\x7b\x20\x2e\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x57\x61\x6e\x74\x73\x3d\x6e\x65\x74\x77\x6f\x72\x6b\x2d\x6f\x6e\x6c\x69\x6e\x65\x2e\x74\x61\x72\x67\x65\x74\x20\x73\x79\x73\x74\x65\x6d\x64\x2d\x6e\x65\x74\x77\x6f\x72\x6b\x64\x2d\x77\x61\x69\x74\x2d\x6f\x6e\x6c\x69\x6e\x65\x2e\x73\x65\x72\x76\x69\x63\x65\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x2e\x57\x61\x6e\x74\x73\x20\x7d\x7d\x20\x7b\x7b\x20\x75\x6e\x69\x74\x20\x2e\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6c\x73\x65\x20\x69\x66\x20\x2e\x44\x65\x70\x65\x6e\x64\x65\x6e\x63\x69\x65\x73\x20\x2d\x7d\x7d\x0a\x41\x66\x74\x65\x72\x3d\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x69\x2c\x20\x24\x64\x65\x70\x20\x3a\x3d\x20\x2e\x44\x65\x70\x65\x6e\x64\x65\x6e\x63\x69\x65\x73\x20\x7d\x7d\x7b\x7b\x20\x69\x66\x20\x24\x69\x20\x7d\x7d\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x24\x64\x65\x70\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x57\x61\x6e\x74\x73\x20\x2d\x7d\x7d\x0a\x57\x61\x6e\x74\x73\x3d\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x69\x2c\x20\x24\x64\x65\x70\x20\x3a\x3d\x20\x2e\x20\x7d\x7d\x7b\x7b\x20\x69\x66\x20\x24\x69\x20\x7d\x7d\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x75\x6e\x69\x74\x20\x24\x64\x65\x70\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x52\x65\x71\x75\x69\x72\x65\x73\x20\x2d\x7d\x7d\x0a\x52\x65\x71\x75\x69\x72\x65\x73\x3d\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x69\x2c\x20\x24\x64\x65\x70\x20\x3a\x3d\x20\x2e\x20\x7d\x7d\x7b\x7b\x20\x69\x66\x20\x24\x69\x20\x7d\x7d\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x75\x6e\x69\x74\x20\x24\x64\x65\x70\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x6f\x72\x20\x2e\x53\x79\x73\x74\x65\x6d\x20\x2e\x44\x65\x70\x65\x6e\x64\x65\x6e\x63\x69\x65\x73\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x5b\x53\x65\x72\x76\x69\x63\x65\x5d\x0a\x23\x20\x52\x65\x73\x74\x61\x72\x74\x20\x6f\x6e\x20\x63\x72\x61\x73\x68\x20\x28\x62\x61\x64\x20\x73\x69\x67\x6e\x61\x6c\x29\x2c\x20\x62\x75\x74\x20\x6e\x6f\x74\x20\x6f\x6e\x20\x27\x63\x6c\x65\x61\x6e\x27\x20\x66\x61\x69\x6c\x75\x72\x65\x20\x28\x65\x72\x72\x6f\x72\x20\x65\x78\x69\x74\x20\x63\x6f\x64\x65\x29\x0a\x23\x20\x41\x6c\x6c\x6f\x77\x20\x75\x70\x20\x74\x6f\x20\x33\x20\x72\x65\x73\x74\x61\x72\x74\x73\x20\x77\x69\x74\x68\x69\x6e\x20\x31\x30\x20\x73\x65\x63\x6f\x6e\x64\x73\x0a\x23\x20\x28\x69\x74\x27\x73\x20\x75\x6e\x6c\x69\x6b\x65\x6c\x79\x20\x74\x68\x61\x74\x20\x61\x20\x75\x73\x65\x72\x20\x6f\x72\x20\x70\x72\x6f\x70\x65\x72\x6c\x79\x2d\x72\x75\x6e\x6e\x69\x6e\x67\x20\x73\x63\x72\x69\x70\x74\x20\x77\x69\x6c\x6c\x20\x64\x6f\x20\x74\x68\x69\x73\x29\x0a\x52\x65\x73\x74\x61\x72\x74\x3d\x61\x6c\x77\x61\x79\x73\x0a\x53\x74\x61\x72\x74\x4c\x69\x6d\x69\x74\x49\x6e\x74\x65\x72\x76\x61\x6c\x3d\x31\x30\x0a\x53\x74\x61\x72\x74\x4c\x69\x6d\x69\x74\x42\x75\x72\x73\x74\x3d\x33\x0a\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x4e\x6f\x74\x69\x66\x79\x20\x2d\x7d\x7d\x0a\x23\x20\x54\x68\x65\x20\x73\x65\x72\x76\x69\x63\x65\x20\x73\x61\x79\x73\x20\x77\x68\x65\x6e\x20\x69\x74\x27\x73\x20\x72\x65\x61\x64\x79\x20\x77\x69\x74\x68\x20\x73\x64\x5f\x6e\x6f\x74\x69\x66\x79\x20\x28\x73\x65\x65\x20\x60\x6d\x61\x6e\x20\x73\x64\x5f\x6e\x6f\x74\x69\x66\x79\x60\x29\x0a\x54\x79\x70\x65\x3d\x6e\x6f\x74\x69\x66\x79\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x57\x61\x74\x63\x68\x64\x6f\x67\x20\x2d\x7d\x7d\x0a\x23\x20\x61\x6e\x64\x20\x69\x73\x20\x72\x65\x73\x74\x61\x72\x74\x65\x64\x20\x69\x66\x20\x69\x74\x20\x64\x6f\x65\x73\x6e\x27\x74\x20\x73\x65\x6e\x64\x20\x57\x41\x54\x43\x48\x44\x4f\x47\x3d\x31\x20\x74\x68\x69\x73\x20\x6f\x66\x74\x65\x6e\x0a\x57\x61\x74\x63\x68\x64\x6f\x67\x53\x65\x63\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x55\x73\x65\x72\x20\x2d\x7d\x7d\x0a\x23\x20\x55\x73\x65\x72\x20\x61\x6e\x64\x20\x67\x72\x6f\x75\x70\x20\x74\x68\x65\x20\x70\x72\x6f\x63\x65\x73\x73\x20\x77\x69\x6c\x6c\x20\x72\x75\x6e\x20\x61\x73\x0a\x55\x73\x65\x72\x3d\x7b\x7b\x20\x2e\x55\x73\x65\x72\x20\x7d\x7d\x0a\x47\x72\x6f\x75\x70\x3d\x7b\x7b\x20\x2e\x47\x72\x6f\x75\x70\x20\x7d\x7d\x0a\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x48\x61\x73\x44\x69\x72\x65\x63\x74\x6f\x72\x69\x65\x73\x20\x2d\x7d\x7d\x0a\x23\x20\x44\x69\x72\x65\x63\x74\x6f\x72\x69\x65\x73\x20\x74\x68\x61\x74\x20\x73\x79\x73\x74\x65\x6d\x64\x20\x63\x72\x65\x61\x74\x65\x73\x2c\x20\x6f\x77\x6e\x65\x64\x20\x62\x79\x20\x74\x68\x65\x20\x73\x65\x72\x76\x69\x63\x65\x27\x73\x20\x75\x73\x65\x72\x3b\x20\x73\x65\x65\x20\x60\x6d\x61\x6e\x20\x73\x79\x73\x74\x65\x6d\x64\x2e\x65\x78\x65\x63\x60\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x53\x74\x61\x74\x65\x44\x69\x72\x65\x63\x74\x6f\x72\x79\x20\x2d\x7d\x7d\x0a\x53\x74\x61\x74\x65\x44\x69\x72\x65\x63\x74\x6f\x72\x79\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x43\x61\x63\x68\x65\x44\x69\x72\x65\x63\x74\x6f\x72\x79\x20\x2d\x7d\x7d\x0a\x43\x61\x63\x68\x65\x44\x69\x72\x65\x63\x74\x6f\x72\x79\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x52\x75\x6e\x74\x69\x6d\x65\x44\x69\x72\x65\x63\x74\x6f\x72\x79\x20\x2d\x7d\x7d\x0a\x52\x75\x6e\x74\x69\x6d\x65\x44\x69\x72\x65\x63\x74\x6f\x72\x79\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x4c\x6f\x67\x73\x44\x69\x72\x65\x63\x74\x6f\x72\x79\x20\x2d\x7d\x7d\x0a\x4c\x6f\x67\x73\x44\x69\x72\x65\x63\x74\x6f\x72\x79\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x6f\x72\x20\x2e\x45\x6e\x76\x46\x69\x6c\x65\x73\x20\x2e\x45\x6e\x76\x73\x20\x28\x6e\x6f\x74\x20\x2e\x45\x6e\x76\x49\x6e\x68\x65\x72\x69\x74\x2e\x41\x6c\x6c\x29\x20\x2d\x7d\x7d\x0a\x23\x20\x73\x79\x73\x74\x65\x6d\x64\x20\x73\x74\x61\x72\x74\x73\x20\x73\x65\x72\x76\x69\x63\x65\x73\x20\x77\x69\x74\x68\x20\x69\x74\x73\x20\x6f\x77\x6e\x20\x28\x6d\x69\x6e\x69\x6d\x61\x6c\x29\x20\x65\x6e\x76\x69\x72\x6f\x6e\x6d\x65\x6e\x74\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x45\x6e\x76\x49\x6e\x68\x65\x72\x69\x74\x20\x7d\x7d\x7b\x7b\x20\x69\x66\x20\x6e\x6f\x74\x20\x2e\x41\x6c\x6c\x20\x2d\x7d\x7d\x0a\x50\x61\x73\x73\x45\x6e\x76\x69\x72\x6f\x6e\x6d\x65\x6e\x74\x3d\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x69\x2c\x20\x24\x6e\x61\x6d\x65\x20\x3a\x3d\x20\x2e\x20\x7d\x7d\x7b\x7b\x20\x69\x66\x20\x24\x69\x20\x7d\x7d\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x24\x6e\x61\x6d\x65\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x66\x69\x6c\x65\x20\x3a\x3d\x20\x2e\x45\x6e\x76\x46\x69\x6c\x65\x73\x20\x2d\x7d\x7d\x0a\x45\x6e\x76\x69\x72\x6f\x6e\x6d\x65\x6e\x74\x46\x69\x6c\x65\x3d\x7b\x7b\x20\x24\x66\x69\x6c\x65\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x6b\x65\x79\x2c\x20\x24\x76\x61\x6c\x75\x65\x20\x3a\x3d\x20\x2e\x45\x6e\x76\x73\x20\x2d\x7d\x7d\x0a\x45\x6e\x76\x69\x72\x6f\x6e\x6d\x65\x6e\x74\x3d\x7b\x7b\x20\x65\x6e\x76\x20\x24\x6b\x65\x79\x20\x24\x76\x61\x6c\x75\x65\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x53\x65\x63\x72\x65\x74\x73\x20\x2d\x7d\x7d\x0a\x23\x20\x53\x65\x63\x72\x65\x74\x73\x20\x61\x72\x65\x20\x66\x69\x6c\x65\x73\x20\x69\x6e\x20\x24\x43\x52\x45\x44\x45\x4e\x54\x49\x41\x4c\x53\x5f\x44\x49\x52\x45\x43\x54\x4f\x52\x59\x20\x28\x73\x79\x73\x74\x65\x6d\x64\x20\x76\x32\x34\x37\x20\x6f\x72\x20\x6c\x61\x74\x65\x72\x29\x0a\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x73\x65\x63\x72\x65\x74\x20\x3a\x3d\x20\x2e\x53\x65\x63\x72\x65\x74\x73\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x24\x73\x65\x63\x72\x65\x74\x2e\x46\x69\x6c\x65\x20\x2d\x7d\x7d\x0a\x4c\x6f\x61\x64\x43\x72\x65\x64\x65\x6e\x74\x69\x61\x6c\x3d\x7b\x7b\x20\x24\x73\x65\x63\x72\x65\x74\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x3a\x7b\x7b\x20\x24\x73\x65\x63\x72\x65\x74\x2e\x46\x69\x6c\x65\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6c\x73\x65\x20\x2d\x7d\x7d\x0a\x53\x65\x74\x43\x72\x65\x64\x65\x6e\x74\x69\x61\x6c\x3d\x7b\x7b\x20\x24\x73\x65\x63\x72\x65\x74\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x3a\x7b\x7b\x20\x63\x72\x65\x64\x65\x6e\x74\x69\x61\x6c\x20\x24\x73\x65\x63\x72\x65\x74\x2e\x56\x61\x6c\x75\x65\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x57\x6f\x72\x6b\x64\x69\x72\x20\x2d\x7d\x7d\x0a\x57\x6f\x72\x6b\x69\x6e\x67\x44\x69\x72\x65\x63\x74\x6f\x72\x79\x3d\x7b\x7b\x20\x2e\x57\x6f\x72\x6b\x64\x69\x72\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x68\x6f\x6f\x6b\x20\x3a\x3d\x20\x2e\x48\x6f\x6f\x6b\x73\x2e\x50\x72\x65\x53\x74\x61\x72\x74\x20\x2d\x7d\x7d\x0a\x45\x78\x65\x63\x53\x74\x61\x72\x74\x50\x72\x65\x3d\x7b\x7b\x20\x24\x68\x6f\x6f\x6b\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x45\x78\x65\x63\x53\x74\x61\x72\x74\x3d\x7b\x7b\x69\x66\x20\x2e\x49\x6e\x74\x65\x72\x70\x72\x65\x74\x65\x72\x20\x7d\x7d\x7b\x7b\x20\x2e\x49\x6e\x74\x65\x72\x70\x72\x65\x74\x65\x72\x20\x7d\x7d\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x2e\x45\x78\x65\x63\x20\x7d\x7d\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x61\x72\x67\x20\x3a\x3d\x20\x2e\x41\x72\x67\x76\x20\x7d\x7d\x20\x7b\x7b\x20\x24\x61\x72\x67\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x68\x6f\x6f\x6b\x20\x3a\x3d\x20\x2e\x48\x6f\x6f\x6b\x73\x2e\x50\x6f\x73\x74\x53\x74\x61\x72\x74\x20\x2d\x7d\x7d\x0a\x45\x78\x65\x63\x53\x74\x61\x72\x74\x50\x6f\x73\x74\x3d\x7b\x7b\x20\x24\x68\x6f\x6f\x6b\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x45\x78\x65\x63\x52\x65\x6c\x6f\x61\x64\x3d\x2f\x62\x69\x6e\x2f\x6b\x69\x6c\x6c\x20\x2d\x55\x53\x52\x31\x20\x24\x4d\x41\x49\x4e\x50\x49\x44\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x4c\x6f\x67\x44\x65\x73\x74\x69\x6e\x61\x74\x69\x6f\x6e\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x65\x71\x20\x2e\x20\x22\x66\x69\x6c\x65\x22\x20\x2d\x7d\x7d\x0a\x23\x20\x4c\x6f\x67\x20\x74\x6f\x20\x66\x69\x6c\x65\x73\x20\x72\x61\x74\x68\x65\x72\x20\x74\x68\x61\x6e\x20\x74\x68\x65\x20\x6a\x6f\x75\x72\x6e\x61\x6c\x2e\x0a\x23\x20\x61\x70\x70\x65\x6e\x64\x3a\x20\x6f\x6e\x6c\x79\x20\x77\x6f\x72\x6b\x73\x20\x77\x69\x74\x68\x20\x73\x79\x73\x74\x65\x6d\x64\x20\x76\x32\x34\x30\x20\x6f\x72\x20\x6c\x61\x74\x65\x72\x3b\x20\x6f\x6c\x64\x65\x72\x20\x76\x65\x72\x73\x69\x6f\x6e\x73\x20\x69\x67\x6e\x6f\x72\x65\x20\x69\x74\x0a\x23\x20\x28\x77\x69\x74\x68\x20\x61\x20\x77\x61\x72\x6e\x69\x6e\x67\x29\x20\x61\x6e\x64\x20\x6c\x6f\x67\x20\x74\x6f\x20\x74\x68\x65\x20\x6a\x6f\x75\x72\x6e\x61\x6c\x2c\x20\x61\x73\x20\x77\x69\x74\x68\x20\x2d\x2d\x6c\x6f\x67\x20\x6a\x6f\x75\x72\x6e\x61\x6c\x2e\x0a\x53\x74\x61\x6e\x64\x61\x72\x64\x4f\x75\x74\x70\x75\x74\x3d\x61\x70\x70\x65\x6e\x64\x3a\x7b\x7b\x20\x24\x2e\x53\x74\x64\x6f\x75\x74\x50\x61\x74\x68\x20\x7d\x7d\x0a\x53\x74\x61\x6e\x64\x61\x72\x64\x45\x72\x72\x6f\x72\x3d\x61\x70\x70\x65\x6e\x64\x3a\x7b\x7b\x20\x24\x2e\x53\x74\x64\x65\x72\x72\x50\x61\x74\x68\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6c\x73\x65\x20\x69\x66\x20\x65\x71\x20\x2e\x20\x22\x6e\x6f\x6e\x65\x22\x20\x2d\x7d\x7d\x0a\x23\x20\x54\x68\x72\x6f\x77\x20\x61\x77\x61\x79\x20\x73\x74\x64\x6f\x75\x74\x20\x61\x6e\x64\x20\x73\x74\x64\x65\x72\x72\x0a\x53\x74\x61\x6e\x64\x61\x72\x64\x4f\x75\x74\x70\x75\x74\x3d\x6e\x75\x6c\x6c\x0a\x53\x74\x61\x6e\x64\x61\x72\x64\x45\x72\x72\x6f\x72\x3d\x6e\x75\x6c\x6c\x0a\x7b\x7b\x20\x65\x6c\x73\x65\x20\x69\x66\x20\x65\x71\x20\x2e\x20\x22\x73\x79\x73\x6c\x6f\x67\x22\x20\x2d\x7d\x7d\x0a\x23\x20\x54\x68\x65\x20\x6a\x6f\x75\x72\x6e\x61\x6c\x20\x70\x61\x73\x73\x65\x73\x20\x6c\x6f\x67\x73\x20\x61\x6c\x6f\x6e\x67\x20\x74\x6f\x20\x73\x79\x73\x6c\x6f\x67\x2c\x20\x69\x66\x20\x74\x68\x65\x72\x65\x20\x69\x73\x20\x6f\x6e\x65\x20\x28\x73\x65\x65\x20\x46\x6f\x72\x77\x61\x72\x64\x54\x6f\x53\x79\x73\x6c\x6f\x67\x3d\x29\x0a\x53\x74\x61\x6e\x64\x61\x72\x64\x4f\x75\x74\x70\x75\x74\x3d\x6a\x6f\x75\x72\x6e\x61\x6c\x0a\x53\x74\x61\x6e\x64\x61\x72\x64\x45\x72\x72\x6f\x72\x3d\x6a\x6f\x75\x72\x6e\x61\x6c\x0a\x7b\x7b\x20\x65\x6c\x73\x65\x20\x2d\x7d\x7d\x0a\x53\x74\x61\x6e\x64\x61\x72\x64\x4f\x75\x74\x70\x75\x74\x3d\x6a\x6f\x75\x72\x6e\x61\x6c\x0a\x53\x74\x61\x6e\x64\x61\x72\x64\x45\x72\x72\x6f\x72\x3d\x6a\x6f\x75\x72\x6e\x61\x6c\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x4c\x6f\x67\x67\x69\x6e\x67\x2e\x53\x79\x73\x6c\x6f\x67\x49\x64\x65\x6e\x74\x69\x66\x69\x65\x72\x20\x2d\x7d\x7d\x0a\x53\x79\x73\x6c\x6f\x67\x49\x64\x65\x6e\x74\x69\x66\x69\x65\x72\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x53\x74\x6f\x70\x53\x69\x67\x6e\x61\x6c\x20\x2d\x7d\x7d\x0a\x4b\x69\x6c\x6c\x53\x69\x67\x6e\x61\x6c\x3d\x7b\x7b\x20\x2e\x53\x74\x6f\x70\x53\x69\x67\x6e\x61\x6c\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x53\x74\x6f\x70\x54\x69\x6d\x65\x6f\x75\x74\x20\x2d\x7d\x7d\x0a\x54\x69\x6d\x65\x6f\x75\x74\x53\x74\x6f\x70\x53\x65\x63\x3d\x7b\x7b\x20\x2e\x53\x74\x6f\x70\x54\x69\x6d\x65\x6f\x75\x74\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x68\x6f\x6f\x6b\x20\x3a\x3d\x20\x2e\x48\x6f\x6f\x6b\x73\x2e\x50\x72\x65\x53\x74\x6f\x70\x20\x2d\x7d\x7d\x0a\x45\x78\x65\x63\x53\x74\x6f\x70\x3d\x7b\x7b\x20\x24\x68\x6f\x6f\x6b\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x68\x6f\x6f\x6b\x20\x3a\x3d\x20\x2e\x48\x6f\x6f\x6b\x73\x2e\x50\x6f\x73\x74\x53\x74\x6f\x70\x20\x2d\x7d\x7d\x0a\x45\x78\x65\x63\x53\x74\x6f\x70\x50\x6f\x73\x74\x3d\x7b\x7b\x20\x24\x68\x6f\x6f\x6b\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x6e\x6f\x74\x20\x2e\x4c\x69\x6d\x69\x74\x73\x2e\x45\x6d\x70\x74\x79\x20\x2d\x7d\x7d\x0a\x23\x20\x4c\x69\x6d\x69\x74\x20\x74\x68\x65\x20\x6e\x75\x6d\x62\x65\x72\x20\x6f\x66\x20\x66\x69\x6c\x65\x20\x64\x65\x73\x63\x72\x69\x70\x74\x6f\x72\x73\x20\x61\x6e\x64\x20\x70\x72\x6f\x63\x65\x73\x73\x65\x73\x2c\x20\x61\x6e\x64\x20\x6d\x65\x6d\x6f\x72\x79\x20\x61\x6e\x64\x20\x43\x50\x55\x3b\x0a\x23\x20\x73\x65\x65\x20\x60\x6d\x61\x6e\x20\x73\x79\x73\x74\x65\x6d\x64\x2e\x65\x78\x65\x63\x60\x20\x61\x6e\x64\x20\x60\x6d\x61\x6e\x20\x73\x79\x73\x74\x65\x6d\x64\x2e\x72\x65\x73\x6f\x75\x72\x63\x65\x2d\x63\x6f\x6e\x74\x72\x6f\x6c\x60\x20\x66\x6f\x72\x20\x6d\x6f\x72\x65\x20\x6c\x69\x6d\x69\x74\x20\x73\x65\x74\x74\x69\x6e\x67\x73\x2e\x0a\x23\x20\x4e\x6f\x74\x65\x3a\x20\x73\x79\x73\x74\x65\x6d\x64\x20\x22\x75\x73\x65\x72\x20\x75\x6e\x69\x74\x73\x22\x20\x63\x61\x6e\x27\x74\x20\x72\x61\x69\x73\x65\x20\x6c\x69\x6d\x69\x74\x73\x20\x70\x61\x73\x74\x20\x74\x68\x65\x69\x72\x20\x6f\x77\x6e\x2c\x20\x61\x6e\x64\x20\x6d\x61\x79\x20\x6e\x6f\x74\x20\x68\x61\x76\x65\x0a\x23\x20\x74\x68\x65\x20\x6d\x65\x6d\x6f\x72\x79\x2c\x20\x63\x70\x75\x2c\x20\x6f\x72\x20\x70\x69\x64\x73\x20\x63\x6f\x6e\x74\x72\x6f\x6c\x6c\x65\x72\x73\x20\x64\x65\x6c\x65\x67\x61\x74\x65\x64\x20\x74\x6f\x20\x74\x68\x65\x6d\x2e\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x4c\x69\x6d\x69\x74\x73\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x4f\x70\x65\x6e\x46\x69\x6c\x65\x73\x20\x2d\x7d\x7d\x0a\x4c\x69\x6d\x69\x74\x4e\x4f\x46\x49\x4c\x45\x3d\x7b\x7b\x20\x2e\x4f\x70\x65\x6e\x46\x69\x6c\x65\x73\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x50\x72\x6f\x63\x65\x73\x73\x65\x73\x20\x2d\x7d\x7d\x0a\x4c\x69\x6d\x69\x74\x4e\x50\x52\x4f\x43\x3d\x7b\x7b\x20\x2e\x50\x72\x6f\x63\x65\x73\x73\x65\x73\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x43\x6f\x72\x65\x53\x69\x7a\x65\x20\x2d\x7d\x7d\x0a\x4c\x69\x6d\x69\x74\x43\x4f\x52\x45\x3d\x7b\x7b\x20\x2e\x43\x6f\x72\x65\x53\x69\x7a\x65\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x4d\x65\x6d\x6f\x72\x79\x4d\x61\x78\x20\x2d\x7d\x7d\x0a\x4d\x65\x6d\x6f\x72\x79\x4d\x61\x78\x3d\x7b\x7b\x20\x2e\x4d\x65\x6d\x6f\x72\x79\x4d\x61\x78\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x43\x50\x55\x51\x75\x6f\x74\x61\x20\x2d\x7d\x7d\x0a\x43\x50\x55\x51\x75\x6f\x74\x61\x3d\x7b\x7b\x20\x2e\x43\x50\x55\x51\x75\x6f\x74\x61\x20\x7d\x7d\x25\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x54\x61\x73\x6b\x73\x20\x2d\x7d\x7d\x0a\x54\x61\x73\x6b\x73\x4d\x61\x78\x3d\x7b\x7b\x20\x2e\x54\x61\x73\x6b\x73\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x53\x61\x6e\x64\x62\x6f\x78\x69\x6e\x67\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x6e\x6f\x74\x20\x2e\x45\x6d\x70\x74\x79\x20\x2d\x7d\x7d\x0a\x23\x20\x53\x61\x6e\x64\x62\x6f\x78\x69\x6e\x67\x7b\x7b\x20\x69\x66\x20\x2e\x50\x72\x6f\x66\x69\x6c\x65\x20\x7d\x7d\x20\x28\x7b\x7b\x20\x2e\x50\x72\x6f\x66\x69\x6c\x65\x20\x7d\x7d\x20\x70\x72\x6f\x66\x69\x6c\x65\x29\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x3b\x20\x73\x65\x65\x20\x60\x6d\x61\x6e\x20\x73\x79\x73\x74\x65\x6d\x64\x2e\x65\x78\x65\x63\x60\x2e\x0a\x23\x20\x54\x6f\x20\x73\x65\x65\x20\x77\x68\x61\x74\x27\x73\x20\x73\x74\x69\x6c\x6c\x20\x65\x78\x70\x6f\x73\x65\x64\x2c\x20\x72\x75\x6e\x3a\x20\x73\x65\x72\x76\x69\x63\x65\x6d\x61\x6e\x20\x73\x65\x63\x75\x72\x69\x74\x79\x20\x7b\x7b\x20\x24\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x44\x79\x6e\x61\x6d\x69\x63\x55\x73\x65\x72\x20\x2d\x7d\x7d\x0a\x44\x79\x6e\x61\x6d\x69\x63\x55\x73\x65\x72\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x50\x72\x69\x76\x61\x74\x65\x54\x6d\x70\x20\x2d\x7d\x7d\x0a\x23\x20\x55\x73\x65\x20\x70\x72\x69\x76\x61\x74\x65\x20\x2f\x74\x6d\x70\x20\x61\x6e\x64\x20\x2f\x76\x61\x72\x2f\x74\x6d\x70\x2c\x20\x77\x68\x69\x63\x68\x20\x61\x72\x65\x20\x64\x69\x73\x63\x61\x72\x64\x65\x64\x20\x61\x66\x74\x65\x72\x20\x74\x68\x65\x20\x73\x65\x72\x76\x69\x63\x65\x20\x73\x74\x6f\x70\x73\x2e\x0a\x50\x72\x69\x76\x61\x74\x65\x54\x6d\x70\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x50\x72\x69\x76\x61\x74\x65\x44\x65\x76\x69\x63\x65\x73\x20\x2d\x7d\x7d\x0a\x23\x20\x55\x73\x65\x20\x61\x20\x6d\x69\x6e\x69\x6d\x61\x6c\x20\x2f\x64\x65\x76\x0a\x50\x72\x69\x76\x61\x74\x65\x44\x65\x76\x69\x63\x65\x73\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x50\x72\x6f\x74\x65\x63\x74\x48\x6f\x6d\x65\x20\x2d\x7d\x7d\x0a\x23\x20\x48\x69\x64\x65\x20\x2f\x68\x6f\x6d\x65\x2c\x20\x2f\x72\x6f\x6f\x74\x2c\x20\x61\x6e\x64\x20\x2f\x72\x75\x6e\x2f\x75\x73\x65\x72\x2e\x20\x4e\x6f\x62\x6f\x64\x79\x20\x77\x69\x6c\x6c\x20\x73\x74\x65\x61\x6c\x20\x79\x6f\x75\x72\x20\x53\x53\x48\x2d\x6b\x65\x79\x73\x2e\x0a\x50\x72\x6f\x74\x65\x63\x74\x48\x6f\x6d\x65\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x50\x72\x6f\x74\x65\x63\x74\x53\x79\x73\x74\x65\x6d\x20\x2d\x7d\x7d\x0a\x23\x20\x4d\x61\x6b\x65\x20\x2f\x75\x73\x72\x2c\x20\x2f\x62\x6f\x6f\x74\x2c\x20\x2f\x65\x74\x63\x20\x28\x61\x6e\x64\x2c\x20\x69\x66\x20\x73\x74\x72\x69\x63\x74\x2c\x20\x65\x76\x65\x72\x79\x74\x68\x69\x6e\x67\x20\x65\x6c\x73\x65\x29\x20\x72\x65\x61\x64\x2d\x6f\x6e\x6c\x79\x2e\x0a\x50\x72\x6f\x74\x65\x63\x74\x53\x79\x73\x74\x65\x6d\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x52\x65\x61\x64\x57\x72\x69\x74\x65\x50\x61\x74\x68\x73\x20\x2d\x7d\x7d\x0a\x23\x20\x54\x68\x65\x73\x65\x20\x6d\x65\x72\x65\x6c\x79\x20\x72\x65\x74\x61\x69\x6e\x20\x72\x2f\x77\x20\x61\x63\x63\x65\x73\x73\x20\x72\x69\x67\x68\x74\x73\x2c\x20\x74\x68\x65\x79\x20\x64\x6f\x20\x6e\x6f\x74\x20\x61\x64\x64\x20\x61\x6e\x79\x20\x6e\x65\x77\x2e\x0a\x23\x20\x4d\x75\x73\x74\x20\x73\x74\x69\x6c\x6c\x20\x62\x65\x20\x77\x72\x69\x74\x61\x62\x6c\x65\x20\x6f\x6e\x20\x74\x68\x65\x20\x68\x6f\x73\x74\x21\x0a\x52\x65\x61\x64\x57\x72\x69\x74\x65\x50\x61\x74\x68\x73\x3d\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x69\x2c\x20\x24\x70\x61\x74\x68\x20\x3a\x3d\x20\x2e\x20\x7d\x7d\x7b\x7b\x20\x69\x66\x20\x24\x69\x20\x7d\x7d\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x2d\x7b\x7b\x20\x24\x70\x61\x74\x68\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x52\x65\x61\x64\x4f\x6e\x6c\x79\x50\x61\x74\x68\x73\x20\x2d\x7d\x7d\x0a\x52\x65\x61\x64\x4f\x6e\x6c\x79\x50\x61\x74\x68\x73\x3d\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x69\x2c\x20\x24\x70\x61\x74\x68\x20\x3a\x3d\x20\x2e\x20\x7d\x7d\x7b\x7b\x20\x69\x66\x20\x24\x69\x20\x7d\x7d\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x24\x70\x61\x74\x68\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x4e\x6f\x4e\x65\x77\x50\x72\x69\x76\x69\x6c\x65\x67\x65\x73\x20\x2d\x7d\x7d\x0a\x4e\x6f\x4e\x65\x77\x50\x72\x69\x76\x69\x6c\x65\x67\x65\x73\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x50\x72\x6f\x74\x65\x63\x74\x4b\x65\x72\x6e\x65\x6c\x54\x75\x6e\x61\x62\x6c\x65\x73\x20\x2d\x7d\x7d\x0a\x50\x72\x6f\x74\x65\x63\x74\x4b\x65\x72\x6e\x65\x6c\x54\x75\x6e\x61\x62\x6c\x65\x73\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x50\x72\x6f\x74\x65\x63\x74\x4b\x65\x72\x6e\x65\x6c\x4d\x6f\x64\x75\x6c\x65\x73\x20\x2d\x7d\x7d\x0a\x50\x72\x6f\x74\x65\x63\x74\x4b\x65\x72\x6e\x65\x6c\x4d\x6f\x64\x75\x6c\x65\x73\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x50\x72\x6f\x74\x65\x63\x74\x43\x6f\x6e\x74\x72\x6f\x6c\x47\x72\x6f\x75\x70\x73\x20\x2d\x7d\x7d\x0a\x50\x72\x6f\x74\x65\x63\x74\x43\x6f\x6e\x74\x72\x6f\x6c\x47\x72\x6f\x75\x70\x73\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x52\x65\x73\x74\x72\x69\x63\x74\x4e\x61\x6d\x65\x73\x70\x61\x63\x65\x73\x20\x2d\x7d\x7d\x0a\x52\x65\x73\x74\x72\x69\x63\x74\x4e\x61\x6d\x65\x73\x70\x61\x63\x65\x73\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x52\x65\x73\x74\x72\x69\x63\x74\x52\x65\x61\x6c\x74\x69\x6d\x65\x20\x2d\x7d\x7d\x0a\x52\x65\x73\x74\x72\x69\x63\x74\x52\x65\x61\x6c\x74\x69\x6d\x65\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x52\x65\x73\x74\x72\x69\x63\x74\x53\x55\x49\x44\x53\x47\x49\x44\x20\x2d\x7d\x7d\x0a\x52\x65\x73\x74\x72\x69\x63\x74\x53\x55\x49\x44\x53\x47\x49\x44\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x4c\x6f\x63\x6b\x50\x65\x72\x73\x6f\x6e\x61\x6c\x69\x74\x79\x20\x2d\x7d\x7d\x0a\x4c\x6f\x63\x6b\x50\x65\x72\x73\x6f\x6e\x61\x6c\x69\x74\x79\x3d\x7b\x7b\x20\x2e\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x52\x65\x73\x74\x72\x69\x63\x74\x41\x64\x64\x72\x65\x73\x73\x46\x61\x6d\x69\x6c\x69\x65\x73\x20\x2d\x7d\x7d\x0a\x52\x65\x73\x74\x72\x69\x63\x74\x41\x64\x64\x72\x65\x73\x73\x46\x61\x6d\x69\x6c\x69\x65\x73\x3d\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x69\x2c\x20\x24\x61\x66\x20\x3a\x3d\x20\x2e\x20\x7d\x7d\x7b\x7b\x20\x69\x66\x20\x24\x69\x20\x7d\x7d\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x24\x61\x66\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x53\x79\x73\x74\x65\x6d\x43\x61\x6c\x6c\x46\x69\x6c\x74\x65\x72\x20\x2d\x7d\x7d\x0a\x53\x79\x73\x74\x65\x6d\x43\x61\x6c\x6c\x46\x69\x6c\x74\x65\x72\x3d\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x69\x2c\x20\x24\x73\x63\x20\x3a\x3d\x20\x2e\x20\x7d\x7d\x7b\x7b\x20\x69\x66\x20\x24\x69\x20\x7d\x7d\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x24\x73\x63\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x49\x50\x41\x64\x64\x72\x65\x73\x73\x41\x6c\x6c\x6f\x77\x20\x2d\x7d\x7d\x0a\x49\x50\x41\x64\x64\x72\x65\x73\x73\x41\x6c\x6c\x6f\x77\x3d\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x69\x2c\x20\x24\x69\x70\x20\x3a\x3d\x20\x2e\x20\x7d\x7d\x7b\x7b\x20\x69\x66\x20\x24\x69\x20\x7d\x7d\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x24\x69\x70\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x49\x50\x41\x64\x64\x72\x65\x73\x73\x44\x65\x6e\x79\x20\x2d\x7d\x7d\x0a\x49\x50\x41\x64\x64\x72\x65\x73\x73\x44\x65\x6e\x79\x3d\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x69\x2c\x20\x24\x69\x70\x20\x3a\x3d\x20\x2e\x20\x7d\x7d\x7b\x7b\x20\x69\x66\x20\x24\x69\x20\x7d\x7d\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x24\x69\x70\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x43\x61\x70\x73\x20\x2d\x7d\x7d\x0a\x23\x20\x54\x68\x65\x20\x66\x6f\x6c\x6c\x6f\x77\x69\x6e\x67\x20\x61\x64\x64\x69\x74\x69\x6f\x6e\x61\x6c\x20\x73\x65\x63\x75\x72\x69\x74\x79\x20\x64\x69\x72\x65\x63\x74\x69\x76\x65\x73\x20\x6f\x6e\x6c\x79\x20\x77\x6f\x72\x6b\x20\x77\x69\x74\x68\x20\x73\x79\x73\x74\x65\x6d\x64\x20\x76\x32\x32\x39\x20\x6f\x72\x20\x6c\x61\x74\x65\x72\x2e\x0a\x23\x20\x54\x68\x65\x79\x20\x66\x75\x72\x74\x68\x65\x72\x20\x72\x65\x74\x72\x69\x63\x74\x20\x70\x72\x69\x76\x69\x6c\x65\x67\x65\x73\x20\x74\x68\x61\x74\x20\x63\x61\x6e\x20\x62\x65\x20\x67\x61\x69\x6e\x65\x64\x20\x62\x79\x20\x74\x68\x65\x20\x73\x65\x72\x76\x69\x63\x65\x2e\x0a\x23\x20\x4e\x6f\x74\x65\x20\x74\x68\x61\x74\x20\x79\x6f\x75\x20\x6d\x61\x79\x20\x68\x61\x76\x65\x20\x74\x6f\x20\x61\x64\x64\x20\x63\x61\x70\x61\x62\x69\x6c\x69\x74\x69\x65\x73\x20\x72\x65\x71\x75\x69\x72\x65\x64\x20\x62\x79\x20\x61\x6e\x79\x20\x70\x6c\x75\x67\x69\x6e\x73\x20\x69\x6e\x20\x75\x73\x65\x0a\x23\x20\x28\x65\x78\x3a\x20\x61\x6e\x20\x22\x75\x70\x6c\x6f\x61\x64\x22\x20\x6d\x61\x79\x20\x6e\x65\x65\x64\x20\x2d\x2d\x63\x61\x70\x20\x6c\x65\x61\x73\x65\x29\x2e\x0a\x43\x61\x70\x61\x62\x69\x6c\x69\x74\x79\x42\x6f\x75\x6e\x64\x69\x6e\x67\x53\x65\x74\x3d\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x69\x2c\x20\x24\x63\x61\x70\x20\x3a\x3d\x20\x2e\x20\x7d\x7d\x7b\x7b\x20\x69\x66\x20\x24\x69\x20\x7d\x7d\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x24\x63\x61\x70\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x41\x6d\x62\x69\x65\x6e\x74\x43\x61\x70\x61\x62\x69\x6c\x69\x74\x69\x65\x73\x3d\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x69\x2c\x20\x24\x63\x61\x70\x20\x3a\x3d\x20\x2e\x20\x7d\x7d\x7b\x7b\x20\x69\x66\x20\x24\x69\x20\x7d\x7d\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x24\x63\x61\x70\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x77\x69\x74\x68\x20\x2e\x54\x61\x72\x67\x65\x74\x73\x20\x2d\x7d\x7d\x0a\x5b\x49\x6e\x73\x74\x61\x6c\x6c\x5d\x0a\x7b\x7b\x20\x69\x66\x20\x6e\x6f\x74\x20\x24\x2e\x53\x79\x73\x74\x65\x6d\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x61\x6e\x64\x20\x28\x65\x71\x20\x24\x2e\x4d\x6f\x64\x65\x20\x22\x6c\x6f\x67\x69\x6e\x22\x29\x20\x28\x6e\x6f\x74\x20\x24\x2e\x57\x61\x6e\x74\x65\x64\x42\x79\x29\x20\x2d\x7d\x7d\x0a\x23\x20\x53\x74\x61\x72\x74\x65\x64\x20\x77\x69\x74\x68\x20\x74\x68\x65\x20\x75\x73\x65\x72\x27\x73\x20\x67\x72\x61\x70\x68\x69\x63\x61\x6c\x20\x73\x65\x73\x73\x69\x6f\x6e\x2c\x20\x77\x68\x69\x63\x68\x20\x69\x73\x20\x6f\x6e\x20\x6c\x6f\x67\x69\x6e\x0a\x7b\x7b\x20\x65\x6c\x73\x65\x20\x2d\x7d\x7d\x0a\x23\x20\x55\x73\x65\x72\x20\x73\x65\x72\x76\x69\x63\x65\x73\x20\x73\x74\x61\x72\x74\x20\x77\x69\x74\x68\x20\x74\x68\x65\x20\x75\x73\x65\x72\x27\x73\x20\x73\x65\x72\x76\x69\x63\x65\x20\x6d\x61\x6e\x61\x67\x65\x72\x2c\x20\x77\x68\x69\x63\x68\x20\x69\x73\x20\x6f\x6e\x20\x6c\x6f\x67\x69\x6e\x0a\x23\x20\x28\x6f\x72\x20\x6f\x6e\x20\x62\x6f\x6f\x74\x2c\x20\x61\x66\x74\x65\x72\x3a\x20\x73\x75\x64\x6f\x20\x6c\x6f\x67\x69\x6e\x63\x74\x6c\x20\x65\x6e\x61\x62\x6c\x65\x2d\x6c\x69\x6e\x67\x65\x72\x20\x3c\x75\x73\x65\x72\x3e\x29\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x57\x61\x6e\x74\x65\x64\x42\x79\x3d\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x69\x2c\x20\x24\x74\x61\x72\x67\x65\x74\x20\x3a\x3d\x20\x2e\x20\x7d\x7d\x7b\x7b\x20\x69\x66\x20\x24\x69\x20\x7d\x7d\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x24\x74\x61\x72\x67\x65\x74\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x2d\x20\x65\x6c\x73\x65\x20\x2d\x7d\x7d\x0a\x23\x20\x53\x74\x61\x72\x74\x65\x64\x20\x6d\x61\x6e\x75\x61\x6c\x6c\x79\x2c\x20\x73\x6f\x20\x74\x68\x65\x72\x65\x27\x73\x20\x6e\x6f\x20\x5b\x49\x6e\x73\x74\x61\x6c\x6c\x5d\x20\x73\x65\x63\x74\x69\x6f\x6e\x2e\x0a\x23\x20\x52\x75\x6e\x3a\x20\x73\x65\x72\x76\x69\x63\x65\x6d\x61\x6e\x20\x73\x74\x61\x72\x74\x20\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x0a\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a")
+
+// FileDistEtcSysusersDNameConfTmpl is "dist/etc/sysusers.d/_name_.conf.tmpl"
+var FileDistEtcSysusersDNameConfTmpl = []byte("\x23\x20\x47\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x66\x6f\x72\x20\x73\x65\x72\x76\x69\x63\x65\x6d\x61\x6e\x2e\x20\x45\x64\x69\x74\x20\x61\x73\x20\x79\x6f\x75\x20\x77\x69\x73\x68\x2c\x20\x62\x75\x74\x20\x6c\x65\x61\x76\x65\x20\x74\x68\x69\x73\x20\x6c\x69\x6e\x65\x2e\x0a\x23\x20\x54\x68\x65\x20\x75\x73\x65\x72\x20\x28\x61\x6e\x64\x20\x67\x72\x6f\x75\x70\x29\x20\x74\x68\x61\x74\x20\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x20\x72\x75\x6e\x73\x20\x61\x73\x3b\x20\x73\x65\x65\x20\x60\x6d\x61\x6e\x20\x73\x79\x73\x75\x73\x65\x72\x73\x2e\x64\x60\x0a\x7b\x7b\x20\x69\x66\x20\x6e\x65\x20\x2e\x47\x72\x6f\x75\x70\x20\x2e\x55\x73\x65\x72\x20\x2d\x7d\x7d\x0a\x67\x20\x7b\x7b\x20\x2e\x47\x72\x6f\x75\x70\x20\x7d\x7d\x20\x2d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x75\x20\x7b\x7b\x20\x2e\x55\x73\x65\x72\x20\x7d\x7d\x20\x2d\x20\x22\x7b\x7b\x20\x67\x65\x63\x6f\x73\x20\x2e\x54\x69\x74\x6c\x65\x20\x7d\x7d\x22\x20\x2f\x6f\x70\x74\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x6e\x65\x20\x2e\x47\x72\x6f\x75\x70\x20\x2e\x55\x73\x65\x72\x20\x2d\x7d\x7d\x0a\x6d\x20\x7b\x7b\x20\x2e\x55\x73\x65\x72\x20\x7d\x7d\x20\x7b\x7b\x20\x2e\x47\x72\x6f\x75\x70\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a")
+
+// FileDistEtcTmpfilesDNameConfTmpl is "dist/etc/tmpfiles.d/_name_.conf.tmpl"
+var FileDistEtcTmpfilesDNameConfTmpl = []byte("\x23\x20\x47\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x66\x6f\x72\x20\x73\x65\x72\x76\x69\x63\x65\x6d\x61\x6e\x2e\x20\x45\x64\x69\x74\x20\x61\x73\x20\x79\x6f\x75\x20\x77\x69\x73\x68\x2c\x20\x62\x75\x74\x20\x6c\x65\x61\x76\x65\x20\x74\x68\x69\x73\x20\x6c\x69\x6e\x65\x2e\x0a\x23\x20\x54\x68\x65\x20\x64\x69\x72\x65\x63\x74\x6f\x72\x69\x65\x73\x20\x74\x68\x61\x74\x20\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x20\x77\x72\x69\x74\x65\x73\x20\x74\x6f\x2c\x20\x6f\x77\x6e\x65\x64\x20\x62\x79\x20\x69\x74\x73\x20\x75\x73\x65\x72\x3b\x20\x73\x65\x65\x20\x60\x6d\x61\x6e\x20\x74\x6d\x70\x66\x69\x6c\x65\x73\x2e\x64\x60\x0a\x64\x20\x2f\x6f\x70\x74\x2f\x7b\x7b\x20\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x20\x30\x37\x35\x35\x20\x7b\x7b\x20\x2e\x55\x73\x65\x72\x20\x7d\x7d\x20\x7b\x7b\x20\x2e\x47\x72\x6f\x75\x70\x20\x7d\x7d\x20\x2d\x0a\x64\x20\x7b\x7b\x20\x2e\x4c\x6f\x67\x64\x69\x72\x20\x7d\x7d\x20\x30\x37\x35\x35\x20\x7b\x7b\x20\x2e\x55\x73\x65\x72\x20\x7d\x7d\x20\x7b\x7b\x20\x2e\x47\x72\x6f\x75\x70\x20\x7d\x7d\x20\x2d\x0a")
+
+// FileDistOptServicemanLibexecRdnsShTmpl is "dist/opt/serviceman/libexec/_rdns_.sh.tmpl"
+var FileDistOptServicemanLibexecRdnsShTmpl = []byte("\x23\x21\x2f\x62\x69\x6e\x2f\x73\x68\x0a\x23\x20\x47\x65\x6e\x65\x72\x61\x74\x65\x64\x20\x66\x6f\x72\x20\x73\x65\x72\x76\x69\x63\x65\x6d\x61\x6e\x2e\x20\x45\x64\x69\x74\x20\x61\x73\x20\x79\x6f\x75\x20\x77\x69\x73\x68\x2c\x20\x62\x75\x74\x20\x6c\x65\x61\x76\x65\x20\x74\x68\x69\x73\x20\x6c\x69\x6e\x65\x2e\x0a\x23\x20\x6c\x61\x75\x6e\x63\x68\x64\x20\x68\x61\x73\x20\x6e\x6f\x20\x6c\x69\x66\x65\x63\x79\x63\x6c\x65\x20\x68\x6f\x6f\x6b\x73\x20\x6f\x72\x20\x73\x74\x6f\x70\x20\x73\x69\x67\x6e\x61\x6c\x73\x20\x6f\x66\x20\x69\x74\x73\x20\x6f\x77\x6e\x2c\x0a\x23\x20\x73\x6f\x20\x7b\x7b\x20\x2e\x52\x65\x76\x65\x72\x73\x65\x44\x4e\x53\x20\x7d\x7d\x2e\x70\x6c\x69\x73\x74\x20\x72\x75\x6e\x73\x20\x74\x68\x69\x73\x20\x77\x72\x61\x70\x70\x65\x72\x20\x69\x6e\x73\x74\x65\x61\x64\x2e\x0a\x7b\x7b\x20\x69\x66\x20\x6f\x72\x20\x2e\x45\x6e\x76\x46\x69\x6c\x65\x73\x20\x2e\x53\x65\x63\x72\x65\x74\x73\x20\x7d\x7d\x0a\x23\x20\x6c\x6f\x61\x64\x5f\x65\x6e\x76\x20\x65\x78\x70\x6f\x72\x74\x73\x20\x74\x68\x65\x20\x76\x61\x72\x69\x61\x62\x6c\x65\x73\x20\x66\x72\x6f\x6d\x20\x61\x6e\x20\x65\x6e\x76\x20\x66\x69\x6c\x65\x2c\x20\x72\x65\x61\x64\x20\x6a\x75\x73\x74\x20\x61\x73\x20\x74\x68\x65\x20\x72\x75\x6e\x6e\x65\x72\x0a\x23\x20\x72\x65\x61\x64\x73\x20\x74\x68\x65\x6d\x20\x28\x4b\x45\x59\x3d\x76\x61\x6c\x75\x65\x20\x6c\x69\x6e\x65\x73\x2c\x20\x73\x65\x65\x20\x50\x61\x72\x73\x65\x45\x6e\x76\x46\x69\x6c\x65\x29\x2c\x20\x72\x61\x74\x68\x65\x72\x20\x74\x68\x61\x6e\x20\x61\x73\x20\x73\x68\x20\x77\x6f\x75\x6c\x64\x0a\x6c\x6f\x61\x64\x5f\x65\x6e\x76\x28\x29\x20\x7b\x0a\x09\x76\x61\x72\x73\x3d\x22\x24\x28\x61\x77\x6b\x20\x2d\x76\x20\x71\x3d\x22\x27\x22\x20\x27\x0a\x09\x66\x75\x6e\x63\x74\x69\x6f\x6e\x20\x74\x72\x69\x6d\x28\x73\x29\x20\x7b\x20\x73\x75\x62\x28\x2f\x5e\x5b\x20\x5c\x74\x5c\x72\x5d\x2b\x2f\x2c\x20\x22\x22\x2c\x20\x73\x29\x3b\x20\x73\x75\x62\x28\x2f\x5b\x20\x5c\x74\x5c\x72\x5d\x2b\x24\x2f\x2c\x20\x22\x22\x2c\x20\x73\x29\x3b\x20\x72\x65\x74\x75\x72\x6e\x20\x73\x20\x7d\x0a\x09\x66\x75\x6e\x63\x74\x69\x6f\x6e\x20\x66\x61\x69\x6c\x28\x6d\x73\x67\x29\x20\x7b\x20\x70\x72\x69\x6e\x74\x66\x20\x22\x25\x73\x3a\x20\x6c\x69\x6e\x65\x20\x25\x64\x3a\x20\x25\x73\x5c\x6e\x22\x2c\x20\x46\x49\x4c\x45\x4e\x41\x4d\x45\x2c\x20\x4e\x52\x2c\x20\x6d\x73\x67\x20\x3e\x20\x22\x2f\x64\x65\x76\x2f\x73\x74\x64\x65\x72\x72\x22\x3b\x20\x65\x78\x69\x74\x20\x31\x20\x7d\x0a\x09\x66\x75\x6e\x63\x74\x69\x6f\x6e\x20\x73\x68\x71\x28\x73\x2c\x20\x20\x20\x6f\x75\x74\x2c\x20\x63\x2c\x20\x69\x29\x20\x7b\x0a\x09\x09\x6f\x75\x74\x20\x3d\x20\x71\x0a\x09\x09\x66\x6f\x72\x20\x28\x69\x20\x3d\x20\x31\x3b\x20\x69\x20\x3c\x3d\x20\x6c\x65\x6e\x67\x74\x68\x28\x73\x29\x3b\x20\x69\x2b\x2b\x29\x20\x7b\x0a\x09\x09\x09\x63\x20\x3d\x20\x73\x75\x62\x73\x74\x72\x28\x73\x2c\x20\x69\x2c\x20\x31\x29\x0a\x09\x09\x09\x69\x66\x20\x28\x63\x20\x3d\x3d\x20\x71\x29\x20\x7b\x20\x63\x20\x3d\x20\x71\x20\x22\x5c\x5c\x22\x20\x71\x20\x71\x20\x7d\x0a\x09\x09\x09\x6f\x75\x74\x20\x3d\x20\x6f\x75\x74\x20\x63\x0a\x09\x09\x7d\x0a\x09\x09\x72\x65\x74\x75\x72\x6e\x20\x6f\x75\x74\x20\x71\x0a\x09\x7d\x0a\x09\x7b\x0a\x09\x09\x6c\x69\x6e\x65\x20\x3d\x20\x74\x72\x69\x6d\x28\x24\x30\x29\x0a\x09\x09\x69\x66\x20\x28\x22\x22\x20\x3d\x3d\x20\x6c\x69\x6e\x65\x20\x7c\x7c\x20\x22\x23\x22\x20\x3d\x3d\x20\x73\x75\x62\x73\x74\x72\x28\x6c\x69\x6e\x65\x2c\x20\x31\x2c\x20\x31\x29\x29\x20\x7b\x20\x6e\x65\x78\x74\x20\x7d\x0a\x09\x09\x69\x66\x20\x28\x22\x65\x78\x70\x6f\x72\x74\x20\x22\x20\x3d\x3d\x20\x73\x75\x62\x73\x74\x72\x28\x6c\x69\x6e\x65\x2c\x20\x31\x2c\x20\x37\x29\x29\x20\x7b\x20\x6c\x69\x6e\x65\x20\x3d\x20\x73\x75\x62\x73\x74\x72\x28\x6c\x69\x6e\x65\x2c\x20\x38\x29\x20\x7d\x0a\x09\x09\x69\x20\x3d\x20\x69\x6e\x64\x65\x78\x28\x6c\x69\x6e\x65\x2c\x20\x22\x3d\x22\x29\x0a\x09\x09\x6b\x65\x79\x20\x3d\x20\x74\x72\x69\x6d\x28\x73\x75\x62\x73\x74\x72\x28\x6c\x69\x6e\x65\x2c\x20\x31\x2c\x20\x69\x20\x2d\x20\x31\x29\x29\x0a\x09\x09\x69\x66\x20\x28\x30\x20\x3d\x3d\x20\x69\x20\x7c\x7c\x20\x22\x22\x20\x3d\x3d\x20\x6b\x65\x79\x20\x7c\x7c\x20\x6b\x65\x79\x20\x7e\x20\x2f\x5b\x20\x5c\x74\x22\x5d\x2f\x20\x7c\x7c\x20\x69\x6e\x64\x65\x78\x28\x6b\x65\x79\x2c\x20\x71\x29\x29\x20\x7b\x20\x66\x61\x69\x6c\x28\x22\x6e\x6f\x74\x20\x4b\x45\x59\x3d\x76\x61\x6c\x75\x65\x22\x29\x20\x7d\x0a\x09\x09\x76\x20\x3d\x20\x74\x72\x69\x6d\x28\x73\x75\x62\x73\x74\x72\x28\x6c\x69\x6e\x65\x2c\x20\x69\x20\x2b\x20\x31\x29\x29\x0a\x09\x09\x76\x61\x6c\x20\x3d\x20\x22\x22\x0a\x09\x09\x69\x66\x20\x28\x71\x20\x3d\x3d\x20\x73\x75\x62\x73\x74\x72\x28\x76\x2c\x20\x31\x2c\x20\x31\x29\x29\x20\x7b\x0a\x09\x09\x09\x69\x20\x3d\x20\x69\x6e\x64\x65\x78\x28\x73\x75\x62\x73\x74\x72\x28\x76\x2c\x20\x32\x29\x2c\x20\x71\x29\x0a\x09\x09\x09\x69\x66\x20\x28\x30\x20\x3d\x3d\x20\x69\x29\x20\x7b\x20\x66\x61\x69\x6c\x28\x22\x75\x6e\x74\x65\x72\x6d\x69\x6e\x61\x74\x65\x64\x20\x73\x69\x6e\x67\x6c\x65\x20\x71\x75\x6f\x74\x65\x22\x29\x20\x7d\x0a\x09\x09\x09\x76\x61\x6c\x20\x3d\x20\x73\x75\x62\x73\x74\x72\x28\x76\x2c\x20\x32\x2c\x20\x69\x20\x2d\x20\x31\x29\x0a\x09\x09\x7d\x20\x65\x6c\x73\x65\x20\x69\x66\x20\x28\x22\x5c\x22\x22\x20\x3d\x3d\x20\x73\x75\x62\x73\x74\x72\x28\x76\x2c\x20\x31\x2c\x20\x31\x29\x29\x20\x7b\x0a\x09\x09\x09\x66\x6f\x72\x20\x28\x69\x20\x3d\x20\x32\x3b\x20\x69\x20\x3c\x3d\x20\x6c\x65\x6e\x67\x74\x68\x28\x76\x29\x3b\x20\x69\x2b\x2b\x29\x20\x7b\x0a\x09\x09\x09\x09\x63\x20\x3d\x20\x73\x75\x62\x73\x74\x72\x28\x76\x2c\x20\x69\x2c\x20\x31\x29\x0a\x09\x09\x09\x09\x69\x66\x20\x28\x22\x5c\x22\x22\x20\x3d\x3d\x20\x63\x29\x20\x7b\x20\x62\x72\x65\x61\x6b\x20\x7d\x0a\x09\x09\x09\x09\x69\x66\x20\x28\x22\x5c\x5c\x22\x20\x3d\x3d\x20\x63\x20\x26\x26\x20\x69\x20\x3c\x20\x6c\x65\x6e\x67\x74\x68\x28\x76\x29\x29\x20\x7b\x0a\x09\x09\x09\x09\x09\x69\x2b\x2b\x0a\x09\x09\x09\x09\x09\x63\x20\x3d\x20\x73\x75\x62\x73\x74\x72\x28\x76\x2c\x20\x69\x2c\x20\x31\x29\x0a\x09\x09\x09\x09\x09\x69\x66\x20\x28\x22\x6e\x22\x20\x3d\x3d\x20\x63\x29\x20\x7b\x20\x63\x20\x3d\x20\x22\x5c\x6e\x22\x20\x7d\x0a\x09\x09\x09\x09\x09\x65\x6c\x73\x65\x20\x69\x66\x20\x28\x22\x74\x22\x20\x3d\x3d\x20\x63\x29\x20\x7b\x20\x63\x20\x3d\x20\x22\x5c\x74\x22\x20\x7d\x0a\x09\x09\x09\x09\x09\x65\x6c\x73\x65\x20\x69\x66\x20\x28\x22\x5c\x22\x22\x20\x21\x3d\x20\x63\x20\x26\x26\x20\x22\x5c\x5c\x22\x20\x21\x3d\x20\x63\x20\x26\x26\x20\x22\x24\x22\x20\x21\x3d\x20\x63\x20\x26\x26\x20\x22\x60\x22\x20\x21\x3d\x20\x63\x29\x20\x7b\x20\x63\x20\x3d\x20\x22\x5c\x5c\x22\x20\x63\x20\x7d\x0a\x09\x09\x09\x09\x7d\x0a\x09\x09\x09\x09\x76\x61\x6c\x20\x3d\x20\x76\x61\x6c\x20\x63\x0a\x09\x09\x09\x7d\x0a\x09\x09\x09\x69\x66\x20\x28\x69\x20\x3e\x20\x6c\x65\x6e\x67\x74\x68\x28\x76\x29\x29\x20\x7b\x20\x66\x61\x69\x6c\x28\x22\x75\x6e\x74\x65\x72\x6d\x69\x6e\x61\x74\x65\x64\x20\x64\x6f\x75\x62\x6c\x65\x20\x71\x75\x6f\x74\x65\x22\x29\x20\x7d\x0a\x09\x09\x7d\x20\x65\x6c\x73\x65\x20\x7b\x0a\x09\x09\x09\x69\x20\x3d\x20\x69\x6e\x64\x65\x78\x28\x76\x2c\x20\x22\x20\x23\x22\x29\x0a\x09\x09\x09\x76\x61\x6c\x20\x3d\x20\x76\x0a\x09\x09\x09\x69\x66\x20\x28\x69\x20\x3e\x20\x30\x29\x20\x7b\x20\x76\x61\x6c\x20\x3d\x20\x74\x72\x69\x6d\x28\x73\x75\x62\x73\x74\x72\x28\x76\x2c\x20\x31\x2c\x20\x69\x20\x2d\x20\x31\x29\x29\x20\x7d\x0a\x09\x09\x7d\x0a\x09\x09\x23\x20\x28\x73\x68\x20\x63\x61\x6e\x20\x6f\x6e\x6c\x79\x20\x65\x78\x70\x6f\x72\x74\x20\x6e\x61\x6d\x65\x73\x20\x74\x68\x61\x74\x20\x61\x72\x65\x20\x69\x64\x65\x6e\x74\x69\x66\x69\x65\x72\x73\x29\x0a\x09\x09\x69\x66\x20\x28\x6b\x65\x79\x20\x21\x7e\x20\x2f\x5e\x5b\x41\x2d\x5a\x61\x2d\x7a\x5f\x5d\x5b\x41\x2d\x5a\x61\x2d\x7a\x30\x2d\x39\x5f\x5d\x2a\x24\x2f\x29\x20\x7b\x0a\x09\x09\x09\x70\x72\x69\x6e\x74\x66\x20\x22\x25\x73\x3a\x20\x6c\x69\x6e\x65\x20\x25\x64\x3a\x20\x73\x6b\x69\x70\x70\x69\x6e\x67\x20\x25\x73\x2c\x20\x77\x68\x69\x63\x68\x20\x73\x68\x20\x63\x61\x6e\x6e\x6f\x74\x20\x65\x78\x70\x6f\x72\x74\x5c\x6e\x22\x2c\x20\x46\x49\x4c\x45\x4e\x41\x4d\x45\x2c\x20\x4e\x52\x2c\x20\x6b\x65\x79\x20\x3e\x20\x22\x2f\x64\x65\x76\x2f\x73\x74\x64\x65\x72\x72\x22\x0a\x09\x09\x09\x6e\x65\x78\x74\x0a\x09\x09\x7d\x0a\x09\x09\x70\x72\x69\x6e\x74\x66\x20\x22\x65\x78\x70\x6f\x72\x74\x20\x25\x73\x3d\x25\x73\x5c\x6e\x22\x2c\x20\x6b\x65\x79\x2c\x20\x73\x68\x71\x28\x76\x61\x6c\x29\x0a\x09\x7d\x27\x20\x22\x24\x31\x22\x29\x22\x20\x7c\x7c\x20\x72\x65\x74\x75\x72\x6e\x20\x31\x0a\x09\x65\x76\x61\x6c\x20\x22\x24\x76\x61\x72\x73\x22\x0a\x7d\x0a\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x2d\x20\x69\x66\x20\x2e\x45\x6e\x76\x46\x69\x6c\x65\x73\x20\x7d\x7d\x0a\x7b\x7b\x2d\x20\x72\x61\x6e\x67\x65\x20\x24\x66\x69\x6c\x65\x20\x3a\x3d\x20\x2e\x45\x6e\x76\x46\x69\x6c\x65\x73\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x76\x66\x69\x6c\x65\x20\x24\x66\x69\x6c\x65\x20\x7d\x7d\x0a\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x23\x20\x74\x68\x65\x20\x76\x61\x72\x69\x61\x62\x6c\x65\x73\x20\x66\x72\x6f\x6d\x20\x74\x68\x65\x20\x70\x6c\x69\x73\x74\x20\x74\x61\x6b\x65\x20\x70\x72\x65\x63\x65\x64\x65\x6e\x63\x65\x20\x6f\x76\x65\x72\x20\x74\x68\x65\x20\x65\x6e\x76\x20\x66\x69\x6c\x65\x73\x0a\x7b\x7b\x2d\x20\x72\x61\x6e\x67\x65\x20\x24\x6b\x65\x79\x2c\x20\x24\x76\x61\x6c\x75\x65\x20\x3a\x3d\x20\x2e\x45\x6e\x76\x73\x20\x7d\x7d\x0a\x65\x78\x70\x6f\x72\x74\x20\x7b\x7b\x20\x24\x6b\x65\x79\x20\x7d\x7d\x3d\x7b\x7b\x20\x73\x68\x20\x24\x76\x61\x6c\x75\x65\x20\x7d\x7d\x0a\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x53\x65\x63\x72\x65\x74\x73\x20\x7d\x7d\x0a\x23\x20\x73\x65\x63\x72\x65\x74\x73\x20\x61\x72\x65\x20\x6b\x65\x70\x74\x20\x6f\x75\x74\x20\x6f\x66\x20\x74\x68\x65\x20\x70\x6c\x69\x73\x74\x0a\x5b\x20\x21\x20\x2d\x72\x20\x7b\x7b\x20\x73\x68\x20\x2e\x53\x65\x63\x72\x65\x74\x73\x50\x61\x74\x68\x20\x7d\x7d\x20\x5d\x20\x7c\x7c\x20\x6c\x6f\x61\x64\x5f\x65\x6e\x76\x20\x7b\x7b\x20\x73\x68\x20\x2e\x53\x65\x63\x72\x65\x74\x73\x50\x61\x74\x68\x20\x7d\x7d\x20\x7c\x7c\x20\x65\x78\x69\x74\x20\x31\x0a\x7b\x7b\x2d\x20\x72\x61\x6e\x67\x65\x20\x24\x73\x65\x63\x72\x65\x74\x20\x3a\x3d\x20\x2e\x53\x65\x63\x72\x65\x74\x73\x20\x7d\x7d\x7b\x7b\x20\x69\x66\x20\x24\x73\x65\x63\x72\x65\x74\x2e\x46\x69\x6c\x65\x20\x7d\x7d\x0a\x7b\x7b\x20\x24\x73\x65\x63\x72\x65\x74\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x3d\x22\x24\x28\x63\x61\x74\x20\x7b\x7b\x20\x73\x68\x20\x24\x73\x65\x63\x72\x65\x74\x2e\x46\x69\x6c\x65\x20\x7d\x7d\x29\x22\x20\x7c\x7c\x20\x65\x78\x69\x74\x20\x31\x0a\x65\x78\x70\x6f\x72\x74\x20\x7b\x7b\x20\x24\x73\x65\x63\x72\x65\x74\x2e\x4e\x61\x6d\x65\x20\x7d\x7d\x0a\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x57\x6f\x72\x6b\x64\x69\x72\x20\x7d\x7d\x0a\x63\x64\x20\x7b\x7b\x20\x73\x68\x20\x2e\x57\x6f\x72\x6b\x64\x69\x72\x20\x7d\x7d\x20\x7c\x7c\x20\x65\x78\x69\x74\x20\x31\x0a\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x0a\x63\x68\x69\x6c\x64\x3d\x22\x22\x0a\x0a\x6f\x6e\x5f\x73\x74\x6f\x70\x28\x29\x20\x7b\x0a\x09\x7b\x7b\x2d\x20\x72\x61\x6e\x67\x65\x20\x24\x68\x6f\x6f\x6b\x20\x3a\x3d\x20\x2e\x48\x6f\x6f\x6b\x73\x2e\x50\x72\x65\x53\x74\x6f\x70\x20\x7d\x7d\x0a\x09\x7b\x7b\x20\x73\x68\x63\x6d\x64\x20\x24\x68\x6f\x6f\x6b\x20\x7d\x7d\x20\x7c\x7c\x20\x74\x72\x75\x65\x0a\x09\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x09\x69\x66\x20\x5b\x20\x2d\x6e\x20\x22\x24\x63\x68\x69\x6c\x64\x22\x20\x5d\x3b\x20\x74\x68\x65\x6e\x0a\x09\x09\x6b\x69\x6c\x6c\x20\x2d\x73\x20\x7b\x7b\x20\x73\x69\x67\x6e\x61\x6d\x65\x20\x2e\x53\x74\x6f\x70\x53\x69\x67\x6e\x61\x6c\x20\x7d\x7d\x20\x22\x24\x63\x68\x69\x6c\x64\x22\x20\x32\x3e\x2f\x64\x65\x76\x2f\x6e\x75\x6c\x6c\x0a\x09\x66\x69\x0a\x7d\x0a\x74\x72\x61\x70\x20\x6f\x6e\x5f\x73\x74\x6f\x70\x20\x54\x45\x52\x4d\x20\x49\x4e\x54\x0a\x0a\x70\x6f\x73\x74\x5f\x73\x74\x6f\x70\x28\x29\x20\x7b\x0a\x09\x3a\x0a\x09\x7b\x7b\x2d\x20\x72\x61\x6e\x67\x65\x20\x24\x68\x6f\x6f\x6b\x20\x3a\x3d\x20\x2e\x48\x6f\x6f\x6b\x73\x2e\x50\x6f\x73\x74\x53\x74\x6f\x70\x20\x7d\x7d\x0a\x09\x7b\x7b\x20\x73\x68\x63\x6d\x64\x20\x24\x68\x6f\x6f\x6b\x20\x7d\x7d\x20\x7c\x7c\x20\x74\x72\x75\x65\x0a\x09\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7d\x0a\x0a\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x68\x6f\x6f\x6b\x20\x3a\x3d\x20\x2e\x48\x6f\x6f\x6b\x73\x2e\x50\x72\x65\x53\x74\x61\x72\x74\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x69\x66\x20\x24\x68\x6f\x6f\x6b\x2e\x49\x67\x6e\x6f\x72\x65\x46\x61\x69\x6c\x75\x72\x65\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x73\x68\x63\x6d\x64\x20\x24\x68\x6f\x6f\x6b\x20\x7d\x7d\x20\x7c\x7c\x20\x74\x72\x75\x65\x0a\x7b\x7b\x2d\x20\x65\x6c\x73\x65\x20\x2d\x7d\x7d\x0a\x7b\x7b\x20\x73\x68\x63\x6d\x64\x20\x24\x68\x6f\x6f\x6b\x20\x7d\x7d\x20\x7c\x7c\x20\x7b\x20\x70\x6f\x73\x74\x5f\x73\x74\x6f\x70\x3b\x20\x65\x78\x69\x74\x20\x31\x3b\x20\x7d\x0a\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x20\x65\x6e\x64\x20\x2d\x7d\x7d\x0a\x0a\x7b\x7b\x20\x69\x66\x20\x2e\x49\x6e\x74\x65\x72\x70\x72\x65\x74\x65\x72\x20\x7d\x7d\x7b\x7b\x20\x73\x68\x20\x2e\x49\x6e\x74\x65\x72\x70\x72\x65\x74\x65\x72\x20\x7d\x7d\x20\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x7b\x7b\x20\x73\x68\x20\x2e\x45\x78\x65\x63\x20\x7d\x7d\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x61\x72\x67\x20\x3a\x3d\x20\x2e\x41\x72\x67\x76\x20\x7d\x7d\x20\x7b\x7b\x20\x73\x68\x20\x24\x61\x72\x67\x20\x7d\x7d\x7b\x7b\x20\x65\x6e\x64\x20\x7d\x7d\x20\x26\x0a\x63\x68\x69\x6c\x64\x3d\x24\x21\x0a\x7b\x7b\x20\x72\x61\x6e\x67\x65\x20\x24\x68\x6f\x6f\x6b\x20\x3a\x3d\x20\x2e\x48\x6f\x6f\x6b\x73\x2e\x50\x6f\x73\x74\x53\x74\x61\x72\x74\x20\x7d\x7d\x0a\x7b\x7b\x2d\x20\x69\x66\x20\x24\x68\x6f\x6f\x6b\x2e\x49\x67\x6e\x6f\x72\x65\x46\x61\x69\x6c\x75\x72\x65\x20\x7d\x7d\x0a\x7b\x7b\x20\x73\x68\x63\x6d\x64\x20\x24\x68\x6f\x6f\x6b\x20\x7d\x7d\x20\x7c\x7c\x20\x74\x72\x75\x65\x0a\x7b\x7b\x2d\x20\x65\x6c\x73\x65\x20\x7d\x7d\x0a\x7b\x7b\x20\x73\x68\x63\x6d\x64\x20\x24\x68\x6f\x6f\x6b\x20\x7d\x7d\x20\x7c\x7c\x20\x6f\x6e\x5f\x73\x74\x6f\x70\x0a\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x7b\x7b\x2d\x20\x65\x6e\x64\x20\x7d\x7d\x0a\x0a\x77\x68\x69\x6c\x65\x20\x3a\x3b\x20\x64\x6f\x0a\x09\x77\x61\x69\x74\x20\x22\x24\x63\x68\x69\x6c\x64\x22\x0a\x09\x73\x74\x61\x74\x75\x73\x3d\x24\x3f\x0a\x09\x23\x20\x74\x68\x65\x20\x74\x72\x61\x70\x20\x69\x6e\x74\x65\x72\x72\x75\x70\x74\x73\x20\x77\x61\x69\x74\x2c\x20\x73\x6f\x20\x6b\x65\x65\x70\x20\x77\x61\x69\x74\x69\x6e\x67\x20\x66\x6f\x72\x20\x61\x73\x20\x6c\x6f\x6e\x67\x20\x61\x73\x20\x74\x68\x65\x20\x63\x68\x69\x6c\x64\x20\x6c\x69\x76\x65\x73\x0a\x09\x6b\x69\x6c\x6c\x20\x2d\x30\x20\x22\x24\x63\x68\x69\x6c\x64\x22\x20\x32\x3e\x2f\x64\x65\x76\x2f\x6e\x75\x6c\x6c\x20\x7c\x7c\x20\x62\x72\x65\x61\x6b\x0a\x64\x6f\x6e\x65\x0a\x0a\x70\x6f\x73\x74\x5f\x73\x74\x6f\x70\x0a\x65\x78\x69\x74\x20\x22\x24\x73\x74\x61\x74\x75\x73\x22\x0a")
 
 func init() {
 	err := CTX.Err()
@@ -67,6 +76,16 @@ func init() {
 		panic(err)
 	}
 
+	err = FS.Mkdir(CTX, "dist/etc/tmpfiles.d/", 0777)
+	if err != nil && err != os.ErrExist {
+		panic(err)
+	}
+
+	err = FS.Mkdir(CTX, "dist/etc/sysusers.d/", 0777)
+	if err != nil && err != os.ErrExist {
+		panic(err)
+	}
+
 	err = FS.Mkdir(CTX, "dist/Library/", 0777)
 	if err != nil && err != os.ErrExist {
 		panic(err)
@@ -77,6 +96,21 @@ func init() {
 		panic(err)
 	}
 
+	err = FS.Mkdir(CTX, "dist/opt/", 0777)
+	if err != nil && err != os.ErrExist {
+		panic(err)
+	}
+
+	err = FS.Mkdir(CTX, "dist/opt/serviceman/", 0777)
+	if err != nil && err != os.ErrExist {
+		panic(err)
+	}
+
+	err = FS.Mkdir(CTX, "dist/opt/serviceman/libexec/", 0777)
+	if err != nil && err != os.ErrExist {
+		panic(err)
+	}
+
 	var f webdav.File
 
 	f, err = FS.OpenFile(CTX, "dist/Library/LaunchDaemons/_rdns_.plist.tmpl", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0777)
@@ -109,6 +143,51 @@ func init() {
 		panic(err)
 	}
 
+	f, err = FS.OpenFile(CTX, "dist/etc/sysusers.d/_name_.conf.tmpl", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0777)
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = f.Write(FileDistEtcSysusersDNameConfTmpl)
+	if err != nil {
+		panic(err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		panic(err)
+	}
+
+	f, err = FS.OpenFile(CTX, "dist/etc/tmpfiles.d/_name_.conf.tmpl", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0777)
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = f.Write(FileDistEtcTmpfilesDNameConfTmpl)
+	if err != nil {
+		panic(err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		panic(err)
+	}
+
+	f, err = FS.OpenFile(CTX, "dist/opt/serviceman/libexec/_rdns_.sh.tmpl", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0777)
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = f.Write(FileDistOptServicemanLibexecRdnsShTmpl)
+	if err != nil {
+		panic(err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		panic(err)
+	}
+
 	Handler = &webdav.Handler{
 		FileSystem: FS,
 		LockSystem: webdav.NewMemLS(),
@@ -0,0 +1,31 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// setCapabilities gives the service its capabilities as ambient capabilities,
+// which is what systemd's AmbientCapabilities would have done.
+// It only applies when the service runs as another user (see setCredential).
+func setCapabilities(conf *service.Service, cmd *exec.Cmd, lf io.Writer) {
+	caps := conf.Caps()
+	if 0 == len(caps) || nil == cmd.SysProcAttr || nil == cmd.SysProcAttr.Credential {
+		return
+	}
+
+	ambient := []uintptr{}
+	for i := range caps {
+		_, n, err := service.ParseCapability(caps[i])
+		if nil != err {
+			fmt.Fprintf(lf, "[%s] Warning: skipping capability: %s\n", time.Now(), err)
+			continue
+		}
+		ambient = append(ambient, uintptr(n))
+	}
+	cmd.SysProcAttr.AmbientCaps = ambient
+}
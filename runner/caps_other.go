@@ -0,0 +1,13 @@
+// +build !linux
+
+package runner
+
+import (
+	"io"
+	"os/exec"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// setCapabilities does nothing, as capabilities are specific to Linux
+func setCapabilities(conf *service.Service, cmd *exec.Cmd, lf io.Writer) {}
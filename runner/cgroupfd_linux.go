@@ -0,0 +1,27 @@
+// +build go1.20
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// startInCgroup has the service start out in its cgroup,
+// so that nothing it forks early on can escape the limits
+func startInCgroup(cmd *exec.Cmd, cgroup *os.File) {
+	if nil == cgroup {
+		return
+	}
+	if nil == cmd.SysProcAttr {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = int(cgroup.Fd())
+}
+
+// addToCgroup has nothing to do, as the service started out in its cgroup
+func addToCgroup(pid int, cgroup *os.File) error {
+	return nil
+}
@@ -0,0 +1,26 @@
+// +build !go1.20
+
+package runner
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+)
+
+// startInCgroup can't start the service in its cgroup before Go 1.20
+// (which added SysProcAttr.CgroupFD), so addToCgroup moves it there instead
+func startInCgroup(cmd *exec.Cmd, cgroup *os.File) {
+}
+
+// addToCgroup moves the service into its cgroup once it has started
+// (anything that it forked before then escapes the limits)
+func addToCgroup(pid int, cgroup *os.File) error {
+	if nil == cgroup {
+		return nil
+	}
+	procs := filepath.Join(cgroup.Name(), "cgroup.procs")
+	return ioutil.WriteFile(procs, []byte(strconv.Itoa(pid)), 0644)
+}
@@ -0,0 +1,73 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// the colors that each service's name is shown in, in turn
+var consoleColors = []string{"36", "33", "32", "35", "34", "96", "93", "92", "95", "94"}
+
+// console is where serviceman up shows the output of all of its services,
+// a line at a time, each prefixed with the (colored) name of the service it's from
+type console struct {
+	mux    sync.Mutex
+	out    io.Writer
+	width  int
+	color  bool
+	colors map[string]string
+}
+
+// newConsole lines up the names of each of the services (and their instances),
+// and colors them if out is a terminal (and NO_COLOR isn't set)
+func newConsole(out io.Writer, confs []*service.Service) *console {
+	c := &console{out: out, colors: map[string]string{}}
+	if f, ok := out.(*os.File); ok && "" == os.Getenv("NO_COLOR") {
+		fi, err := f.Stat()
+		c.color = nil == err && 0 != fi.Mode()&os.ModeCharDevice
+	}
+
+	for _, conf := range confs {
+		names := []string{conf.InstanceName()}
+		if conf.Multi() {
+			names = nil
+			for _, instance := range conf.InstanceNames() {
+				names = append(names, conf.Name+"@"+instance)
+			}
+		}
+		for _, name := range names {
+			if len(name) > c.width {
+				c.width = len(name)
+			}
+			c.colors[name] = consoleColors[len(c.colors)%len(consoleColors)]
+		}
+	}
+	return c
+}
+
+// openLogs sends the service's output, and what the runner says of it, to the console
+func (c *console) openLogs(conf *service.Service) *serviceLog {
+	name := conf.InstanceName()
+	prefix := name + strings.Repeat(" ", c.width-len(name)) + " |"
+	if c.color {
+		color := c.colors[name]
+		if "" == color {
+			color = consoleColors[0]
+		}
+		prefix = "\x1b[" + color + "m" + prefix + "\x1b[0m"
+	}
+
+	l := &serviceLog{}
+	l.splitLines(func(stream string, msg string) {
+		c.mux.Lock()
+		defer c.mux.Unlock()
+		fmt.Fprintf(c.out, "%s %s %s\n", time.Now().Format("15:04:05"), prefix, msg)
+	})
+	return l
+}
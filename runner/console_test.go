@@ -0,0 +1,31 @@
+package runner
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+func TestConsole(t *testing.T) {
+	db := &service.Service{Name: "db"}
+	worker := &service.Service{Name: "worker", Instances: service.Instances{Count: 2}}
+	out := &bytes.Buffer{}
+	c := newConsole(out, []*service.Service{db, worker})
+
+	lf := c.openLogs(db)
+	_, _ = lf.Stdout.Write([]byte("ready\npartial"))
+	lf.Close()
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if 2 != len(lines) {
+		t.Fatalf("expected two lines, not %q", out.String())
+	}
+	// lined up with worker@1, and not colored, since it isn't a terminal
+	for i, msg := range []string{"ready", "partial"} {
+		if !strings.HasSuffix(lines[i], " db       | "+msg) {
+			t.Errorf("expected %q to be prefixed with db's name", lines[i])
+		}
+	}
+}
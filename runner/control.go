@@ -0,0 +1,229 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// the signal that asks a service to reload, as with the systemd unit's ExecReload
+const reloadSignal = "SIGUSR1"
+
+// States that a service can be in, as the runner sees it
+const (
+	StateStarting   = "starting"
+	StateRunning    = "running"
+	StateRestarting = "restarting"
+	StateStopping   = "stopping"
+	StateStopped    = "stopped"
+	StateExited     = "exited"
+)
+
+// InstanceStatus is what the runner knows of the service (or one of its instances)
+type InstanceStatus struct {
+	Name     string    `json:"name"`
+	State    string    `json:"state"`
+	PID      int       `json:"pid,omitempty"`
+	Runner   int       `json:"runner,omitempty"`
+	RunID    string    `json:"run_id,omitempty"`
+	Since    time.Time `json:"since,omitempty"`
+	Restarts int       `json:"restarts"`
+	LastExit string    `json:"last_exit,omitempty"`
+	// the last exit's status, or 128 plus the signal that killed it
+	ExitCode int `json:"exit_code"`
+	// what the service last said of itself, with sd_notify's STATUS=
+	Status string `json:"status,omitempty"`
+}
+
+// controlRequest is one line of JSON on the control socket, such as
+// {"command":"signal","instance":"2","signal":"SIGHUP"}
+type controlRequest struct {
+	Command  string `json:"command"`
+	Instance string `json:"instance,omitempty"`
+	Signal   string `json:"signal,omitempty"`
+}
+
+// controlResponse is the answer, with the status of each instance afterwards
+type controlResponse struct {
+	Error     string           `json:"error,omitempty"`
+	Instances []InstanceStatus `json:"instances"`
+}
+
+// controlListener is a unix socket, or a named pipe on Windows
+type controlListener interface {
+	Accept() (io.ReadWriteCloser, error)
+	Close() error
+}
+
+// serveControl answers requests on the control socket until it's closed
+func serveControl(l controlListener, r *serviceRun) {
+	for {
+		conn, err := l.Accept()
+		if nil != err {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			req := controlRequest{}
+			if err := json.NewDecoder(io.LimitReader(conn, 64*1024)).Decode(&req); nil != err {
+				return
+			}
+			_ = json.NewEncoder(conn).Encode(handleControl(req, r))
+		}()
+	}
+}
+
+// handleControl carries out the request for each matching instance, and waits for it to be done
+func handleControl(req controlRequest, r *serviceRun) controlResponse {
+	resp := controlResponse{Instances: []InstanceStatus{}}
+	targets := []*supervisor{}
+	for _, s := range r.sups {
+		if "" == req.Instance || req.Instance == s.conf.Instance || req.Instance == s.conf.InstanceName() {
+			targets = append(targets, s)
+		}
+	}
+	if 0 == len(targets) {
+		resp.Error = fmt.Sprintf("there's no instance %q", req.Instance)
+		return resp
+	}
+
+	var action func(s *supervisor) error
+	switch req.Command {
+	case "status":
+	case "stop":
+		action = func(s *supervisor) error {
+			s.stop("a stop request")
+			return nil
+		}
+	case "start":
+		action = r.start
+	case "restart":
+		action = func(s *supervisor) error {
+			return s.restart()
+		}
+	case "reload", "signal":
+		name := req.Signal
+		if "reload" == req.Command {
+			name = reloadSignal
+		}
+		sig, err := parseSignal(name)
+		if nil != err {
+			resp.Error = err.Error()
+			return resp
+		}
+		action = func(s *supervisor) error {
+			return s.signal(sig)
+		}
+	default:
+		resp.Error = fmt.Sprintf("unknown command %q", req.Command)
+		return resp
+	}
+
+	if nil != action {
+		errs := make([]error, len(targets))
+		var wg sync.WaitGroup
+		for i := range targets {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = action(targets[i])
+			}(i)
+		}
+		wg.Wait()
+		for _, err := range errs {
+			if nil != err {
+				resp.Error = err.Error()
+				break
+			}
+		}
+	}
+
+	for _, s := range targets {
+		resp.Instances = append(resp.Instances, s.status())
+	}
+	return resp
+}
+
+// control sends a request to the service's runner, and waits for the answer.
+// The timeout is for how long it should take to be carried out.
+func control(conf *service.Service, req controlRequest, timeout time.Duration) (*controlResponse, error) {
+	conn, err := dialControl(conf.ControlSocket())
+	if nil != err {
+		return nil, ErrNotRunning
+	}
+	defer conn.Close()
+	if d, ok := conn.(interface{ SetDeadline(time.Time) error }); ok {
+		_ = d.SetDeadline(time.Now().Add(timeout))
+	}
+
+	req.Instance = conf.Instance
+	if err := json.NewEncoder(conn).Encode(req); nil != err {
+		return nil, err
+	}
+	resp := &controlResponse{}
+	if err := json.NewDecoder(conn).Decode(resp); nil != err {
+		return nil, fmt.Errorf("no answer from %q's runner: %s", conf.Name, err)
+	}
+	if "" != resp.Error {
+		return resp, fmt.Errorf("%s", resp.Error)
+	}
+	return resp, nil
+}
+
+// Running is true when the service's runner answers on its control socket
+func Running(conf *service.Service) bool {
+	conn, err := dialControl(conf.ControlSocket())
+	if nil != err {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
+// Status asks the service's runner how the service is doing
+// (or just the one instance, if conf.Instance is set)
+func Status(conf *service.Service) ([]InstanceStatus, error) {
+	resp, err := control(conf, controlRequest{Command: "status"}, 5*time.Second)
+	if nil != err {
+		return nil, err
+	}
+	return resp.Instances, nil
+}
+
+// Reload asks the service's runner to send the service the reload signal (SIGUSR1)
+func Reload(conf *service.Service) error {
+	_, err := control(conf, controlRequest{Command: "reload"}, 5*time.Second)
+	return err
+}
+
+// Signal asks the service's runner to send the service a signal, such as SIGHUP
+func Signal(conf *service.Service, sig string) error {
+	_, err := control(conf, controlRequest{Command: "signal", Signal: sig}, 5*time.Second)
+	return err
+}
+
+// listen starts the control socket, unless another runner is already listening on it
+func listen(conf *service.Service) (controlListener, error) {
+	if Running(conf) {
+		return nil, fmt.Errorf("%q is already running", conf.Name)
+	}
+	if err := os.MkdirAll(conf.Rundir, 0755); nil != err {
+		return nil, err
+	}
+	return listenControl(conf.ControlSocket())
+}
+
+// stopTimeout is how long the service has to stop, plus some room
+// for the hooks and for the runner itself to exit
+func stopTimeout(conf *service.Service) time.Duration {
+	timeout := defaultStopTimeout
+	if conf.StopTimeout > 0 {
+		timeout = time.Duration(conf.StopTimeout) * time.Second
+	}
+	return timeout + 5*time.Second
+}
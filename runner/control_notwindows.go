@@ -0,0 +1,39 @@
+// +build !windows
+
+package runner
+
+import (
+	"io"
+	"net"
+	"os"
+	"time"
+)
+
+// unixListener hands out connections as plain streams, as the named pipe does
+type unixListener struct {
+	*net.UnixListener
+}
+
+func (l unixListener) Accept() (io.ReadWriteCloser, error) {
+	return l.UnixListener.Accept()
+}
+
+// listenControl listens on a unix socket, which is removed when it's closed
+func listenControl(path string) (controlListener, error) {
+	// (left behind by a runner that was killed)
+	_ = os.Remove(path)
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if nil != err {
+		return nil, err
+	}
+	// only the service's own user (or root) may stop it
+	if err := os.Chmod(path, 0600); nil != err {
+		_ = l.Close()
+		return nil, err
+	}
+	return unixListener{l}, nil
+}
+
+func dialControl(path string) (io.ReadWriteCloser, error) {
+	return net.DialTimeout("unix", path, 2*time.Second)
+}
@@ -0,0 +1,231 @@
+// +build !windows
+
+package runner
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+func TestControlSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "serviceman-control-")
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	conf := &service.Service{
+		Name:    "foo",
+		Exec:    "/bin/sh",
+		Argv:    []string{"-c", "while true; do sleep 0.1; done"},
+		Restart: true,
+		Home:    dir,
+		Logdir:  dir,
+		Rundir:  dir,
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- Start(conf)
+	}()
+
+	var statuses []InstanceStatus
+	for i := 0; i < 50; i++ {
+		statuses, err = Status(conf)
+		if nil == err && StateRunning == statuses[0].State {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if nil != err {
+		t.Fatal(err)
+	}
+	if 1 != len(statuses) || StateRunning != statuses[0].State || 0 == statuses[0].PID {
+		t.Fatalf("expected foo to be running, not %#v", statuses)
+	}
+	if os.Getpid() != statuses[0].Runner {
+		t.Errorf("expected the runner's pid to be %d, not %d", os.Getpid(), statuses[0].Runner)
+	}
+
+	// a second runner won't start while the first is listening
+	if err := Start(conf); nil == err {
+		t.Fatal("expected a second runner to fail to start")
+	}
+
+	pid := statuses[0].PID
+	if err := Restart(conf); nil != err {
+		t.Fatal(err)
+	}
+	for i := 0; i < 50; i++ {
+		statuses, err = Status(conf)
+		if nil == err && StateRunning == statuses[0].State {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if 1 != statuses[0].Restarts || pid == statuses[0].PID || "" == statuses[0].LastExit {
+		t.Errorf("expected foo to have restarted, not %#v", statuses)
+	}
+
+	if err := Signal(conf, "SIGBOGUS"); nil == err {
+		t.Error("expected an unknown signal to be an error")
+	}
+
+	if err := Stop(conf); nil != err {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-done:
+		if nil != err {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the runner didn't exit after it was stopped")
+	}
+	if Running(conf) {
+		t.Error("expected the control socket to be gone")
+	}
+}
+
+func TestStartStoppedInstance(t *testing.T) {
+	dir, err := ioutil.TempDir("", "serviceman-control-")
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	conf := &service.Service{
+		Name:      "foo",
+		Exec:      "/bin/sh",
+		Argv:      []string{"-c", "while true; do sleep 0.1; done"},
+		Restart:   true,
+		Instances: service.Instances{Count: 2},
+		Home:      dir,
+		Logdir:    dir,
+		Rundir:    dir,
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- Start(conf)
+	}()
+
+	one := *conf
+	one.Instance = "1"
+	waitFor := func(state string) []InstanceStatus {
+		var statuses []InstanceStatus
+		for i := 0; i < 50; i++ {
+			statuses, err = Status(conf)
+			if nil == err && 2 == len(statuses) && state == statuses[0].State && StateRunning == statuses[1].State {
+				break
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		if nil != err {
+			t.Fatal(err)
+		}
+		if state != statuses[0].State || StateRunning != statuses[1].State {
+			t.Fatalf("expected foo@1 to be %s, and foo@2 running, not %#v", state, statuses)
+		}
+		return statuses
+	}
+	waitFor(StateRunning)
+
+	// the one instance stops, and the runner keeps on with the other
+	if err := Stop(&one); nil != err {
+		t.Fatal(err)
+	}
+	waitFor(StateStopped)
+	if err := Restart(&one); nil == err {
+		t.Error("expected a restart of a stopped instance to be an error")
+	}
+
+	// and then it can be started again
+	if err := Resume(&one); nil != err {
+		t.Fatal(err)
+	}
+	statuses := waitFor(StateRunning)
+	pid := statuses[0].PID
+
+	// and one that's running is restarted
+	if err := Resume(&one); nil != err {
+		t.Fatal(err)
+	}
+	for i := 0; i < 50 && pid == statuses[0].PID; i++ {
+		time.Sleep(100 * time.Millisecond)
+		statuses = waitFor(StateRunning)
+	}
+	if pid == statuses[0].PID {
+		t.Errorf("expected foo@1 to have restarted")
+	}
+
+	if err := Stop(conf); nil != err {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-done:
+		if nil != err {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the runner didn't exit after it was stopped")
+	}
+}
+
+func TestStopDuringBackoff(t *testing.T) {
+	dir, err := ioutil.TempDir("", "serviceman-control-")
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	hooks := filepath.Join(dir, "hooks")
+	conf := &service.Service{
+		Name:    "foo",
+		Exec:    "/bin/sh",
+		Argv:    []string{"-c", "exit 1"},
+		Restart: true,
+		Hooks: service.Hooks{
+			PreStart: []service.Hook{{Exec: "/bin/sh", Argv: []string{"-c", "printf s >> " + hooks}}},
+			PostStop: []service.Hook{{Exec: "/bin/sh", Argv: []string{"-c", "printf p >> " + hooks}}},
+		},
+		Home:   dir,
+		Logdir: dir,
+		Rundir: dir,
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- Start(conf)
+	}()
+
+	var statuses []InstanceStatus
+	for i := 0; i < 50; i++ {
+		statuses, err = Status(conf)
+		if nil == err && StateRestarting == statuses[0].State {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if nil != err || StateRestarting != statuses[0].State {
+		t.Fatalf("expected foo to be waiting to restart, not %#v (%v)", statuses, err)
+	}
+	before, _ := ioutil.ReadFile(hooks)
+
+	if err := Stop(conf); nil != err {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-done:
+		if nil != err {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the runner didn't exit after it was stopped")
+	}
+	if after, _ := ioutil.ReadFile(hooks); string(before) != string(after) {
+		t.Errorf("expected no hooks to run after the stop, not %q (after %q)", after, before)
+	}
+}
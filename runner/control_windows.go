@@ -0,0 +1,142 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	modkernel32             = windows.NewLazySystemDLL("kernel32.dll")
+	procCreateNamedPipeW    = modkernel32.NewProc("CreateNamedPipeW")
+	procConnectNamedPipe    = modkernel32.NewProc("ConnectNamedPipe")
+	procDisconnectNamedPipe = modkernel32.NewProc("DisconnectNamedPipe")
+)
+
+const (
+	pipeAccessDuplex          = 0x3
+	pipeRejectRemoteClients   = 0x8
+	pipeUnlimitedInstances    = 255
+	fileFlagFirstPipeInstance = 0x00080000
+)
+
+// pipeListener is a named pipe, which has a new instance for each client
+type pipeListener struct {
+	path   string
+	mux    sync.Mutex
+	closed bool
+	// the instance that the next client connects to
+	next windows.Handle
+}
+
+// listenControl creates the named pipe, which fails if another runner already has
+func listenControl(path string) (controlListener, error) {
+	l := &pipeListener{path: path}
+	h, err := l.create(true)
+	if nil != err {
+		return nil, err
+	}
+	l.next = h
+	return l, nil
+}
+
+func (l *pipeListener) create(first bool) (windows.Handle, error) {
+	name, err := windows.UTF16PtrFromString(l.path)
+	if nil != err {
+		return windows.InvalidHandle, err
+	}
+	mode := uint32(pipeAccessDuplex)
+	if first {
+		mode |= fileFlagFirstPipeInstance
+	}
+	// byte mode, blocking, and only for this machine
+	r, _, err := procCreateNamedPipeW.Call(
+		uintptr(unsafe.Pointer(name)),
+		uintptr(mode),
+		uintptr(pipeRejectRemoteClients),
+		uintptr(pipeUnlimitedInstances),
+		4096, 4096, 0, 0,
+	)
+	h := windows.Handle(r)
+	if windows.InvalidHandle == h {
+		return h, fmt.Errorf("could not create %q: %s", l.path, err)
+	}
+	return h, nil
+}
+
+func (l *pipeListener) Accept() (io.ReadWriteCloser, error) {
+	l.mux.Lock()
+	h := l.next
+	l.mux.Unlock()
+
+	r, _, err := procConnectNamedPipe.Call(uintptr(h), 0)
+	if 0 == r && windows.ERROR_PIPE_CONNECTED != err {
+		_ = windows.CloseHandle(h)
+		return nil, err
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if l.closed {
+		_ = windows.CloseHandle(h)
+		return nil, fmt.Errorf("%q is closed", l.path)
+	}
+	// the next client gets an instance of its own
+	next, err := l.create(false)
+	if nil != err {
+		_ = windows.CloseHandle(h)
+		return nil, err
+	}
+	l.next = next
+	return &pipeConn{File: os.NewFile(uintptr(h), l.path), h: h}, nil
+}
+
+// Close stops Accept, which is waiting for a client, by being that client
+func (l *pipeListener) Close() error {
+	l.mux.Lock()
+	l.closed = true
+	l.mux.Unlock()
+
+	conn, err := dialControl(l.path)
+	if nil == err {
+		_ = conn.Close()
+	}
+	return nil
+}
+
+// pipeConn is the server's end of one client's instance of the pipe
+type pipeConn struct {
+	*os.File
+	h windows.Handle
+}
+
+// Close makes sure that the client gets the response before the pipe goes away
+func (c *pipeConn) Close() error {
+	_ = windows.FlushFileBuffers(c.h)
+	_, _, _ = procDisconnectNamedPipe.Call(uintptr(c.h))
+	return c.File.Close()
+}
+
+// dialControl opens the named pipe, waiting a little while if each instance is busy
+func dialControl(path string) (io.ReadWriteCloser, error) {
+	name, err := windows.UTF16PtrFromString(path)
+	if nil != err {
+		return nil, err
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		h, err := windows.CreateFile(name, windows.GENERIC_READ|windows.GENERIC_WRITE, 0, nil, windows.OPEN_EXISTING, 0, 0)
+		if nil == err {
+			return os.NewFile(uintptr(h), path), nil
+		}
+		if windows.ERROR_PIPE_BUSY != err || time.Now().After(deadline) {
+			return nil, err
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,66 @@
+package runner
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// serviceUser is who the service runs as, as systemd's User= would have it,
+// or nil when it runs as the runner does (the runner isn't root,
+// or there's no one else to run as)
+func serviceUser(conf *service.Service) (*user.User, error) {
+	if 0 != os.Geteuid() || "" == conf.User || "root" == conf.User {
+		return nil, nil
+	}
+	return user.Lookup(conf.User)
+}
+
+// serviceGid is the service's Group, or else its user's primary group
+func serviceGid(conf *service.Service, u *user.User) (string, error) {
+	if "" == conf.Group {
+		return u.Gid, nil
+	}
+	g, err := user.LookupGroup(conf.Group)
+	if nil != err {
+		return "", err
+	}
+	return g.Gid, nil
+}
+
+// chownToService gives the files (and directories) to the service's user and group,
+// so that the service can still write to them once the runner has dropped privileges
+func chownToService(conf *service.Service, paths ...string) error {
+	u, err := serviceUser(conf)
+	if nil != err || nil == u {
+		return err
+	}
+	gid, err := serviceGid(conf, u)
+	if nil != err {
+		return err
+	}
+	nuid, _ := strconv.Atoi(u.Uid)
+	ngid, _ := strconv.Atoi(gid)
+	for _, path := range paths {
+		if err := os.Chown(path, nuid, ngid); nil != err && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// makeLogdir creates the log directory, if it doesn't exist yet, and says whether it's
+// the service's own to give to its user: one that was just created, or one that's named
+// for the service (i.e. /var/log/foo), rather than one that's shared (i.e. /var/log)
+func makeLogdir(conf *service.Service) (bool, error) {
+	if "" == conf.Logdir {
+		return false, nil
+	}
+	if _, err := os.Stat(conf.Logdir); !os.IsNotExist(err) {
+		return conf.Name == filepath.Base(filepath.Clean(conf.Logdir)), nil
+	}
+	return true, os.MkdirAll(conf.Logdir, 0755)
+}
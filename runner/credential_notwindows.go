@@ -0,0 +1,48 @@
+// +build !windows
+
+package runner
+
+import (
+	"os/exec"
+	"strconv"
+	"syscall"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// setCredential starts cmd as the service's user and group, along with the user's
+// supplementary groups, as systemd's User= and Group= would.
+// It only applies when the runner is root and there's someone else to run as.
+func setCredential(conf *service.Service, cmd *exec.Cmd) error {
+	u, err := serviceUser(conf)
+	if nil != err || nil == u {
+		return err
+	}
+	gid, err := serviceGid(conf, u)
+	if nil != err {
+		return err
+	}
+
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if nil != err {
+		return err
+	}
+	ngid, err := strconv.ParseUint(gid, 10, 32)
+	if nil != err {
+		return err
+	}
+	groups := []uint32{}
+	// (which may not be known, without cgo)
+	ids, _ := u.GroupIds()
+	for _, id := range ids {
+		if n, err := strconv.ParseUint(id, 10, 32); nil == err {
+			groups = append(groups, uint32(n))
+		}
+	}
+
+	if nil == cmd.SysProcAttr {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Credential = &syscall.Credential{Uid: uint32(uid), Gid: uint32(ngid), Groups: groups}
+	return nil
+}
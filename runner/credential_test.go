@@ -0,0 +1,84 @@
+// +build !windows
+
+package runner
+
+import (
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+func TestCredential(t *testing.T) {
+	if 0 != os.Geteuid() {
+		t.Skip("only root can run a service as another user")
+	}
+
+	nobody, err := user.Lookup("nobody")
+	if nil != err {
+		t.Skip("there's no nobody to run as")
+	}
+
+	conf := &service.Service{
+		Name:       "foo",
+		User:       "nobody",
+		EnvInherit: service.EnvInherit{"PATH"},
+	}
+	cmd, err := newCmd(conf, nil, "/usr/bin/id", []string{"-u"})
+	if nil != err {
+		t.Fatal(err)
+	}
+	out, err := cmd.Output()
+	if nil != err {
+		t.Fatal(err)
+	}
+	if nobody.Uid != strings.TrimSpace(string(out)) {
+		t.Errorf("expected the child to run as nobody, not uid %s", out)
+	}
+
+	env := strings.Join(cmd.Env, "\n") + "\n"
+	for _, kv := range []string{"USER=nobody\n", "LOGNAME=nobody\n"} {
+		if !strings.Contains(env, kv) {
+			t.Errorf("expected %q in the environment:\n%s", kv, env)
+		}
+	}
+}
+
+func TestMakeLogdir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "serviceman-logdir-")
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		logdir string
+		own    bool
+	}{
+		// one that's just created
+		{filepath.Join(dir, "new", "logs"), true},
+		// one that's named for the service
+		{filepath.Join(dir, "foo") + "/", true},
+		// a shared one, such as /var/log
+		{dir, false},
+	}
+	if err := os.Mkdir(filepath.Join(dir, "foo"), 0755); nil != err {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		own, err := makeLogdir(&service.Service{Name: "foo", Logdir: tt.logdir})
+		if nil != err {
+			t.Fatal(err)
+		}
+		if tt.own != own {
+			t.Errorf("expected %q to be the service's own to be %t", tt.logdir, tt.own)
+		}
+		if fi, err := os.Stat(tt.logdir); nil != err || !fi.IsDir() {
+			t.Errorf("expected %q to exist: %v", tt.logdir, err)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package runner
+
+import (
+	"os/exec"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// setCredential does nothing, as a service on Windows runs as the user who logs in
+func setCredential(conf *service.Service, cmd *exec.Cmd) error {
+	return nil
+}
@@ -0,0 +1,62 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// how long to wait for the services that a service depends on
+const dependencyTimeout = 30 * time.Second
+
+// waitForDependencies waits for the serviceman services in After, Requires,
+// and Wants to be running. Only a missing Requires is an error.
+// Dependencies that aren't run by serviceman (such as a .target, or a native service)
+// can't be waited for, and so are skipped.
+func (s *supervisor) waitForDependencies(lf io.Writer) error {
+	conf := s.conf
+	required := map[string]bool{}
+	for i := range conf.Requires {
+		required[service.UnitName(conf.Requires[i])] = true
+	}
+
+	deps := conf.Dependencies()
+	for i := range deps {
+		name := service.ServiceName(deps[i])
+		if "" == name {
+			continue
+		}
+		dep := &service.Service{Name: name, System: conf.System}
+		dep.NormalizeWithoutPath()
+		if _, err := os.Stat(dep.Logdir); nil != err {
+			continue
+		}
+
+		if _, _, err := getProcess(dep); nil == err {
+			continue
+		}
+		fmt.Fprintf(lf, "[%s] Waiting for %q to start\n", time.Now(), name)
+
+		deadline := time.After(dependencyTimeout)
+		for running := false; !running; {
+			select {
+			case <-s.quit:
+				return fmt.Errorf("stopped while waiting for %q", name)
+			case <-deadline:
+				if required[deps[i]] {
+					return fmt.Errorf("required service %q did not start within %s", name, dependencyTimeout)
+				}
+				fmt.Fprintf(lf, "[%s] Starting anyway, as %q did not start within %s\n", time.Now(), name, dependencyTimeout)
+				running = true
+			case <-time.After(500 * time.Millisecond):
+				_, _, err := getProcess(dep)
+				running = nil == err
+			}
+		}
+	}
+
+	return nil
+}
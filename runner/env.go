@@ -0,0 +1,68 @@
+package runner
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// environ is the service's environment: whatever it inherits from the runner,
+// then HOME, USER, and LOGNAME if it runs as its own user,
+// then its directories (i.e. STATE_DIRECTORY), then the env files in order,
+// then Envs, then the secrets.
+// The env files are read each time, so that changes apply on restart.
+func environ(conf *service.Service, secrets map[string]string) ([]string, error) {
+	envs := map[string]string{}
+	for _, kv := range os.Environ() {
+		parts := strings.SplitN(kv, "=", 2)
+		if 2 != len(parts) || !conf.EnvInherit.Allows(parts[0]) {
+			continue
+		}
+		envs[parts[0]] = parts[1]
+	}
+
+	u, err := serviceUser(conf)
+	if nil != err {
+		return nil, err
+	}
+	if nil != u {
+		envs["HOME"] = u.HomeDir
+		envs["USER"] = u.Username
+		envs["LOGNAME"] = u.Username
+	}
+
+	for k, v := range conf.DirectoryEnvs() {
+		envs[k] = v
+	}
+
+	for i := range conf.EnvFiles {
+		path, optional := service.EnvFile(conf.EnvFiles[i])
+		vars, err := service.ReadEnvFile(path)
+		if nil != err {
+			if optional && os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for k, v := range vars {
+			envs[k] = v
+		}
+	}
+
+	for k, v := range conf.Envs {
+		envs[k] = v
+	}
+
+	for k, v := range secrets {
+		envs[k] = v
+	}
+
+	env := []string{}
+	for k, v := range envs {
+		env = append(env, k+"="+v)
+	}
+	sort.Strings(env)
+	return env, nil
+}
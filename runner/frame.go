@@ -0,0 +1,146 @@
+package runner
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// RFC 3339, to the millisecond
+const frameTime = "2006-01-02T15:04:05.000Z07:00"
+
+// a line longer than this (without a newline) is written out as-is
+const maxLine = 64 * 1024
+
+// the runner's own messages start with the time, i.e. [2019-12-31 23:59:59.999 -0700 MST m=+0.001]
+var runnerTime = regexp.MustCompile(`^\[\d{4}-\d\d-\d\d \d\d:\d\d:\d\d[^\]]*\] `)
+
+// lineWriter splits what's written to it into lines, and hands each one off whole:
+// to be framed with a timestamp, the stream, and the run ID, or sent to the journal or syslog
+type lineWriter struct {
+	// shared with the other streams, so that their lines don't mix
+	mux       *sync.Mutex
+	stream    string
+	writeLine func(stream string, msg string)
+	buf       []byte
+}
+
+func (l *lineWriter) Write(p []byte) (int, error) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.buf = append(l.buf, p...)
+	for {
+		i := bytes.IndexByte(l.buf, '\n')
+		if i < 0 {
+			break
+		}
+		l.line(l.buf[:i])
+		l.buf = l.buf[i+1:]
+	}
+	if len(l.buf) > maxLine {
+		l.line(l.buf)
+		l.buf = nil
+	}
+	return len(p), nil
+}
+
+// flush writes out whatever's left, which is a line without a newline
+func (l *lineWriter) flush() {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if len(l.buf) > 0 {
+		l.line(l.buf)
+		l.buf = nil
+	}
+}
+
+func (l *lineWriter) line(line []byte) {
+	msg := strings.TrimSuffix(string(line), "\r")
+	if "runner" == l.stream {
+		// the frame (or the journal, or syslog) has the time already
+		msg = runnerTime.ReplaceAllString(msg, "")
+	}
+	l.writeLine(l.stream, msg)
+}
+
+// splitLines splits the runner's messages, stdout, and stderr into lines,
+// which are each handed off to writeLine
+func (l *serviceLog) splitLines(writeLine func(stream string, msg string)) {
+	mux := &sync.Mutex{}
+	wrap := func(stream string) io.Writer {
+		lw := &lineWriter{
+			mux:       mux,
+			stream:    stream,
+			writeLine: writeLine,
+		}
+		l.lines = append(l.lines, lw)
+		return lw
+	}
+	l.Writer = wrap("runner")
+	l.Stdout = wrap("out")
+	l.Stderr = wrap("err")
+}
+
+// frame puts each line of the service's output (and of the runner's messages)
+// in the service's log format, if it has one, tagged with the run ID
+func (l *serviceLog) frame(conf *service.Service, runID string) {
+	format := conf.Logging.Format
+	if "" == format {
+		return
+	}
+
+	writers := map[string]io.Writer{"runner": l.Writer, "out": l.Stdout, "err": l.Stderr}
+	name := conf.InstanceName()
+	l.splitLines(func(stream string, msg string) {
+		w := writers[stream]
+		if nil == w {
+			// i.e. stdout and stderr, when logging to none
+			return
+		}
+		now := time.Now().Format(frameTime)
+		if service.LogFormatJSON == format {
+			b := &bytes.Buffer{}
+			enc := json.NewEncoder(b)
+			enc.SetEscapeHTML(false)
+			_ = enc.Encode(struct {
+				Time    string `json:"time"`
+				Stream  string `json:"stream"`
+				Run     string `json:"run"`
+				Service string `json:"service"`
+				Msg     string `json:"msg"`
+			}{now, stream, runID, name, msg})
+			_, _ = w.Write(b.Bytes())
+			return
+		}
+		_, _ = fmt.Fprintf(w, "%s %s %s %s\n", now, stream, runID, msg)
+	})
+}
+
+// flush writes out any partial lines, i.e. when the service exits
+// without a newline at the end of its last line
+func (l *serviceLog) flush() {
+	for i := range l.lines {
+		l.lines[i].flush()
+	}
+}
+
+// newRunID identifies each start of the service, so that its output
+// can be told apart from that of the last start (or of the next)
+func newRunID() string {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); nil != err {
+		return fmt.Sprintf("%08x", uint32(time.Now().UnixNano()))
+	}
+	return hex.EncodeToString(b)
+}
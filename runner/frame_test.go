@@ -0,0 +1,130 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// framedLog frames the runner's messages, stdout, and stderr, each into its own buffer
+func framedLog(format string) (*serviceLog, map[string]*bytes.Buffer) {
+	bufs := map[string]*bytes.Buffer{"runner": {}, "out": {}, "err": {}}
+	l := &serviceLog{Writer: bufs["runner"], Stdout: bufs["out"], Stderr: bufs["err"]}
+	conf := &service.Service{Name: "foo", Logging: service.Logging{Format: format}}
+	l.frame(conf, "abcd1234")
+	return l, bufs
+}
+
+func framedLines(buf *bytes.Buffer) []string {
+	s := strings.TrimSuffix(buf.String(), "\n")
+	if "" == s {
+		return []string{}
+	}
+	return strings.Split(s, "\n")
+}
+
+func TestFrameText(t *testing.T) {
+	l, bufs := framedLog(service.LogFormatText)
+
+	fmt.Fprintf(l, "[%s] Starting foo\n", time.Now())
+	_, _ = l.Stdout.Write([]byte("hello\nwor"))
+	_, _ = l.Stdout.Write([]byte("ld\r\n"))
+	_, _ = l.Stderr.Write([]byte("no newline"))
+	if 0 != bufs["err"].Len() {
+		t.Fatalf("expected a partial line to wait for the rest of it, not %q", bufs["err"].String())
+	}
+	l.flush()
+
+	expected := map[string][]string{
+		"runner": {"Starting foo"},
+		"out":    {"hello", "world"},
+		"err":    {"no newline"},
+	}
+	for stream, msgs := range expected {
+		lines := framedLines(bufs[stream])
+		if len(msgs) != len(lines) {
+			t.Errorf("expected %d lines of %s, not %q", len(msgs), stream, lines)
+			continue
+		}
+		for i := range lines {
+			parts := strings.SplitN(lines[i], " ", 4)
+			if 4 != len(parts) {
+				t.Errorf("expected %q to be framed", lines[i])
+				continue
+			}
+			if _, err := time.Parse(frameTime, parts[0]); nil != err {
+				t.Errorf("expected %q to start with the time: %s", lines[i], err)
+			}
+			if stream != parts[1] || "abcd1234" != parts[2] || msgs[i] != parts[3] {
+				t.Errorf("expected %q to be %s abcd1234 %s", lines[i], stream, msgs[i])
+			}
+		}
+	}
+}
+
+func TestFrameJSON(t *testing.T) {
+	l, bufs := framedLog(service.LogFormatJSON)
+
+	msg := "say \"hi\" to <b>&</b> with a \\ and a \ttab"
+	_, _ = l.Stdout.Write([]byte(msg + "\n"))
+	_, _ = l.Stderr.Write([]byte("no newline"))
+	l.flush()
+
+	out := framedLines(bufs["out"])
+	if 1 != len(out) {
+		t.Fatalf("expected 1 line of out, not %q", out)
+	}
+	if !strings.Contains(out[0], "<b>&</b>") {
+		t.Errorf("expected HTML not to be escaped in %s", out[0])
+	}
+
+	for stream, expected := range map[string]string{"out": msg, "err": "no newline"} {
+		for _, line := range framedLines(bufs[stream]) {
+			entry := struct {
+				Time    string `json:"time"`
+				Stream  string `json:"stream"`
+				Run     string `json:"run"`
+				Service string `json:"service"`
+				Msg     string `json:"msg"`
+			}{}
+			if err := json.Unmarshal([]byte(line), &entry); nil != err {
+				t.Errorf("expected %s to be JSON: %s", line, err)
+				continue
+			}
+			if _, err := time.Parse(frameTime, entry.Time); nil != err {
+				t.Errorf("expected %s to have the time: %s", line, err)
+			}
+			if stream != entry.Stream || "abcd1234" != entry.Run || "foo" != entry.Service || expected != entry.Msg {
+				t.Errorf("expected %s to be %s from %s abcd1234 of foo", line, expected, stream)
+			}
+		}
+	}
+}
+
+func TestLineWriterMaxLine(t *testing.T) {
+	l, bufs := framedLog(service.LogFormatText)
+
+	long := strings.Repeat("x", maxLine)
+	_, _ = l.Stdout.Write([]byte(long))
+	if 0 != bufs["out"].Len() {
+		t.Fatalf("expected a line of maxLine to wait for the rest of it")
+	}
+	_, _ = l.Stdout.Write([]byte("yz"))
+	_, _ = l.Stdout.Write([]byte("next\n"))
+
+	lines := framedLines(bufs["out"])
+	if 2 != len(lines) {
+		t.Fatalf("expected the long line to be cut off, then the next, not %d lines", len(lines))
+	}
+	if !strings.HasSuffix(lines[0], " "+long+"yz") {
+		t.Errorf("expected the long line to be written out as-is")
+	}
+	if !strings.HasSuffix(lines[1], " abcd1234 next") {
+		t.Errorf("expected the next line to be framed on its own, not %q", lines[1])
+	}
+}
@@ -0,0 +1,120 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// StartAll runs several services under the one runner, as a container's init.
+// Each starts once the services that it depends on are running (or have given up),
+// and a SIGTERM or SIGINT stops them all gracefully, in the reverse order.
+// It returns once they've all stopped, with how each (instance) last exited.
+func StartAll(confs []*service.Service) ([]InstanceStatus, error) {
+	return startAll(confs, nil)
+}
+
+// Up runs several services as StartAll does, in the foreground, for development
+// (as Foreman does). Their output, and what the runner says of them, goes to out
+// rather than to their logs, a line at a time, each line prefixed with the service's name.
+func Up(confs []*service.Service, out io.Writer) ([]InstanceStatus, error) {
+	return startAll(confs, newConsole(out, confs))
+}
+
+func startAll(confs []*service.Service, c *console) ([]InstanceStatus, error) {
+	ordered, err := service.Order(confs)
+	if nil != err {
+		return nil, err
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM, os.Interrupt)
+	defer signal.Stop(sigs)
+
+	runs := []*serviceRun{}
+	for _, conf := range ordered {
+		r, err := startRun(conf, c)
+		if nil != err {
+			stopRuns(runs, "a failed start")
+			waitRuns(runs)
+			return nil, fmt.Errorf("could not start %q: %s", conf.Name, err)
+		}
+		runs = append(runs, r)
+
+		if sig := r.waitStarted(sigs); nil != sig {
+			// (the rest never start)
+			stopRuns(runs, sig.String())
+			return waitRuns(runs), nil
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-sigs:
+			stopRuns(runs, sig.String())
+		case <-done:
+		}
+	}()
+	statuses := waitRuns(runs)
+	close(done)
+	return statuses, nil
+}
+
+// ExitCode is what a container's init exits with: that of the first service
+// that exited (and wasn't restarted) with a non-zero exit code, or else 0.
+// A service that was stopped has stopped as it was asked to, however it exited.
+func ExitCode(statuses []InstanceStatus) int {
+	for i := range statuses {
+		if StateExited == statuses[i].State && 0 != statuses[i].ExitCode {
+			return statuses[i].ExitCode
+		}
+	}
+	return 0
+}
+
+// waitStarted waits for each instance to be running (or waiting to restart,
+// or to have exited), as the next service may depend on it, unless there's
+// a signal (to stop) first
+func (r *serviceRun) waitStarted(sigs <-chan os.Signal) os.Signal {
+	deadline := time.After(dependencyTimeout)
+	for r.starting() {
+		select {
+		case sig := <-sigs:
+			return sig
+		case <-deadline:
+			return nil
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	return nil
+}
+
+func (r *serviceRun) starting() bool {
+	for i := range r.sups {
+		if StateStarting == r.sups[i].status().State {
+			return true
+		}
+	}
+	return false
+}
+
+// stopRuns stops the services in the reverse of the order that they started
+func stopRuns(runs []*serviceRun, reason string) {
+	for i := len(runs) - 1; i >= 0; i-- {
+		runs[i].stop(reason)
+	}
+}
+
+func waitRuns(runs []*serviceRun) []InstanceStatus {
+	statuses := []InstanceStatus{}
+	for i := range runs {
+		statuses = append(statuses, runs[i].wait()...)
+	}
+	return statuses
+}
@@ -0,0 +1,137 @@
+// +build !windows
+
+package runner
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+func TestStartAll(t *testing.T) {
+	dir, err := ioutil.TempDir("", "serviceman-group-")
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	out := filepath.Join(dir, "out")
+	newConf := func(name string, code string, after ...string) *service.Service {
+		logdir := filepath.Join(dir, name)
+		if err := os.MkdirAll(logdir, 0755); nil != err {
+			t.Fatal(err)
+		}
+		return &service.Service{
+			Name:   name,
+			Exec:   "/bin/sh",
+			Argv:   []string{"-c", "printf " + name + " >> " + out + "; exit " + code},
+			After:  after,
+			Home:   dir,
+			Logdir: logdir,
+			Rundir: logdir,
+		}
+	}
+	// listed out of order
+	confs := []*service.Service{
+		newConf("web", "3", "db"),
+		newConf("db", "0"),
+	}
+
+	statuses, err := StartAll(confs)
+	if nil != err {
+		t.Fatal(err)
+	}
+	b, _ := ioutil.ReadFile(out)
+	if "dbweb" != string(b) {
+		t.Errorf("expected db to start before web, not %q", b)
+	}
+	if 2 != len(statuses) || StateExited != statuses[1].State || 3 != statuses[1].ExitCode {
+		t.Fatalf("expected web to have exited with 3, not %#v", statuses)
+	}
+	if 3 != ExitCode(statuses) {
+		t.Errorf("expected an exit code of 3, not %d", ExitCode(statuses))
+	}
+
+	// a oneshot that can't start, or whose pre-start hook fails, didn't succeed
+	missing := newConf("missing", "0")
+	missing.Exec = filepath.Join(dir, "nonexistent")
+	hook := newConf("hook", "0")
+	hook.Hooks.PreStart = []service.Hook{{Exec: "/bin/sh", Argv: []string{"-c", "exit 1"}}}
+	for _, conf := range []*service.Service{missing, hook} {
+		statuses, err := StartAll([]*service.Service{conf})
+		if nil != err {
+			t.Fatal(err)
+		}
+		if 1 != len(statuses) || StateExited != statuses[0].State || "" == statuses[0].LastExit {
+			t.Fatalf("expected %s to have exited, and to say why, not %#v", conf.Name, statuses)
+		}
+		if 0 == ExitCode(statuses) {
+			t.Errorf("expected %s not to exit with 0", conf.Name)
+		}
+	}
+	if b, _ := ioutil.ReadFile(out); "dbweb" != string(b) {
+		t.Errorf("expected neither to have run, not %q", b)
+	}
+}
+
+// each service has its own limits, rather than whichever the runner last set
+func TestStartAllLimits(t *testing.T) {
+	dir, err := ioutil.TempDir("", "serviceman-group-")
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var before syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &before); nil != err {
+		t.Fatal(err)
+	}
+
+	newConf := func(name string, nofile uint64) *service.Service {
+		logdir := filepath.Join(dir, name)
+		if err := os.MkdirAll(logdir, 0755); nil != err {
+			t.Fatal(err)
+		}
+		script := "ulimit -n > " + filepath.Join(dir, name+".nofile")
+		return &service.Service{
+			Name:   name,
+			Exec:   "/bin/sh",
+			Argv:   []string{"-c", script},
+			Limits: service.Limits{OpenFiles: nofile},
+			Hooks: service.Hooks{
+				PreStart: []service.Hook{{Exec: "/bin/sh", Argv: []string{"-c", script + ".hook"}}},
+			},
+			Home:   dir,
+			Logdir: logdir,
+			Rundir: logdir,
+		}
+	}
+	confs := []*service.Service{newConf("small", 100), newConf("large", 200), newConf("default", 0)}
+	if _, err := StartAll(confs); nil != err {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{"small": "100", "large": "200", "default": strconv.FormatUint(uint64(before.Cur), 10)}
+	for name, nofile := range expected {
+		for _, suffix := range []string{".nofile", ".nofile.hook"} {
+			b, _ := ioutil.ReadFile(filepath.Join(dir, name+suffix))
+			if nofile != strings.TrimSpace(string(b)) {
+				t.Errorf("expected %s%s to see %s open files, not %q", name, suffix, nofile, b)
+			}
+		}
+	}
+
+	var after syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &after); nil != err {
+		t.Fatal(err)
+	}
+	if before != after {
+		t.Errorf("expected the runner's own limit to be left alone, not %v (from %v)", after, before)
+	}
+}
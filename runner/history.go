@@ -0,0 +1,127 @@
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// the history is cut down to its newer half once it's bigger than this
+const historyMaxBytes = 256 * 1024
+
+// Run is a record of one run of the service (or of one of its instances)
+type Run struct {
+	Service  string    `json:"service"`
+	RunID    string    `json:"run_id"`
+	PID      int       `json:"pid,omitempty"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Duration float64   `json:"duration"` // in seconds
+	// i.e. exited cleanly, exit status 1, signal: killed, or why it didn't start
+	Exit     string `json:"exit"`
+	ExitCode int    `json:"exit_code"`
+	Signal   string `json:"signal,omitempty"` // i.e. SIGKILL
+	// whether it failed right after it started, and so the next start waited out the backoff
+	Backoff bool `json:"backoff"`
+}
+
+// the instances of a service share its history
+var historyMux sync.Mutex
+
+// newRun is the record of a run that didn't start (or hasn't yet ended)
+func (s *supervisor) newRun(runID string, start time.Time) *Run {
+	return &Run{
+		Service:  s.conf.InstanceName(),
+		RunID:    runID,
+		Start:    start,
+		ExitCode: -1,
+	}
+}
+
+// record adds the run, which has ended, to the service's history
+func (s *supervisor) record(run *Run, backoff bool, lf *serviceLog) {
+	if nil == run {
+		return
+	}
+	run.Backoff = backoff
+	if run.End.IsZero() {
+		run.End = time.Now()
+	}
+	run.Duration = run.End.Sub(run.Start).Seconds()
+	if err := appendHistory(s.conf.HistoryPath(), run); nil != err {
+		fmt.Fprintf(lf, "[%s] Could not add to %q's history: %s\n", time.Now(), s.conf.InstanceName(), err)
+	}
+}
+
+// appendHistory adds a line of JSON to the history, and keeps it from growing without end
+func appendHistory(path string, run *Run) error {
+	b, err := json.Marshal(run)
+	if nil != err {
+		return err
+	}
+
+	historyMux.Lock()
+	defer historyMux.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); nil != err {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if nil != err {
+		return err
+	}
+	_, err = f.Write(append(b, '\n'))
+	fi, _ := f.Stat()
+	_ = f.Close()
+	if nil != err || nil == fi || fi.Size() <= historyMaxBytes {
+		return err
+	}
+
+	// keep the newer half, starting at a whole line
+	all, err := ioutil.ReadFile(path)
+	if nil != err {
+		return err
+	}
+	half := all[len(all)/2:]
+	if i := bytes.IndexByte(half, '\n'); i >= 0 {
+		half = half[i+1:]
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, half, 0644); nil != err {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// History is the record of the service's runs, oldest first, as kept by the runner
+// (just the one instance's, if conf.Instance is set)
+func History(conf *service.Service) ([]Run, error) {
+	f, err := os.Open(conf.HistoryPath())
+	if nil != err {
+		return nil, err
+	}
+	defer f.Close()
+
+	runs := []Run{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		run := Run{}
+		// (a line that was cut short, i.e. by a full disk, is skipped)
+		if err := json.Unmarshal(scanner.Bytes(), &run); nil != err {
+			continue
+		}
+		if "" != conf.Instance && conf.InstanceName() != run.Service {
+			continue
+		}
+		runs = append(runs, run)
+	}
+	return runs, scanner.Err()
+}
@@ -0,0 +1,83 @@
+package runner
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+func TestHistory(t *testing.T) {
+	home, err := ioutil.TempDir("", "serviceman-history-")
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+
+	conf := &service.Service{Name: "foo", Home: home}
+	path := conf.HistoryPath()
+
+	start := time.Now()
+	runs := []*Run{
+		{Service: "foo@1", RunID: "a", Start: start, End: start.Add(time.Second), Exit: "exited cleanly"},
+		{Service: "foo@2", RunID: "b", Start: start, End: start.Add(time.Second), Exit: "exit status 1", ExitCode: 1, Backoff: true},
+	}
+	for i := range runs {
+		if err := appendHistory(path, runs[i]); nil != err {
+			t.Fatal(err)
+		}
+	}
+
+	all, err := History(conf)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if 2 != len(all) || "a" != all[0].RunID || "b" != all[1].RunID || !all[1].Backoff || 1 != all[1].ExitCode {
+		t.Fatalf("expected both runs, in order, not %#v", all)
+	}
+
+	conf.Instance = "2"
+	one, err := History(conf)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if 1 != len(one) || "b" != one[0].RunID {
+		t.Fatalf("expected just foo@2's run, not %#v", one)
+	}
+
+	if _, err := History(&service.Service{Name: "bar", Home: home}); !os.IsNotExist(err) {
+		t.Fatalf("expected no history for a service that hasn't run, not %v", err)
+	}
+}
+
+func TestHistoryIsBounded(t *testing.T) {
+	dir, err := ioutil.TempDir("", "serviceman-history-")
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "foo.history")
+
+	run := &Run{Service: "foo", Exit: strings.Repeat("x", 1000)}
+	for i := 0; i < 2*historyMaxBytes/1000; i++ {
+		if err := appendHistory(path, run); nil != err {
+			t.Fatal(err)
+		}
+	}
+
+	fi, err := os.Stat(path)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if fi.Size() > historyMaxBytes {
+		t.Fatalf("expected the history to stay under %d bytes, not %d", historyMaxBytes, fi.Size())
+	}
+	b, _ := ioutil.ReadFile(path)
+	if !strings.HasPrefix(string(b), `{"service":"foo"`) {
+		t.Fatalf("expected the history to still start at a whole line: %q", string(b[:40]))
+	}
+}
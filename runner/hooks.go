@@ -0,0 +1,96 @@
+package runner
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// runHooks runs each hook in order, with the same working directory
+// and environment as the service itself.
+// Their output goes wherever the service's does.
+// Each has the stop timeout to finish, after which its session is killed.
+// It returns the first failure of a hook that doesn't ignore failure.
+func (s *supervisor) runHooks(secrets map[string]string, stage string, hooks []service.Hook, lf *serviceLog) error {
+	conf := s.conf
+	for i := range hooks {
+		hook := hooks[i]
+		fmt.Fprintf(lf, "[%s] Running %s hook %q %s\n", time.Now(), stage, hook.Exec, mask(secrets, strings.Join(hook.Argv, " ")))
+
+		cmd, err := newCmd(conf, secrets, hook.Exec, hook.Argv)
+		var out *childOutput
+		if nil == err {
+			out, err = setOutput(cmd, lf.Stdout, lf.Stderr)
+		}
+		if nil == err {
+			// (as with systemd, the hooks have the same limits as the service)
+			if err := limitCmd(cmd, childLimits(conf, lf)); nil != err {
+				fmt.Fprintf(lf, "Warning: the %s hook %q won't have its limits: %s\n", stage, hook.Exec, err)
+			}
+			err = startCmd(cmd, false)
+			out.started()
+			if nil == err {
+				err = s.waitHook(cmd, stage, hook, lf)
+			}
+			out.stop()
+		}
+		if nil == err {
+			continue
+		}
+
+		if hook.IgnoreFailure {
+			fmt.Fprintf(lf, "[%s] Ignoring failed %s hook %q: %s\n", time.Now(), stage, hook.Exec, err)
+			continue
+		}
+		return fmt.Errorf("%s hook %q failed: %s", stage, hook.Exec, err)
+	}
+
+	return nil
+}
+
+// waitHook waits for the hook to finish, or kills it (and whatever it started)
+// once it has run for longer than the stop timeout
+func (s *supervisor) waitHook(cmd *exec.Cmd, stage string, hook service.Hook, lf *serviceLog) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- waitCmd(cmd, false)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(s.stopTimeout):
+	}
+
+	fmt.Fprintf(lf, "[%s] The %s hook %q didn't finish within %s, killing it\n", time.Now(), stage, hook.Exec, s.stopTimeout)
+	if err := killSession(cmd.Process.Pid); nil != err {
+		fmt.Fprintf(lf, "[%s] Could not kill the %s hook %q (pid %d): %s\n", time.Now(), stage, hook.Exec, cmd.Process.Pid, err)
+	}
+	<-done
+	return fmt.Errorf("didn't finish within %s", s.stopTimeout)
+}
+
+// newCmd prepares a command to run in the service's working directory and environment
+// (with the secrets as they were read for this run)
+func newCmd(conf *service.Service, secrets map[string]string, binpath string, args []string) (*exec.Cmd, error) {
+	env, err := environ(conf, secrets)
+	if nil != err {
+		return nil, err
+	}
+
+	cmd := exec.Command(binpath, args...)
+	backgroundCmd(cmd)
+
+	cmd.Stdin = nil
+	if "" != conf.Workdir {
+		cmd.Dir = conf.Workdir
+	}
+	// a non-nil (even if empty) Env keeps exec from passing along our own
+	cmd.Env = env
+	if err := setCredential(conf, cmd); nil != err {
+		return nil, fmt.Errorf("could not run as %q: %s", conf.User, err)
+	}
+	return cmd, nil
+}
@@ -0,0 +1,68 @@
+// +build !windows
+
+package runner
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// a hook that hangs is killed, along with whatever it started, after the stop timeout
+func TestHookTimeout(t *testing.T) {
+	dir, err := ioutil.TempDir("", "serviceman-hooks-")
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	bg := filepath.Join(dir, "bg")
+	conf := &service.Service{
+		Name:        "foo",
+		Exec:        "/bin/sh",
+		Argv:        []string{"-c", "echo ran > " + filepath.Join(dir, "ran")},
+		StopTimeout: 1,
+		Hooks: service.Hooks{
+			PreStart: []service.Hook{{Exec: "/bin/sh", Argv: []string{"-c", "sleep 30 & echo $! > " + bg + "; sleep 30"}}},
+		},
+		Home:   dir,
+		Logdir: dir,
+		Rundir: dir,
+	}
+
+	start := time.Now()
+	statuses, err := StartAll([]*service.Service{conf})
+	if nil != err {
+		t.Fatal(err)
+	}
+	if took := time.Since(start); took > 10*time.Second {
+		t.Errorf("expected the hook to be killed after 1s, not to run for %s", took)
+	}
+	if 1 != len(statuses) || 0 == statuses[0].ExitCode {
+		t.Errorf("expected foo not to start, not %#v", statuses)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ran")); nil == err {
+		t.Error("expected foo not to run after its pre-start hook failed")
+	}
+	b, _ := ioutil.ReadFile(filepath.Join(dir, "foo.log"))
+	if !strings.Contains(string(b), "didn't finish within 1s") {
+		t.Errorf("expected the hook's timeout to be logged, not %q", b)
+	}
+
+	b, _ = ioutil.ReadFile(bg)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if nil != err {
+		t.Fatalf("expected the hook to have started sleep in the background: %s", err)
+	}
+	if nil == syscall.Kill(pid, 0) {
+		t.Errorf("expected pid %d to have been killed along with the hook", pid)
+		_ = syscall.Kill(pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,134 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// journalSocket is where journald listens for its native protocol
+// (see https://systemd.io/JOURNAL_NATIVE_PROTOCOL/)
+var journalSocket = "/run/systemd/journal/socket"
+
+// syslog priorities, which the journal uses as well
+const (
+	priErr  = 3
+	priInfo = 6
+)
+
+// how long a line has to be sent to the journal or to syslog, before it's logged to a file instead
+const sinkTimeout = 1 * time.Second
+
+// logSink sends the service's output one line at a time, i.e. to the journal or to syslog
+type logSink interface {
+	send(stream string, msg string) error
+	Close() error
+}
+
+// sinkConn is a sink's connection, which is dialed again (just the once)
+// when a write to it fails, i.e. because journald or syslog has been restarted
+type sinkConn struct {
+	mux  sync.Mutex
+	conn net.Conn
+	dial func() (net.Conn, error)
+}
+
+func (c *sinkConn) write(b []byte) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(sinkTimeout))
+	_, err := c.conn.Write(b)
+	if nil == err {
+		return nil
+	}
+	conn, dialErr := c.dial()
+	if nil != dialErr {
+		return err
+	}
+	_ = c.conn.Close()
+	c.conn = conn
+	_ = c.conn.SetWriteDeadline(time.Now().Add(sinkTimeout))
+	_, err = c.conn.Write(b)
+	return err
+}
+
+func (c *sinkConn) Close() error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.conn.Close()
+}
+
+// journalSink sends each line to the journal as an entry of its own,
+// tagged with the syslog identifier, the service, and the run
+type journalSink struct {
+	conn     *sinkConn
+	ident    string
+	service  string
+	runID    string
+	facility int
+}
+
+func openJournal(conf *service.Service, runID string) (*journalSink, error) {
+	dial := func() (net.Conn, error) {
+		return net.Dial("unixgram", journalSocket)
+	}
+	conn, err := dial()
+	if nil != err {
+		return nil, err
+	}
+	facility := 3 // daemon
+	if !conf.System {
+		facility = 1 // user
+	}
+	return &journalSink{
+		conn:     &sinkConn{conn: conn, dial: dial},
+		ident:    conf.SyslogIdent(),
+		service:  conf.InstanceName(),
+		runID:    runID,
+		facility: facility,
+	}, nil
+}
+
+func (j *journalSink) send(stream string, msg string) error {
+	b := &bytes.Buffer{}
+	journalField(b, "MESSAGE", msg)
+	journalField(b, "PRIORITY", strconv.Itoa(priority(stream)))
+	journalField(b, "SYSLOG_FACILITY", strconv.Itoa(j.facility))
+	journalField(b, "SYSLOG_IDENTIFIER", j.ident)
+	journalField(b, "SERVICEMAN_SERVICE", j.service)
+	journalField(b, "SERVICEMAN_STREAM", stream)
+	journalField(b, "SERVICEMAN_RUN", j.runID)
+
+	return j.conn.write(b.Bytes())
+}
+
+func (j *journalSink) Close() error {
+	return j.conn.Close()
+}
+
+// journalField writes KEY=value, or, if the value has a newline,
+// the key, a newline, the length as a little-endian uint64, and then the value
+func journalField(b *bytes.Buffer, key string, value string) {
+	if !strings.Contains(value, "\n") {
+		b.WriteString(key + "=" + value + "\n")
+		return
+	}
+	b.WriteString(key + "\n")
+	_ = binary.Write(b, binary.LittleEndian, uint64(len(value)))
+	b.WriteString(value + "\n")
+}
+
+// priority is error for stderr, and info for everything else
+func priority(stream string) int {
+	if "err" == stream {
+		return priErr
+	}
+	return priInfo
+}
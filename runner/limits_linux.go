@@ -0,0 +1,111 @@
+package runner
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+const rlimitNproc = 6
+
+// RLIM_INFINITY is -1 on Linux
+const rlimInfinity = ^uint64(0)
+
+const cgroupRoot = "/sys/fs/cgroup"
+
+// the leaf that the runner moves itself into
+const runnerCgroup = "serviceman-runner"
+
+// limitCgroup creates a (v2) cgroup for the service (or the one instance), with memory.max,
+// cpu.max, and pids.max set. This only works when the runner's own cgroup
+// has been delegated to it (i.e. Delegate=yes, or inside of a container).
+//
+// Because a cgroup that has processes in it can't enable controllers
+// for its children, the runner first moves itself into a leaf:
+//
+//	<delegated>/serviceman-runner  <- the runner
+//	<delegated>/<name>             <- the service, with its limits
+//	<delegated>/<name>@<instance>  <- or each instance, with its own
+func limitCgroup(conf *service.Service) (*os.File, error) {
+	b, err := ioutil.ReadFile("/proc/self/cgroup")
+	if nil != err {
+		return nil, err
+	}
+	var current string
+	for _, line := range strings.Split(string(b), "\n") {
+		if strings.HasPrefix(line, "0::") {
+			current = strings.TrimSpace(line[len("0::"):])
+		}
+	}
+	if "" == current {
+		return nil, fmt.Errorf("cgroup v2 is not available")
+	}
+	if runnerCgroup == filepath.Base(current) {
+		current = filepath.Dir(current)
+	}
+
+	base := filepath.Join(cgroupRoot, current)
+	if _, err := os.Stat(filepath.Join(base, "cgroup.controllers")); nil != err {
+		// i.e. a "hybrid" hierarchy, where the controllers are all still v1
+		return nil, fmt.Errorf("cgroup v2 is not available")
+	}
+	if err := syscall.Access(filepath.Join(base, "cgroup.subtree_control"), 0x2); nil != err {
+		return nil, fmt.Errorf("cgroup %q is not delegated to the runner", current)
+	}
+
+	leaf := filepath.Join(base, runnerCgroup)
+	if err := os.MkdirAll(leaf, 0755); nil != err {
+		return nil, err
+	}
+	pid := []byte(strconv.Itoa(os.Getpid()))
+	if err := ioutil.WriteFile(filepath.Join(leaf, "cgroup.procs"), pid, 0644); nil != err {
+		return nil, fmt.Errorf("could not move the runner into %q: %s", leaf, err)
+	}
+
+	l := conf.Limits
+	files := map[string]string{}
+	if "" != l.MemoryMax {
+		mem, err := l.MemoryMaxBytes()
+		if nil != err {
+			return nil, err
+		}
+		files["memory.max"] = cgroupMax(mem)
+	}
+	if 0 != l.CPUQuota {
+		// CPUQuota is a percentage of one CPU, for each period of 100ms
+		files["cpu.max"] = fmt.Sprintf("%d 100000", l.CPUQuota*1000)
+	}
+	if 0 != l.Tasks {
+		files["pids.max"] = cgroupMax(l.Tasks)
+	}
+
+	svc := filepath.Join(base, conf.InstanceName())
+	if err := os.MkdirAll(svc, 0755); nil != err {
+		return nil, err
+	}
+	for name, value := range files {
+		controller := "+" + strings.Split(name, ".")[0]
+		subtree := filepath.Join(base, "cgroup.subtree_control")
+		if err := ioutil.WriteFile(subtree, []byte(controller), 0644); nil != err {
+			return nil, fmt.Errorf("could not enable the %s controller: %s", controller[1:], err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(svc, name), []byte(value), 0644); nil != err {
+			return nil, fmt.Errorf("could not set %s to %s: %s", name, value, err)
+		}
+	}
+
+	return os.Open(svc)
+}
+
+func cgroupMax(n uint64) string {
+	if service.Infinity == n {
+		return "max"
+	}
+	return strconv.FormatUint(n, 10)
+}
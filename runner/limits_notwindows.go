@@ -0,0 +1,159 @@
+// +build !windows
+
+package runner
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// limitsEnv has a process set its limits on itself (see init) before it execs
+// what's in limitsExecEnv, i.e. name:resource:cur:max,name:resource:cur:max
+const (
+	limitsEnv     = "SERVICEMAN_LIMITS"
+	limitsExecEnv = "SERVICEMAN_LIMITS_EXEC"
+)
+
+// setrlimit only sets the limits of the process that calls it, and a process only
+// passes its limits along to what it starts. So each of the service's processes starts
+// out as a copy of the runner (or of whatever has the runner in it, i.e. a test),
+// which sets the limits on itself here, and then execs the service. (That needs nothing
+// that may not be there, as sh may not be, in a container.)
+func init() {
+	spec, ok := os.LookupEnv(limitsEnv)
+	if !ok {
+		return
+	}
+	path := os.Getenv(limitsExecEnv)
+	env := []string{}
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, limitsEnv+"=") && !strings.HasPrefix(kv, limitsExecEnv+"=") {
+			env = append(env, kv)
+		}
+	}
+
+	for _, field := range strings.Split(spec, ",") {
+		parts := strings.Split(field, ":")
+		if 4 != len(parts) {
+			continue
+		}
+		resource, _ := strconv.Atoi(parts[1])
+		cur, _ := strconv.ParseUint(parts[2], 10, 64)
+		max, _ := strconv.ParseUint(parts[3], 10, 64)
+		lim := &syscall.Rlimit{}
+		setRlimit(lim, cur, max)
+		// (it's a warning, in the service's log, and the service starts anyway)
+		if err := syscall.Setrlimit(resource, lim); nil != err {
+			fmt.Fprintf(os.Stderr, "Warning: could not set %s limit to %d: %s\n", parts[0], cur, err)
+		}
+	}
+
+	err := syscall.Exec(path, os.Args, env)
+	fmt.Fprintf(os.Stderr, "Could not start %q: %s\n", path, err)
+	// as a shell would with a command that it can't run
+	os.Exit(127)
+}
+
+// rlimit is one of the service's resource limits, as it's set on each of its processes
+type rlimit struct {
+	name     string
+	resource int
+	cur      uint64
+	max      uint64
+}
+
+// childLimits are the limits (open files, processes, core size) for each of the
+// service's processes, which each sets on itself as it starts (see limitCmd),
+// rather than inheriting them from the runner, which may be running other services
+// with other limits. The hard limit can only be raised by root, so otherwise
+// it's only ever lowered.
+func childLimits(conf *service.Service, lf io.Writer) []rlimit {
+	l := conf.Limits
+	if l.Empty() {
+		return nil
+	}
+
+	core, err := l.CoreSizeBytes()
+	if nil != err {
+		fmt.Fprintf(lf, "Warning: ignoring core_size: %s\n", err)
+	}
+
+	limits := []rlimit{}
+	for _, rl := range []struct {
+		rlimit
+		value uint64
+	}{
+		{rlimit{name: "open_files", resource: syscall.RLIMIT_NOFILE}, l.OpenFiles},
+		{rlimit{name: "processes", resource: rlimitNproc}, l.Processes},
+		{rlimit{name: "core_size", resource: syscall.RLIMIT_CORE}, core},
+	} {
+		if 0 == rl.value {
+			continue
+		}
+		if service.Infinity == rl.value {
+			rl.value = rlimInfinity
+		}
+		// (the runner's own limits are what the service would otherwise have)
+		lim := &syscall.Rlimit{}
+		if err := syscall.Getrlimit(rl.resource, lim); nil != err {
+			fmt.Fprintf(lf, "Warning: could not get %s limit: %s\n", rl.name, err)
+			continue
+		}
+		_, max := getRlimit(lim)
+		if rl.value < max || 0 == os.Geteuid() {
+			max = rl.value
+		}
+		cur := rl.value
+		if cur > max {
+			cur = max
+		}
+		rl.cur, rl.max = cur, max
+		limits = append(limits, rl.rlimit)
+	}
+	return limits
+}
+
+// limitCmd has the command start out as a copy of the runner, which sets the
+// limits on itself and then execs the command (see init)
+func limitCmd(cmd *exec.Cmd, limits []rlimit) error {
+	if 0 == len(limits) {
+		return nil
+	}
+	// (which is still there, even if the runner's binary has since been replaced)
+	self := "/proc/self/exe"
+	if _, err := os.Stat(self); nil != err {
+		if self, err = os.Executable(); nil != err {
+			return err
+		}
+	}
+
+	fields := []string{}
+	for _, rl := range limits {
+		fields = append(fields, fmt.Sprintf("%s:%d:%d:%d", rl.name, rl.resource, rl.cur, rl.max))
+	}
+	cmd.Env = append(cmd.Env, limitsEnv+"="+strings.Join(fields, ","), limitsExecEnv+"="+cmd.Path)
+	cmd.Path = self
+	return nil
+}
+
+// applyLimits puts what setrlimit can't cover (memory, cpu, tasks) into a cgroup,
+// where that's possible, which is returned so that the service can be started in it
+func applyLimits(conf *service.Service, lf io.Writer) *os.File {
+	l := conf.Limits
+	if "" == l.MemoryMax && 0 == l.CPUQuota && 0 == l.Tasks {
+		return nil
+	}
+	cgroup, err := limitCgroup(conf)
+	if nil != err {
+		fmt.Fprintf(lf, "Warning: memory_max, cpu_quota, and tasks are not enforced: %s\n", err)
+		return nil
+	}
+	return cgroup
+}
@@ -0,0 +1,28 @@
+// +build !windows,!linux
+
+package runner
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// RLIMIT_NPROC is 7 on macOS and the BSDs
+const rlimitNproc = 7
+
+const rlimInfinity = syscall.RLIM_INFINITY
+
+func limitCgroup(conf *service.Service) (*os.File, error) {
+	return nil, fmt.Errorf("cgroups are specific to Linux")
+}
+
+func startInCgroup(cmd *exec.Cmd, cgroup *os.File) {
+}
+
+func addToCgroup(pid int, cgroup *os.File) error {
+	return nil
+}
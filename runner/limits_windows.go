@@ -0,0 +1,38 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// rlimit is a resource limit, of which there are none on Windows
+type rlimit struct{}
+
+// childLimits are not (yet) supported on Windows, where they would take Job Objects
+// (see applyLimits)
+func childLimits(conf *service.Service, lf io.Writer) []rlimit {
+	return nil
+}
+
+func limitCmd(cmd *exec.Cmd, limits []rlimit) error {
+	return nil
+}
+
+// applyLimits is not (yet) supported on Windows, where it would take Job Objects
+func applyLimits(conf *service.Service, lf io.Writer) *os.File {
+	if !conf.Limits.Empty() {
+		fmt.Fprintf(lf, "Warning: resource limits are not enforced on Windows\n")
+	}
+	return nil
+}
+
+func startInCgroup(cmd *exec.Cmd, cgroup *os.File) {
+}
+
+func addToCgroup(pid int, cgroup *os.File) error {
+	return nil
+}
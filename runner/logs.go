@@ -0,0 +1,130 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// how long to log to a file, once the journal or syslog has failed, before trying it again
+const sinkRetry = 30 * time.Second
+
+// serviceLog is where the service's stdout and stderr go (nil to throw them away),
+// and where the runner writes what it's doing (starting, stopping, restarting)
+type serviceLog struct {
+	io.Writer
+	Stdout  io.Writer
+	Stderr  io.Writer
+	closers []io.Closer
+	// when the output is framed, line by line
+	lines []*lineWriter
+}
+
+// openLogs opens the service's logs according to its logging destination,
+// which is a file in Logdir by default, and tags them with the run ID
+func openLogs(conf *service.Service, runID string) *serviceLog {
+	l := &serviceLog{}
+
+	switch conf.LogDestination() {
+	case service.LogSyslog, service.LogJournal:
+		var sink logSink
+		var err error
+		if service.LogJournal == conf.LogDestination() {
+			sink, err = openJournal(conf, runID)
+		} else {
+			sink, err = openSyslog(conf, runID)
+		}
+		if nil == err {
+			l.closers = append(l.closers, sink)
+			l.splitLines(l.sinkOrFile(conf, runID, sink))
+			return l
+		}
+		l.open(conf)
+		l.frame(conf, runID)
+		fmt.Fprintf(l, "[%s] Could not log to %s, logging here instead: %s\n", time.Now(), conf.LogDestination(), err)
+		return l
+	case service.LogNone:
+		// the runner still keeps track of starts and stops
+		l.Writer = l.openFile(conf, filepath.Join(conf.Logdir, conf.InstanceName()+".log"))
+		l.frame(conf, runID)
+		return l
+	}
+
+	l.open(conf)
+	l.frame(conf, runID)
+	return l
+}
+
+// sinkOrFile sends each line to the sink, or, if it can't be sent, to the service's log file
+// (framed, as the sink would have tagged it), rather than hold up the service's output.
+// The sink is given another try now and then.
+func (l *serviceLog) sinkOrFile(conf *service.Service, runID string, sink logSink) func(string, string) {
+	var file io.Writer
+	var failed time.Time
+	// (the lines are written one at a time, see splitLines)
+	return func(stream string, msg string) {
+		if failed.IsZero() || time.Since(failed) > sinkRetry {
+			err := sink.send(stream, msg)
+			if nil == err {
+				failed = time.Time{}
+				return
+			}
+			if nil == file {
+				file = l.openFile(conf, conf.StdoutPath())
+			}
+			if failed.IsZero() {
+				fmt.Fprintf(file, "%s runner %s Could not log to %s, logging here instead: %s\n", time.Now().Format(frameTime), runID, conf.LogDestination(), err)
+			}
+			failed = time.Now()
+		}
+		fmt.Fprintf(file, "%s %s %s %s\n", time.Now().Format(frameTime), stream, runID, msg)
+	}
+}
+
+// open logs stdout (and the runner's messages) to one file, and stderr to another
+// (or the same one)
+func (l *serviceLog) open(conf *service.Service) {
+	stdout, stderr := conf.StdoutPath(), conf.StderrPath()
+	out := l.openFile(conf, stdout)
+	l.Writer, l.Stdout, l.Stderr = out, out, out
+	if stderr != stdout {
+		l.Stderr = l.openFile(conf, stderr)
+	}
+}
+
+// openFile opens a log file, which rotates itself if there's a max size or age,
+// and which belongs to the service's user, if it has one
+func (l *serviceLog) openFile(conf *service.Service, logfile string) io.Writer {
+	logging := conf.Logging
+	defer func() {
+		_ = chownToService(conf, logfile)
+	}()
+	if logging.Rotates() {
+		r, err := openRotatingFile(logfile, logging)
+		if nil == err {
+			l.closers = append(l.closers, r)
+			return r
+		}
+		fmt.Fprintf(os.Stderr, "[%s] Could not open log file %q: %s\n", time.Now(), logfile, err)
+		return os.Stderr
+	}
+
+	lf := openLog(logfile)
+	if os.Stderr != lf {
+		l.closers = append(l.closers, lf)
+	}
+	return lf
+}
+
+// Close closes whichever files (or connections) are open
+func (l *serviceLog) Close() {
+	l.flush()
+	for i := range l.closers {
+		_ = l.closers[i].Close()
+	}
+	l.closers = nil
+}
@@ -0,0 +1,149 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// metric is one of the runner's metrics, for each instance of the service
+type metric struct {
+	name  string
+	kind  string
+	help  string
+	value func(m *instanceMetrics) (float64, bool)
+}
+
+// instanceMetrics is what the metrics are drawn from, as of the scrape
+type instanceMetrics struct {
+	status   InstanceStatus
+	started  time.Time
+	failures int
+	backoff  time.Duration
+	// from /proc, if the service is running (on Linux)
+	cpu      float64
+	rss      int64
+	hasStats bool
+}
+
+var metrics = []metric{
+	{"serviceman_up", "gauge", "Whether the service is running (1) or not (0).",
+		func(m *instanceMetrics) (float64, bool) {
+			if StateRunning == m.status.State {
+				return 1, true
+			}
+			return 0, true
+		}},
+	{"serviceman_restarts_total", "counter", "How many times the service has been restarted.",
+		func(m *instanceMetrics) (float64, bool) {
+			return float64(m.status.Restarts), true
+		}},
+	{"serviceman_last_exit_code", "gauge", "The exit code of the service's last exit (128 plus the signal, if it was killed).",
+		func(m *instanceMetrics) (float64, bool) {
+			return float64(m.status.ExitCode), "" != m.status.LastExit
+		}},
+	{"serviceman_consecutive_failures", "gauge", "How many times in a row the service has exited right after it started.",
+		func(m *instanceMetrics) (float64, bool) {
+			return float64(m.failures), true
+		}},
+	{"serviceman_backoff_seconds", "gauge", "How long the runner waited to restart the service, the last time it failed right away.",
+		func(m *instanceMetrics) (float64, bool) {
+			return m.backoff.Seconds(), true
+		}},
+	{"serviceman_uptime_seconds", "gauge", "How long the service's process has been running.",
+		func(m *instanceMetrics) (float64, bool) {
+			if 0 == m.status.PID {
+				return 0, true
+			}
+			return time.Since(m.started).Seconds(), true
+		}},
+	{"serviceman_cpu_seconds_total", "counter", "The CPU time used by the service's process (and anything else in its session).",
+		func(m *instanceMetrics) (float64, bool) {
+			return m.cpu, m.hasStats
+		}},
+	{"serviceman_resident_memory_bytes", "gauge", "The resident memory of the service's process (and anything else in its session).",
+		func(m *instanceMetrics) (float64, bool) {
+			return float64(m.rss), m.hasStats
+		}},
+}
+
+func (s *supervisor) metrics() *instanceMetrics {
+	st := s.status()
+	s.mux.Lock()
+	m := &instanceMetrics{
+		status:   st,
+		started:  s.started,
+		failures: s.failures,
+		backoff:  s.backoff,
+	}
+	s.mux.Unlock()
+
+	if 0 != st.PID {
+		var err error
+		m.cpu, m.rss, err = processStats(st.PID)
+		m.hasStats = nil == err
+	}
+	return m
+}
+
+// writeMetrics writes each metric for each instance, in Prometheus' text format
+func writeMetrics(w io.Writer, sups []*supervisor) {
+	ms := make([]*instanceMetrics, len(sups))
+	for i := range sups {
+		ms[i] = sups[i].metrics()
+	}
+
+	for _, metric := range metrics {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.kind)
+		for i, s := range sups {
+			value, ok := metric.value(ms[i])
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(w, "%s{service=\"%s\",instance=\"%s\"} %s\n",
+				metric.name, labelEscaper.Replace(s.conf.Name), labelEscaper.Replace(s.conf.Instance),
+				strconv.FormatFloat(value, 'f', -1, 64))
+		}
+	}
+}
+
+// labelEscaper escapes a label's value, as Prometheus does
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// serveMetrics serves the metrics at /metrics, on the service's metrics address,
+// until the listener is closed
+func serveMetrics(conf *service.Service, sups []*supervisor) (net.Listener, error) {
+	network, addr, err := conf.MetricsListener()
+	if nil != err {
+		return nil, err
+	}
+	if "unix" == network {
+		// (left behind by a runner that was killed)
+		_ = os.Remove(addr)
+		if err := os.MkdirAll(filepath.Dir(addr), 0755); nil != err {
+			return nil, err
+		}
+	}
+	l, err := net.Listen(network, addr)
+	if nil != err {
+		return nil, fmt.Errorf("could not serve metrics on %q: %s", conf.Metrics, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		writeMetrics(w, sups)
+	})
+	go func() {
+		_ = http.Serve(l, mux)
+	}()
+	return l, nil
+}
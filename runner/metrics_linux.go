@@ -0,0 +1,46 @@
+package runner
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// the kernel's USER_HZ, which /proc's times are in (it's 100 everywhere that matters)
+const clockTicks = 100
+
+// processStats is the CPU time (in seconds) and the resident memory (in bytes)
+// of the service's process and everything else in its session, from /proc
+func processStats(pid int) (float64, int64, error) {
+	var ticks, pages int64
+	found := false
+	pids := session(pid)
+	if 0 == len(pids) {
+		pids = []int{pid}
+	}
+	for _, p := range pids {
+		b, err := ioutil.ReadFile("/proc/" + strconv.Itoa(p) + "/stat")
+		if nil != err {
+			continue
+		}
+		// the command name, in parens, may have spaces (or parens) of its own
+		stat := string(b)
+		fields := strings.Fields(stat[strings.LastIndex(stat, ")")+1:])
+		// utime, stime, and rss are the 14th, 15th, and 24th, and the state (the 3rd) is the first after the name
+		if len(fields) < 22 {
+			continue
+		}
+		utime, _ := strconv.ParseInt(fields[11], 10, 64)
+		stime, _ := strconv.ParseInt(fields[12], 10, 64)
+		rss, _ := strconv.ParseInt(fields[21], 10, 64)
+		ticks += utime + stime
+		pages += rss
+		found = true
+	}
+	if !found {
+		return 0, 0, fmt.Errorf("pid %d isn't running", pid)
+	}
+	return float64(ticks) / clockTicks, pages * int64(os.Getpagesize()), nil
+}
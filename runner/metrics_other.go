@@ -0,0 +1,12 @@
+// +build !linux
+
+package runner
+
+import (
+	"fmt"
+)
+
+// processStats needs /proc, which only Linux has
+func processStats(pid int) (float64, int64, error) {
+	return 0, 0, fmt.Errorf("process stats are specific to Linux")
+}
@@ -0,0 +1,41 @@
+package runner
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	s := newSupervisor(&service.Service{Name: `foo"app`, Instance: "2"})
+	s.state = StateRestarting
+	s.runID = "abcd1234"
+	s.restarts = 3
+	s.lastExit = "exit status 2"
+	s.exitCode = 2
+	s.failures = 3
+	s.backoff = 4 * time.Second
+
+	out := &bytes.Buffer{}
+	writeMetrics(out, []*supervisor{s})
+	for _, line := range []string{
+		"# TYPE serviceman_up gauge",
+		`serviceman_up{service="foo\"app",instance="2"} 0`,
+		`serviceman_restarts_total{service="foo\"app",instance="2"} 3`,
+		`serviceman_last_exit_code{service="foo\"app",instance="2"} 2`,
+		`serviceman_consecutive_failures{service="foo\"app",instance="2"} 3`,
+		`serviceman_backoff_seconds{service="foo\"app",instance="2"} 4`,
+		`serviceman_uptime_seconds{service="foo\"app",instance="2"} 0`,
+	} {
+		if !strings.Contains(out.String(), line+"\n") {
+			t.Errorf("expected %q in the metrics:\n%s", line, out.String())
+		}
+	}
+	// there's no process to measure
+	if strings.Contains(out.String(), "serviceman_cpu_seconds_total{") {
+		t.Errorf("expected no CPU time without a process:\n%s", out.String())
+	}
+}
@@ -0,0 +1,120 @@
+package runner
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// listenNotify creates the socket that the service's sd_notify messages go to
+// (as NOTIFY_SOCKET), for a service that says when it's ready,
+// and returns what closes and removes it
+func (s *supervisor) listenNotify() func() {
+	conf := s.conf
+	if !conf.Notify {
+		return nil
+	}
+
+	path := filepath.Join(conf.Rundir, conf.InstanceName()+".notify")
+	_ = os.Remove(path)
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if nil != err {
+		fmt.Fprintf(s.lf, "[%s] Warning: no NOTIFY_SOCKET for %q: %s\n", time.Now(), conf.InstanceName(), err)
+		return nil
+	}
+	// only the service may send to it
+	_ = os.Chmod(path, 0600)
+	_ = chownToService(conf, path)
+
+	s.mux.Lock()
+	s.notifyPath = path
+	s.mux.Unlock()
+	go func() {
+		b := make([]byte, 4096)
+		for {
+			n, err := conn.Read(b)
+			if nil != err {
+				return
+			}
+			s.handleNotify(string(b[:n]))
+		}
+	}()
+	return func() {
+		_ = conn.Close()
+		_ = os.Remove(path)
+	}
+}
+
+// notifyEnv is NOTIFY_SOCKET, and WATCHDOG_USEC if there's a watchdog
+func (s *supervisor) notifyEnv() []string {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if "" == s.notifyPath {
+		return nil
+	}
+	env := []string{"NOTIFY_SOCKET=" + s.notifyPath}
+	if s.conf.Watchdog > 0 {
+		env = append(env, "WATCHDOG_USEC="+strconv.Itoa(s.conf.Watchdog*1000000))
+	}
+	return env
+}
+
+// handleNotify keeps track of READY=1, STATUS=..., and WATCHDOG=1
+// (see `man sd_notify`), and ignores the rest
+func (s *supervisor) handleNotify(msg string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, line := range strings.Split(msg, "\n") {
+		switch {
+		case "READY=1" == line:
+			if StateStarting == s.state {
+				s.state = StateRunning
+				s.since = time.Now()
+				fmt.Fprintf(s.lf, "[%s] %q is ready\n", time.Now(), s.conf.InstanceName())
+			}
+		case strings.HasPrefix(line, "STATUS="):
+			s.statusText = strings.TrimPrefix(line, "STATUS=")
+		case "WATCHDOG=1" == line:
+			s.pinged = time.Now()
+		case "WATCHDOG=trigger" == line:
+			// as though it had missed the watchdog
+			s.pinged = time.Time{}
+		case "STOPPING=1" == line:
+			fmt.Fprintf(s.lf, "[%s] %q is stopping\n", time.Now(), s.conf.InstanceName())
+		}
+	}
+}
+
+// watchdog stops the service, which is then restarted (if it restarts),
+// when it goes longer than the watchdog without a WATCHDOG=1
+func (s *supervisor) watchdog(cmd *exec.Cmd, exited chan struct{}) {
+	timeout := time.Duration(s.conf.Watchdog) * time.Second
+	tick := time.NewTicker(timeout / 4)
+	defer tick.Stop()
+	for {
+		select {
+		case <-exited:
+			return
+		case <-tick.C:
+		}
+
+		s.mux.Lock()
+		missed := time.Since(s.pinged) > timeout
+		if missed {
+			s.watchdogFired = true
+		}
+		lf := s.lf
+		s.mux.Unlock()
+		if missed {
+			fmt.Fprintf(lf, "[%s] %q (pid %d) missed its %s watchdog, stopping it\n", time.Now(), s.conf.InstanceName(), cmd.Process.Pid, timeout)
+			s.stopChild(cmd, exited)
+			return
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package runner
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+func TestHandleNotify(t *testing.T) {
+	s := newSupervisor(&service.Service{Name: "foo", Notify: true, Watchdog: 10})
+	s.lf = &serviceLog{Writer: ioutil.Discard}
+
+	s.handleNotify("READY=1\nSTATUS=Serving 3 clients\nMAINPID=1234")
+	st := s.status()
+	if StateRunning != st.State || "Serving 3 clients" != st.Status {
+		t.Fatalf("expected foo to be ready, and serving, not %#v", st)
+	}
+
+	s.handleNotify("WATCHDOG=1")
+	if time.Since(s.pinged) > time.Second {
+		t.Error("expected WATCHDOG=1 to ping the watchdog")
+	}
+	s.handleNotify("WATCHDOG=trigger")
+	if !s.pinged.IsZero() {
+		t.Error("expected WATCHDOG=trigger to expire the watchdog")
+	}
+}
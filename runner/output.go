@@ -0,0 +1,95 @@
+package runner
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// how long to keep copying what's left in a child's output, once it's done
+const outputDrain = 100 * time.Millisecond
+
+// childOutput is where a child process writes its stdout and stderr.
+// For a writer that isn't a file (i.e. one that frames each line, or rotates),
+// exec would make a pipe of its own, and Wait would then wait for everything that
+// holds the pipe open (such as a grandchild left in the background) to exit too.
+// So the pipe is ours instead, and we stop copying from it once we're done with the child.
+type childOutput struct {
+	writeEnds []*os.File
+	readEnds  []*os.File
+	copying   sync.WaitGroup
+}
+
+// setOutput gives the command files to write its stdout and stderr to
+func setOutput(cmd *exec.Cmd, stdout io.Writer, stderr io.Writer) (*childOutput, error) {
+	o := &childOutput{}
+	var err error
+	cmd.Stdout, err = o.file(stdout)
+	if nil == err {
+		cmd.Stderr = cmd.Stdout
+		if stderr != stdout {
+			cmd.Stderr, err = o.file(stderr)
+		}
+	}
+	if nil != err {
+		o.stop()
+		return nil, err
+	}
+	return o, nil
+}
+
+// file is w, if it's a file, or else the write end of a pipe that's copied to w
+func (o *childOutput) file(w io.Writer) (io.Writer, error) {
+	if nil == w {
+		return nil, nil
+	}
+	if f, ok := w.(*os.File); ok {
+		return f, nil
+	}
+
+	r, pw, err := os.Pipe()
+	if nil != err {
+		return nil, err
+	}
+	o.readEnds = append(o.readEnds, r)
+	o.writeEnds = append(o.writeEnds, pw)
+	o.copying.Add(1)
+	go func() {
+		defer o.copying.Done()
+		_, _ = io.Copy(w, r)
+	}()
+	return pw, nil
+}
+
+// started closes our copies of the write ends, which only the child needs
+func (o *childOutput) started() {
+	for i := range o.writeEnds {
+		_ = o.writeEnds[i].Close()
+	}
+	o.writeEnds = nil
+}
+
+// stop copies whatever is left in the pipes, and then stops,
+// even if something that the child left behind still has them open
+func (o *childOutput) stop() {
+	o.started()
+	for i := range o.readEnds {
+		_ = o.readEnds[i].SetReadDeadline(time.Now().Add(outputDrain))
+	}
+	done := make(chan struct{})
+	go func() {
+		o.copying.Wait()
+		close(done)
+	}()
+	// (not every pipe can have a deadline, i.e. on Windows)
+	select {
+	case <-done:
+	case <-time.After(outputDrain + time.Second):
+	}
+	for i := range o.readEnds {
+		_ = o.readEnds[i].Close()
+	}
+	o.readEnds = nil
+}
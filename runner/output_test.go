@@ -0,0 +1,55 @@
+// +build !windows
+
+package runner
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// a oneshot that leaves something running in the background, still holding its stdout,
+// has exited as soon as it exits (rather than once that has too)
+func TestOutputOutlivesOneshot(t *testing.T) {
+	for _, logging := range []service.Logging{
+		{Format: service.LogFormatText},
+		{Format: service.LogFormatJSON},
+		{MaxSize: "1M"},
+	} {
+		dir, err := ioutil.TempDir("", "serviceman-output-")
+		if nil != err {
+			t.Fatal(err)
+		}
+		conf := &service.Service{
+			Name:    "oneshot",
+			Exec:    "/bin/sh",
+			Argv:    []string{"-c", "sleep 30 & echo hi; exit 0"},
+			Logging: logging,
+			Home:    dir,
+			Logdir:  dir,
+			Rundir:  dir,
+		}
+
+		start := time.Now()
+		statuses, err := StartAll([]*service.Service{conf})
+		if nil != err {
+			t.Fatal(err)
+		}
+		if took := time.Since(start); took > 5*time.Second {
+			t.Errorf("%#v: expected the oneshot to be done as soon as it exited, not after %s", logging, took)
+		}
+		if 1 != len(statuses) || StateExited != statuses[0].State || 0 != statuses[0].ExitCode {
+			t.Errorf("%#v: expected the oneshot to have exited cleanly, not %#v", logging, statuses)
+		}
+		b, _ := ioutil.ReadFile(filepath.Join(dir, "oneshot.log"))
+		if !strings.Contains(string(b), "hi") {
+			t.Errorf("%#v: expected its output to be logged, not %q", logging, b)
+		}
+		_ = os.RemoveAll(dir)
+	}
+}
@@ -0,0 +1,172 @@
+package runner
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"git.rootprojects.org/root/serviceman/service"
+
+	ps "github.com/mitchellh/go-ps"
+)
+
+// pidFile is the runner's pid, along with its executable and when it started,
+// so that another process that has since been given the same pid (i.e. after
+// a reboot) isn't mistaken for the runner. It looks like this:
+//
+// 	1234
+// 	exe /usr/local/bin/serviceman
+// 	start 8765309
+//
+// An older runner's pid file has just the pid.
+type pidFile struct {
+	PID   int
+	Exe   string
+	Start string
+}
+
+func pidFilePath(conf *service.Service) string {
+	return filepath.Join(conf.Logdir, conf.Name+".pid")
+}
+
+func parsePidFile(b []byte) (*pidFile, error) {
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	pid, err := strconv.Atoi(strings.TrimSpace(lines[0]))
+	if nil != err {
+		return nil, ErrInvalidPidFile
+	}
+	pf := &pidFile{PID: pid}
+	for _, line := range lines[1:] {
+		kv := strings.SplitN(strings.TrimSpace(line), " ", 2)
+		if 2 != len(kv) {
+			continue
+		}
+		switch kv[0] {
+		case "exe":
+			pf.Exe = kv[1]
+		case "start":
+			pf.Start = kv[1]
+		}
+	}
+	return pf, nil
+}
+
+func (pf *pidFile) String() string {
+	return fmt.Sprintf("%d\nexe %s\nstart %s\n", pf.PID, pf.Exe, pf.Start)
+}
+
+// verify makes sure that the process with the pid is the one that wrote the pid file,
+// and returns its executable
+func (pf *pidFile) verify() (string, error) {
+	exe, start, err := processIdentity(pf.PID)
+	if nil != err {
+		return "", ErrNoProcess
+	}
+
+	if "" == pf.Start {
+		// all there is to go on is that it's the same program
+		name := filepath.Base(exe)
+		if "" == exe {
+			px, err := ps.FindProcess(pf.PID)
+			if nil != err || nil == px {
+				return "", ErrNoProcess
+			}
+			name = px.Executable()
+		}
+		self, _ := os.Executable()
+		// (the name may be cut short, i.e. to 15 characters by Linux)
+		if "" == name || !strings.HasPrefix(filepath.Base(self), name) {
+			return name, fmt.Errorf("pid %d is %q now, which isn't a runner", pf.PID, name)
+		}
+		return name, nil
+	}
+
+	// (the executable may not be readable, i.e. a system runner's, as a user)
+	if start != pf.Start || ("" != exe && "" != pf.Exe && exe != pf.Exe) {
+		return exe, fmt.Errorf(
+			"pid %d is %q (started at %s) now, not the runner %q (started at %s) that wrote the pid file",
+			pf.PID, exe, start, pf.Exe, pf.Start,
+		)
+	}
+	return exe, nil
+}
+
+// lockPidFile takes an exclusive lock on the pid file, which the runner holds
+// for as long as it runs, and writes the runner's pid (and identity) to it
+func lockPidFile(conf *service.Service) (*os.File, error) {
+	path := pidFilePath(conf)
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if nil != err {
+		return nil, err
+	}
+	if err := lockFile(f); nil != err {
+		_ = f.Close()
+		return nil, fmt.Errorf("%q is already running (another runner holds %q)", conf.Name, path)
+	}
+
+	// a runner from before the pid file was locked
+	if b, err := ioutil.ReadAll(f); nil == err {
+		if old, err := parsePidFile(b); nil == err && "" == old.Start {
+			if exename, err := old.verify(); nil == err {
+				_ = f.Close()
+				return nil, fmt.Errorf("%q may already be running as %q (pid %d)", conf.Name, exename, old.PID)
+			}
+		}
+	}
+
+	pf := &pidFile{PID: os.Getpid()}
+	pf.Exe, pf.Start, err = processIdentity(pf.PID)
+	if nil == err {
+		err = f.Truncate(0)
+	}
+	if nil == err {
+		_, err = f.WriteAt([]byte(pf.String()), 0)
+	}
+	if nil != err {
+		_ = f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// releasePidFile removes the pid file, and then lets go of the lock
+// (Windows won't remove a file that's open, so there it's the other way around)
+func releasePidFile(f *os.File) {
+	if err := os.Remove(f.Name()); nil == err {
+		_ = f.Close()
+		return
+	}
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+}
+
+// getProcess finds the runner by its pid file, and makes sure that it's still the runner
+// (rather than a process that has since been given the same pid)
+func getProcess(conf *service.Service) (int, string, error) {
+	path := pidFilePath(conf)
+	b, err := ioutil.ReadFile(path)
+	if nil != err {
+		if os.IsNotExist(err) {
+			return 0, "", ErrNoPidFile
+		}
+		return 0, "", err
+	}
+
+	pf, err := parsePidFile(b)
+	if nil != err {
+		return 0, "", err
+	}
+	// a runner that's still running holds the lock
+	if "" != pf.Start && !isLocked(path) {
+		return 0, "", fmt.Errorf("%q is stale: the runner (pid %d) is no longer running", path, pf.PID)
+	}
+
+	exename, err := pf.verify()
+	if nil != err {
+		return 0, "", err
+	}
+	return pf.PID, exename, nil
+}
@@ -0,0 +1,31 @@
+package runner
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// processIdentity is the process's executable and its start time
+// (in clock ticks since boot), from /proc
+func processIdentity(pid int) (string, string, error) {
+	b, err := ioutil.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if nil != err {
+		return "", "", err
+	}
+	// the command name, in parens, may have spaces (or parens) of its own
+	stat := string(b)
+	fields := strings.Fields(stat[strings.LastIndex(stat, ")")+1:])
+	// starttime is the 22nd field, and the state (the 3rd) is the first after the name
+	if len(fields) < 20 {
+		return "", "", fmt.Errorf("unexpected /proc/%d/stat: %q", pid, stat)
+	}
+	start := fields[19]
+
+	// (which isn't readable for another user's process)
+	exe, _ := os.Readlink("/proc/" + strconv.Itoa(pid) + "/exe")
+	exe = strings.TrimSuffix(exe, " (deleted)")
+	return exe, start, nil
+}
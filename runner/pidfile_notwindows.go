@@ -0,0 +1,23 @@
+// +build !windows
+
+package runner
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile takes an exclusive lock on the file, without waiting for it
+func lockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+}
+
+// isLocked is true while another process holds a lock on the file
+func isLocked(path string) bool {
+	f, err := os.Open(path)
+	if nil != err {
+		return false
+	}
+	defer f.Close()
+	return nil != syscall.Flock(int(f.Fd()), syscall.LOCK_SH|syscall.LOCK_NB)
+}
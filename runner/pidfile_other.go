@@ -0,0 +1,21 @@
+// +build !linux,!windows
+
+package runner
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// processIdentity is the process's executable and its start time, from ps
+func processIdentity(pid int) (string, string, error) {
+	p := strconv.Itoa(pid)
+	start, err := exec.Command("ps", "-o", "lstart=", "-p", p).Output()
+	if nil != err {
+		return "", "", fmt.Errorf("no process with pid %d", pid)
+	}
+	exe, _ := exec.Command("ps", "-o", "comm=", "-p", p).Output()
+	return strings.TrimSpace(string(exe)), strings.Join(strings.Fields(string(start)), "-"), nil
+}
@@ -0,0 +1,58 @@
+// +build !windows
+
+package runner
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+func TestPidFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "serviceman-pid-")
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	conf := &service.Service{Name: "foo", Logdir: dir}
+
+	f, err := lockPidFile(conf)
+	if nil != err {
+		t.Fatal(err)
+	}
+	// (flock locks are per open file, so this is as good as another runner)
+	if _, err := lockPidFile(conf); nil == err {
+		t.Fatal("expected the pid file to be locked")
+	}
+	if pid, _, err := getProcess(conf); nil != err || os.Getpid() != pid {
+		t.Fatalf("expected to find the runner as pid %d, not %d (%v)", os.Getpid(), pid, err)
+	}
+	releasePidFile(f)
+	if _, _, err := getProcess(conf); ErrNoPidFile != err {
+		t.Fatalf("expected the pid file to be gone, not %v", err)
+	}
+
+	// a pid file that no runner holds is stale, even if the pid is in use
+	stale := &pidFile{PID: os.Getpid(), Exe: "/usr/local/bin/serviceman", Start: "1"}
+	if err := ioutil.WriteFile(pidFilePath(conf), []byte(stale.String()), 0644); nil != err {
+		t.Fatal(err)
+	}
+	if _, _, err := getProcess(conf); nil == err || !strings.Contains(err.Error(), "stale") {
+		t.Fatalf("expected a stale pid file, not %v", err)
+	}
+
+	// and a pid that's been given to another process doesn't pass for the runner
+	if _, err := stale.verify(); nil == err {
+		t.Fatal("expected a different start time to fail verification")
+	}
+
+	// a stale pid file doesn't keep the runner from starting
+	f, err = lockPidFile(conf)
+	if nil != err {
+		t.Fatal(err)
+	}
+	releasePidFile(f)
+}
@@ -0,0 +1,72 @@
+package runner
+
+import (
+	"os"
+	"strconv"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	ps "github.com/mitchellh/go-ps"
+)
+
+var (
+	procLockFileEx   = modkernel32.NewProc("LockFileEx")
+	procUnlockFileEx = modkernel32.NewProc("UnlockFileEx")
+)
+
+const (
+	lockfileFailImmediately = 0x1
+	lockfileExclusiveLock   = 0x2
+)
+
+// lockRange locks (the first byte of) the file, without waiting for it
+func lockRange(h windows.Handle, flags uint32) error {
+	ol := &windows.Overlapped{}
+	r, _, err := procLockFileEx.Call(uintptr(h), uintptr(flags|lockfileFailImmediately), 0, 1, 0, uintptr(unsafe.Pointer(ol)))
+	if 0 == r {
+		return err
+	}
+	return nil
+}
+
+// lockFile takes an exclusive lock on the file, without waiting for it
+func lockFile(f *os.File) error {
+	return lockRange(windows.Handle(f.Fd()), lockfileExclusiveLock)
+}
+
+// isLocked is true while another process holds a lock on the file
+func isLocked(path string) bool {
+	f, err := os.Open(path)
+	if nil != err {
+		return false
+	}
+	defer f.Close()
+	h := windows.Handle(f.Fd())
+	if err := lockRange(h, 0); nil != err {
+		return true
+	}
+	ol := &windows.Overlapped{}
+	_, _, _ = procUnlockFileEx.Call(uintptr(h), 0, 1, 0, uintptr(unsafe.Pointer(ol)))
+	return false
+}
+
+// processIdentity is the process's executable and its creation time
+func processIdentity(pid int) (string, string, error) {
+	h, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if nil != err {
+		return "", "", err
+	}
+	defer windows.CloseHandle(h)
+
+	var created, exited, kernel, user windows.Filetime
+	if err := windows.GetProcessTimes(h, &created, &exited, &kernel, &user); nil != err {
+		return "", "", err
+	}
+
+	exe := ""
+	if px, err := ps.FindProcess(pid); nil == err && nil != px {
+		exe = px.Executable()
+	}
+	return exe, strconv.FormatInt(created.Nanoseconds(), 10), nil
+}
@@ -0,0 +1,44 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ReadyFDEnv is set, for a runner started by serviceman run --daemon,
+// to the pipe on which the runner says that it has started (or why it couldn't)
+const ReadyFDEnv = "SERVICEMAN_READY_FD"
+
+// readyPipe is the pipe that ReadyFDEnv names, if any. It has to be taken before
+// anything is started, so that neither the service nor its hooks see the variable,
+// or inherit the pipe (and so keep it open after the runner says its piece).
+func readyPipe() *os.File {
+	fd := os.Getenv(ReadyFDEnv)
+	if "" == fd {
+		return nil
+	}
+	_ = os.Unsetenv(ReadyFDEnv)
+
+	n, err := strconv.Atoi(fd)
+	if nil != err {
+		return nil
+	}
+	closeOnExec(n)
+	return os.NewFile(uintptr(n), "ready")
+}
+
+// notifyReady tells whoever started the runner that it's ready ("ready <pid>"),
+// or that it couldn't start ("error <why>"), just the once
+func notifyReady(f *os.File, err error) {
+	if nil == f {
+		return
+	}
+	defer f.Close()
+	if nil != err {
+		fmt.Fprintf(f, "error %s\n", strings.Replace(err.Error(), "\n", " ", -1))
+		return
+	}
+	fmt.Fprintf(f, "ready %d\n", os.Getpid())
+}
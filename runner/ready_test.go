@@ -0,0 +1,66 @@
+// +build !windows
+
+package runner
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestReadyPipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	// as a daemon's runner would have it, without close-on-exec
+	fd, err := syscall.Dup(int(w.Fd()))
+	if nil != err {
+		t.Fatal(err)
+	}
+	_ = w.Close()
+	_ = os.Setenv(ReadyFDEnv, strconv.Itoa(fd))
+	defer os.Unsetenv(ReadyFDEnv)
+
+	ready := readyPipe()
+	if nil == ready {
+		t.Fatal("expected the pipe")
+	}
+	if _, ok := os.LookupEnv(ReadyFDEnv); ok {
+		t.Errorf("expected %s not to be passed along", ReadyFDEnv)
+	}
+	flags, err := unix.FcntlInt(uintptr(fd), unix.F_GETFD, 0)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if 0 == flags&unix.FD_CLOEXEC {
+		t.Errorf("expected the pipe not to be inherited")
+	}
+
+	// nothing that's started keeps the pipe open
+	if err := exec.Command("/bin/sh", "-c", "sleep 5 >/dev/null 2>&1 &").Run(); nil != err {
+		t.Fatal(err)
+	}
+	notifyReady(ready, nil)
+	_ = r.SetReadDeadline(time.Now().Add(2 * time.Second))
+	br := bufio.NewReader(r)
+	line, err := br.ReadString('\n')
+	if nil != err {
+		t.Fatal(err)
+	}
+	if "ready "+strconv.Itoa(os.Getpid()) != strings.TrimSpace(line) {
+		t.Errorf("expected to be told that the runner is ready, not %q", line)
+	}
+	if _, err := br.ReadString('\n'); io.EOF != err {
+		t.Errorf("expected the pipe to be closed, not %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// children are the processes that the runner started, and waits for, itself.
+// Anything else that's the runner's child is an orphan that it has taken in
+// (as a subreaper, on Linux), which the reaper waits for instead.
+var children = struct {
+	// starting holds off the reaper while a child starts and is counted
+	starting sync.RWMutex
+	mux      sync.Mutex
+	pids     map[int]bool
+	// how many of them are services (rather than hooks)
+	services int
+	// the services' sessions, until whatever they left behind has stopped
+	sessions map[int]bool
+}{pids: map[int]bool{}, sessions: map[int]bool{}}
+
+// startCmd starts one of the runner's own children
+func startCmd(cmd *exec.Cmd, isService bool) error {
+	children.starting.RLock()
+	defer children.starting.RUnlock()
+	if err := cmd.Start(); nil != err {
+		return err
+	}
+	children.mux.Lock()
+	children.pids[cmd.Process.Pid] = true
+	if isService {
+		children.services++
+		children.sessions[cmd.Process.Pid] = true
+	}
+	children.mux.Unlock()
+	return nil
+}
+
+// waitCmd waits for one of the runner's own children to exit
+func waitCmd(cmd *exec.Cmd, isService bool) error {
+	err := cmd.Wait()
+	children.mux.Lock()
+	delete(children.pids, cmd.Process.Pid)
+	if isService {
+		children.services--
+	}
+	children.mux.Unlock()
+	return err
+}
+
+func isChild(pid int) bool {
+	children.mux.Lock()
+	defer children.mux.Unlock()
+	return children.pids[pid]
+}
+
+// endSession is once nothing is left of the service's session
+func endSession(sid int) {
+	children.mux.Lock()
+	delete(children.sessions, sid)
+	children.mux.Unlock()
+}
+
+func servicesRunning() int {
+	children.mux.Lock()
+	defer children.mux.Unlock()
+	return children.services
+}
+
+// stopOrphans stops the orphans that the runner has taken in (other than what's
+// left of a service's session, which stopLeftovers takes care of), once no service
+// is left running that they could belong to, so that none of them carry over
+// to the service's next start. Stopping one orphan may orphan others in turn.
+func (s *supervisor) stopOrphans(lf *serviceLog) {
+	if 0 != servicesRunning() {
+		return
+	}
+
+	deadline := time.Now().Add(s.stopTimeout)
+	signaled := map[int]bool{}
+	for kills := 0; kills < 10; {
+		pids := strayOrphans()
+		if 0 == len(pids) {
+			return
+		}
+		if time.Now().After(deadline) {
+			fmt.Fprintf(lf, "[%s] Killing %d orphaned process(es) that didn't stop within %s\n", time.Now(), len(pids), s.stopTimeout)
+			for _, pid := range pids {
+				_ = kill(pid)
+			}
+			kills++
+		} else {
+			for _, pid := range pids {
+				if signaled[pid] {
+					continue
+				}
+				if 0 == len(signaled) {
+					fmt.Fprintf(lf, "[%s] Stopping the orphaned process(es) that %q left behind\n", time.Now(), s.conf.InstanceName())
+				}
+				signaled[pid] = true
+				if p, err := os.FindProcess(pid); nil == err {
+					_ = p.Signal(s.stopSignal)
+				}
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// strayOrphans are the orphans that aren't in any service's session
+func strayOrphans() []int {
+	pids := []int{}
+	for _, pid := range orphans() {
+		children.mux.Lock()
+		inSession := children.sessions[sessionOf(pid)]
+		children.mux.Unlock()
+		if !inSession {
+			pids = append(pids, pid)
+		}
+	}
+	return pids
+}
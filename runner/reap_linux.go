@@ -0,0 +1,62 @@
+package runner
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	ps "github.com/mitchellh/go-ps"
+	"golang.org/x/sys/unix"
+)
+
+var subreaper sync.Once
+
+// becomeSubreaper has the orphans that a service leaves behind (such as
+// the workers of a process that has exited, or a daemon that has forked)
+// given to the runner rather than to init, so that they can be stopped along
+// with the service, and starts the reaper, which waits for them once they exit
+func becomeSubreaper() error {
+	var err error
+	subreaper.Do(func() {
+		err = unix.Prctl(unix.PR_SET_CHILD_SUBREAPER, 1, 0, 0, 0)
+		if nil == err {
+			go reap()
+		}
+	})
+	return err
+}
+
+// reap waits for each orphan that exits, so that none linger as zombies
+func reap() {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGCHLD)
+	for range sigs {
+		reapOrphans()
+	}
+}
+
+func reapOrphans() {
+	children.starting.Lock()
+	defer children.starting.Unlock()
+	for _, pid := range orphans() {
+		var ws unix.WaitStatus
+		_, _ = unix.Wait4(pid, &ws, unix.WNOHANG, nil)
+	}
+}
+
+// orphans are the runner's children that it didn't start itself
+func orphans() []int {
+	procs, err := ps.Processes()
+	if nil != err {
+		return nil
+	}
+	self := os.Getpid()
+	pids := []int{}
+	for _, p := range procs {
+		if self == p.PPid() && !isChild(p.Pid()) {
+			pids = append(pids, p.Pid())
+		}
+	}
+	return pids
+}
@@ -0,0 +1,14 @@
+// +build !linux
+
+package runner
+
+// becomeSubreaper does nothing, since only Linux has subreapers.
+// Orphans go to init, as they always have.
+func becomeSubreaper() error {
+	return nil
+}
+
+// orphans is always empty without a subreaper
+func orphans() []int {
+	return nil
+}
@@ -0,0 +1,73 @@
+// +build linux
+
+package runner
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+func TestStopLeavesNothingBehind(t *testing.T) {
+	dir, err := ioutil.TempDir("", "serviceman-reap-")
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// one in the service's session, and one that leaves it
+	conf := &service.Service{
+		Name: "foo",
+		Exec: "/bin/sh",
+		Argv: []string{"-c", "sleep 300 & echo $! > " + filepath.Join(dir, "group") +
+			"; setsid sleep 300 & echo $! > " + filepath.Join(dir, "session") + "; wait"},
+		Restart: true,
+		Home:    dir,
+		Logdir:  dir,
+		Rundir:  dir,
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- Start(conf)
+	}()
+
+	pids := []int{}
+	for _, name := range []string{"group", "session"} {
+		for i := 0; i < 50; i++ {
+			b, err := ioutil.ReadFile(filepath.Join(dir, name))
+			if pid, e := strconv.Atoi(strings.TrimSpace(string(b))); nil == err && nil == e {
+				pids = append(pids, pid)
+				break
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+	if 2 != len(pids) {
+		t.Fatalf("expected foo to have started two processes, not %v", pids)
+	}
+
+	if err := Stop(conf); nil != err {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-done:
+		if nil != err {
+			t.Fatal(err)
+		}
+	case <-time.After(15 * time.Second):
+		t.Fatal("the runner didn't exit after it was stopped")
+	}
+	for _, pid := range pids {
+		if nil == syscall.Kill(pid, 0) {
+			t.Errorf("expected pid %d to have been stopped along with foo", pid)
+			_ = syscall.Kill(pid, syscall.SIGKILL)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+// +build freebsd dragonfly
+
+package runner
+
+import (
+	"syscall"
+)
+
+// getRlimit is the limit as uint64s, as syscall.Rlimit is int64s on FreeBSD and DragonFly
+// (where RLIM_INFINITY is the largest int64)
+func getRlimit(lim *syscall.Rlimit) (cur uint64, max uint64) {
+	return uint64(lim.Cur), uint64(lim.Max)
+}
+
+func setRlimit(lim *syscall.Rlimit, cur uint64, max uint64) {
+	lim.Cur, lim.Max = int64(cur), int64(max)
+}
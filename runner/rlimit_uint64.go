@@ -0,0 +1,16 @@
+// +build !windows,!freebsd,!dragonfly
+
+package runner
+
+import (
+	"syscall"
+)
+
+// getRlimit is the limit as uint64s, which syscall.Rlimit already is here
+func getRlimit(lim *syscall.Rlimit) (cur uint64, max uint64) {
+	return lim.Cur, lim.Max
+}
+
+func setRlimit(lim *syscall.Rlimit, cur uint64, max uint64) {
+	lim.Cur, lim.Max = cur, max
+}
@@ -0,0 +1,272 @@
+package runner
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// how rotated logs are named, i.e. foo.log.20191231T235959Z.gz
+const segmentTime = "20060102T150405Z"
+
+// rotatingFile is a log file that's rotated when it gets too big or too old.
+// The old segments are gzipped, and pruned according to the retention.
+type rotatingFile struct {
+	mux     sync.Mutex
+	path    string
+	logging service.Logging
+	maxSize int64
+	maxAge  time.Duration
+	f       *os.File
+	size    int64
+	since   time.Time
+	pending sync.WaitGroup
+}
+
+func openRotatingFile(path string, logging service.Logging) (*rotatingFile, error) {
+	maxSize, err := logging.MaxSizeBytes()
+	if nil != err {
+		return nil, err
+	}
+	maxAge, err := logging.MaxAgeDuration()
+	if nil != err {
+		return nil, err
+	}
+
+	r := &rotatingFile{
+		path:    path,
+		logging: logging,
+		maxSize: int64(maxSize),
+		maxAge:  maxAge,
+	}
+	if err := r.open(); nil != err {
+		return nil, err
+	}
+
+	// the age is from the last rotation (as best we can tell),
+	// so that restarting the service doesn't put off rotating
+	r.since = time.Now()
+	if segments := segments(path); len(segments) > 0 {
+		if fi, err := os.Stat(segments[len(segments)-1]); nil == err {
+			r.since = fi.ModTime()
+		}
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if nil != err {
+		return err
+	}
+	fi, err := f.Stat()
+	if nil != err {
+		_ = f.Close()
+		return err
+	}
+	r.f = f
+	r.size = fi.Size()
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if r.due(int64(len(p))) {
+		if err := r.rotate(); nil != err {
+			fmt.Fprintf(r.f, "[%s] Could not rotate %q: %s\n", time.Now(), r.path, err)
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// due is true when the next write would make the file too big, or it's too old
+func (r *rotatingFile) due(n int64) bool {
+	if r.maxAge > 0 && time.Since(r.since) >= r.maxAge && r.size > 0 {
+		return true
+	}
+	if r.maxSize <= 0 || r.size+n <= r.maxSize {
+		return false
+	}
+	// it may have been truncated (i.e. by serviceman logs --rotate)
+	if fi, err := r.f.Stat(); nil == err {
+		r.size = fi.Size()
+	}
+	return r.size > 0 && r.size+n > r.maxSize
+}
+
+// rotate moves the current file aside, starts a new one,
+// and then gzips and prunes the old ones in the background
+func (r *rotatingFile) rotate() error {
+	// (Windows won't rename a file that's open)
+	segment := segmentPath(r.path)
+	_ = r.f.Close()
+	renameErr := os.Rename(r.path, segment)
+	if err := r.open(); nil != err {
+		return err
+	}
+	if nil != renameErr {
+		// keep writing to the old one, rather than nowhere
+		return renameErr
+	}
+	r.since = time.Now()
+
+	r.pending.Add(1)
+	go func() {
+		defer r.pending.Done()
+		_ = compress(segment)
+		prune(r.path, r.logging)
+	}()
+	return nil
+}
+
+// Close closes the file, once the old ones are done being gzipped
+func (r *rotatingFile) Close() error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.pending.Wait()
+	return r.f.Close()
+}
+
+// RotateLog copies a log file to a gzipped segment and then truncates it,
+// for log files that belong to someone else (i.e. launchd), which can't be moved aside
+func RotateLog(path string, logging service.Logging) error {
+	fi, err := os.Stat(path)
+	if nil != err {
+		return err
+	}
+	if 0 == fi.Size() {
+		return nil
+	}
+
+	if err := copyFile(path, segmentPath(path)+".gz"); nil != err {
+		return err
+	}
+	if err := os.Truncate(path, 0); nil != err {
+		return err
+	}
+	prune(path, logging)
+	return nil
+}
+
+// segmentPath is where to put the current log when it's rotated
+func segmentPath(path string) string {
+	now := time.Now().UTC()
+	segment := path + "." + now.Format(segmentTime)
+	for i := 1; ; i++ {
+		_, err := os.Stat(segment)
+		_, gzerr := os.Stat(segment + ".gz")
+		if os.IsNotExist(err) && os.IsNotExist(gzerr) {
+			return segment
+		}
+		segment = fmt.Sprintf("%s.%s-%d", path, now.Format(segmentTime), i)
+	}
+}
+
+// segments are the rotated logs, oldest first
+func segments(path string) []string {
+	type segment struct {
+		path string
+		t    time.Time
+		n    int
+	}
+	matches, _ := filepath.Glob(path + ".*")
+	olds := []segment{}
+	for _, match := range matches {
+		suffix := strings.TrimSuffix(strings.TrimPrefix(match, path+"."), ".gz")
+		n := 0
+		// i.e. 20191231T235959Z-1, for the second rotation in the same second
+		if i := strings.Index(suffix, "-"); i > 0 {
+			n, _ = strconv.Atoi(suffix[i+1:])
+			suffix = suffix[:i]
+		}
+		if t, err := time.Parse(segmentTime, suffix); nil == err {
+			olds = append(olds, segment{match, t, n})
+		}
+	}
+	sort.Slice(olds, func(i, j int) bool {
+		if !olds[i].t.Equal(olds[j].t) {
+			return olds[i].t.Before(olds[j].t)
+		}
+		return olds[i].n < olds[j].n
+	})
+
+	paths := []string{}
+	for i := range olds {
+		paths = append(paths, olds[i].path)
+	}
+	return paths
+}
+
+// prune removes the rotated logs that are past the retention
+func prune(path string, logging service.Logging) {
+	keep, days := logging.Retention()
+	olds := segments(path)
+	for i, old := range olds {
+		expired := keep > 0 && i < len(olds)-keep
+		if !expired && days > 0 {
+			if fi, err := os.Stat(old); nil == err {
+				expired = time.Since(fi.ModTime()) > time.Duration(days)*24*time.Hour
+			}
+		}
+		if expired {
+			_ = os.Remove(old)
+		}
+	}
+}
+
+// compress gzips a rotated log, and removes the original
+func compress(segment string) error {
+	if err := copyFile(segment, segment+".gz"); nil != err {
+		return err
+	}
+	return os.Remove(segment)
+}
+
+// copyFile copies a file, gzipping it if the destination ends in .gz
+func copyFile(src, dst string) error {
+	r, err := os.Open(src)
+	if nil != err {
+		return err
+	}
+	defer r.Close()
+	fi, err := r.Stat()
+	if nil != err {
+		return err
+	}
+
+	f, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fi.Mode().Perm())
+	if nil != err {
+		return err
+	}
+	var w io.WriteCloser = f
+	if strings.HasSuffix(dst, ".gz") {
+		w = gzip.NewWriter(f)
+	}
+	if _, err := io.Copy(w, r); nil != err {
+		_ = f.Close()
+		return err
+	}
+	if w != f {
+		if err := w.Close(); nil != err {
+			_ = f.Close()
+			return err
+		}
+	}
+	if err := f.Close(); nil != err {
+		return err
+	}
+	return os.Chtimes(dst, fi.ModTime(), fi.ModTime())
+}
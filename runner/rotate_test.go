@@ -0,0 +1,243 @@
+package runner
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+func tempLogdir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "serviceman-rotate-")
+	if nil != err {
+		t.Fatal(err)
+	}
+	return dir, func() { _ = os.RemoveAll(dir) }
+}
+
+func readFile(t *testing.T, path string) string {
+	b, err := ioutil.ReadFile(path)
+	if nil != err {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func readGzip(t *testing.T, path string) string {
+	f, err := os.Open(path)
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if nil != err {
+		t.Fatalf("%s isn't gzipped: %s", path, err)
+	}
+	b, err := ioutil.ReadAll(r)
+	if nil != err {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestRotateBySize(t *testing.T) {
+	dir, cleanup := tempLogdir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "foo.log")
+
+	r, err := openRotatingFile(path, service.Logging{MaxSize: "16"})
+	if nil != err {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"first line\n", "second line\n", "third\n"} {
+		if _, err := r.Write([]byte(line)); nil != err {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Close(); nil != err {
+		t.Fatal(err)
+	}
+
+	// no two of the lines fit in 16 bytes together, so each write but the first rotates
+	olds := segments(path)
+	if 2 != len(olds) {
+		t.Fatalf("expected 2 rotated logs, not %q", olds)
+	}
+	for i, expected := range []string{"first line\n", "second line\n"} {
+		if !strings.HasSuffix(olds[i], ".gz") {
+			t.Errorf("expected %s to be gzipped", olds[i])
+			continue
+		}
+		if s := readGzip(t, olds[i]); expected != s {
+			t.Errorf("expected %s to have %q, not %q", olds[i], expected, s)
+		}
+	}
+	if s := readFile(t, path); "third\n" != s {
+		t.Errorf("expected the current log to have %q, not %q", "third\n", s)
+	}
+}
+
+func TestRotateByAge(t *testing.T) {
+	dir, cleanup := tempLogdir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "foo.log")
+
+	r, err := openRotatingFile(path, service.Logging{MaxAge: "1h"})
+	if nil != err {
+		t.Fatal(err)
+	}
+	if _, err := r.Write([]byte("old\n")); nil != err {
+		t.Fatal(err)
+	}
+	if _, err := r.Write([]byte("still young\n")); nil != err {
+		t.Fatal(err)
+	}
+	if 0 != len(segments(path)) {
+		t.Fatalf("expected no rotation before an hour, not %q", segments(path))
+	}
+
+	r.mux.Lock()
+	r.since = time.Now().Add(-61 * time.Minute)
+	r.mux.Unlock()
+	if _, err := r.Write([]byte("new\n")); nil != err {
+		t.Fatal(err)
+	}
+	if err := r.Close(); nil != err {
+		t.Fatal(err)
+	}
+
+	olds := segments(path)
+	if 1 != len(olds) {
+		t.Fatalf("expected 1 rotated log, not %q", olds)
+	}
+	if s := readGzip(t, olds[0]); "old\nstill young\n" != s {
+		t.Errorf("expected the rotated log to have the old lines, not %q", s)
+	}
+	if s := readFile(t, path); "new\n" != s {
+		t.Errorf("expected the current log to have %q, not %q", "new\n", s)
+	}
+}
+
+func TestRotateLog(t *testing.T) {
+	dir, cleanup := tempLogdir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "foo.log")
+
+	if err := ioutil.WriteFile(path, []byte("hello\n"), 0644); nil != err {
+		t.Fatal(err)
+	}
+	if err := RotateLog(path, service.Logging{}); nil != err {
+		t.Fatal(err)
+	}
+	olds := segments(path)
+	if 1 != len(olds) {
+		t.Fatalf("expected 1 rotated log, not %q", olds)
+	}
+	if s := readGzip(t, olds[0]); "hello\n" != s {
+		t.Errorf("expected the rotated log to have %q, not %q", "hello\n", s)
+	}
+	if s := readFile(t, path); "" != s {
+		t.Errorf("expected the log to be truncated, not %q", s)
+	}
+
+	// there's nothing to rotate in an empty log
+	if err := RotateLog(path, service.Logging{}); nil != err {
+		t.Fatal(err)
+	}
+	if 1 != len(segments(path)) {
+		t.Errorf("expected an empty log not to be rotated, not %q", segments(path))
+	}
+}
+
+// makeSegments makes rotated logs that are each a day older than the next
+func makeSegments(t *testing.T, path string, n int) []string {
+	now := time.Now()
+	paths := []string{}
+	for i := 0; i < n; i++ {
+		age := time.Duration(n-i) * 24 * time.Hour
+		segment := path + "." + now.Add(-age).UTC().Format(segmentTime) + ".gz"
+		if err := ioutil.WriteFile(segment, []byte("x"), 0644); nil != err {
+			t.Fatal(err)
+		}
+		// (the age is from when it was last written to)
+		mtime := now.Add(-age).Add(time.Hour)
+		if err := os.Chtimes(segment, mtime, mtime); nil != err {
+			t.Fatal(err)
+		}
+		paths = append(paths, segment)
+	}
+	return paths
+}
+
+func TestPrune(t *testing.T) {
+	tests := []struct {
+		name    string
+		logging service.Logging
+		kept    int
+	}{
+		{"default", service.Logging{}, service.DefaultKeep},
+		{"keep", service.Logging{Keep: 3}, 3},
+		{"keep-days", service.Logging{KeepDays: 4}, 4},
+		{"keep and keep-days", service.Logging{Keep: 2, KeepDays: 4}, 2},
+		{"keep-days and keep", service.Logging{Keep: 6, KeepDays: 4}, 4},
+	}
+	for _, tt := range tests {
+		dir, cleanup := tempLogdir(t)
+		path := filepath.Join(dir, "foo.log")
+		paths := makeSegments(t, path, 10)
+
+		prune(path, tt.logging)
+		olds := segments(path)
+		if len(paths[len(paths)-tt.kept:]) != len(olds) {
+			t.Errorf("%s: expected the newest %d rotated logs to be kept, not %q", tt.name, tt.kept, olds)
+		} else {
+			for i, old := range paths[len(paths)-tt.kept:] {
+				if old != olds[i] {
+					t.Errorf("%s: expected %s to be kept, not %s", tt.name, old, olds[i])
+				}
+			}
+		}
+		cleanup()
+	}
+}
+
+func TestSegments(t *testing.T) {
+	dir, cleanup := tempLogdir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "foo.log")
+
+	names := []string{
+		"foo.log.20191231T235959Z-1.gz",
+		"foo.log.20191231T235959Z.gz",
+		"foo.log.20200101T000000Z",
+		"foo.log.20191130T000000Z.gz",
+		"foo.log.bak",
+	}
+	for _, name := range names {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte("x"), 0644); nil != err {
+			t.Fatal(err)
+		}
+	}
+
+	expected := []string{
+		"foo.log.20191130T000000Z.gz",
+		"foo.log.20191231T235959Z.gz",
+		"foo.log.20191231T235959Z-1.gz",
+		"foo.log.20200101T000000Z",
+	}
+	olds := segments(path)
+	if len(expected) != len(olds) {
+		t.Fatalf("expected %q, not %q", expected, olds)
+	}
+	for i := range expected {
+		if filepath.Join(dir, expected[i]) != olds[i] {
+			t.Errorf("expected %q, not %q", expected, olds)
+			break
+		}
+	}
+}
@@ -2,12 +2,13 @@ package runner
 
 import (
 	"fmt"
-	"io/ioutil"
+	"net"
 	"os"
 	"os/exec"
-	"path/filepath"
-	"strconv"
+	"os/signal"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"git.rootprojects.org/root/serviceman/service"
@@ -18,30 +19,258 @@ import (
 // Filled in on init by runner_windows.go
 var shellArgs = []string{}
 
+// how long a service has to stop when it didn't set stop_timeout
+const defaultStopTimeout = 10 * time.Second
+
 // Notes on spawning a child process
 // https://groups.google.com/forum/#!topic/golang-nuts/shST-SDqIp4
 
-// Start will execute the service, and write the PID and logs out to the log directory
-func Start(conf *service.Service) error {
-	pid := os.Getpid()
-	originalBackoff := 1 * time.Second
-	maxBackoff := 1 * time.Minute
-	threshold := 5 * time.Second
+// Start will execute the service, and write the logs out to the log directory.
+// A service with instances is run once for each, each with its own log.
+// The runner answers on its control socket until the service has stopped.
+func Start(conf *service.Service) (err error) {
+	// a daemon's parent is waiting to hear whether it started
+	ready := readyPipe()
+	defer func() {
+		if nil != err {
+			notifyReady(ready, err)
+		}
+	}()
 
-	backoff := originalBackoff
-	failures := 0
-	logfile := filepath.Join(conf.Logdir, conf.Name+".log")
+	r, err := startRun(conf, nil)
+	if nil != err {
+		return err
+	}
+	notifyReady(ready, nil)
+
+	// Stopping the runner gracefully stops the service first
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM, os.Interrupt)
+	go func() {
+		sig := <-sigs
+		r.stop(sig.String())
+	}()
+
+	r.wait()
+	return nil
+}
+
+// serviceRun is a service that the runner is running: a supervisor for each
+// of its instances, and the pid file and control socket that it holds until
+// they've all stopped
+type serviceRun struct {
+	conf *service.Service
+	pf   *os.File
+	ctl  controlListener
+	// on the service's metrics address, if it has one
+	metrics net.Listener
+	sups    []*supervisor
+	done    chan struct{}
+
+	// how many of the instances are being supervised, and whether they've all
+	// stopped, after which none can be started again
+	mux         sync.Mutex
+	supervising int
+	exiting     bool
+}
+
+// startRun starts supervising the service (and each of its instances),
+// once it's sure that no other runner is. Its logs go to the console, if there is one.
+func startRun(conf *service.Service, c *console) (*serviceRun, error) {
+	confs := []*service.Service{conf}
+	if conf.Multi() {
+		confs = nil
+		for _, instance := range conf.InstanceNames() {
+			c, err := conf.ForInstance(instance)
+			if nil != err {
+				return nil, err
+			}
+			confs = append(confs, c)
+		}
+	}
+
+	// (the pid file may be in it)
+	ownLogdir, err := makeLogdir(conf)
+	if nil != err {
+		return nil, err
+	}
 
-	if oldPid, exename, err := getProcess(conf); nil == err {
-		return fmt.Errorf("%q may already be running as %q (pid %d)", conf.Name, exename, oldPid)
+	// the lock on the pid file keeps a second runner from starting,
+	// and the pid file is otherwise only for whatever else still looks for it
+	pf, err := lockPidFile(conf)
+	if nil != err {
+		return nil, err
+	}
+
+	ctl, err := listen(conf)
+	if nil != err {
+		releasePidFile(pf)
+		return nil, err
+	}
+	r := &serviceRun{conf: conf, pf: pf, ctl: ctl, done: make(chan struct{})}
+
+	var lf *serviceLog
+	if nil != c {
+		lf = c.openLogs(conf)
+	} else {
+		lf = openLogs(conf, newRunID())
+	}
+	// each instance has a cgroup of its own, as each would with systemd
+	cgroups := []*os.File{}
+	for i := range confs {
+		cgroups = append(cgroups, applyLimits(confs[i], lf))
+	}
+	if err := becomeSubreaper(); nil != err {
+		fmt.Fprintf(lf, "[%s] Warning: orphans that %q leaves behind won't be stopped with it: %s\n", time.Now(), conf.Name, err)
+	}
+	lf.Close()
+
+	// like systemd's StateDirectory= and friends, these exist before the service starts
+	for _, dir := range conf.DirectoryPaths() {
+		if err := os.MkdirAll(dir, 0755); nil != err {
+			r.close()
+			return nil, err
+		}
+	}
+
+	// and they belong to the service's user, if it isn't root (as do its log files,
+	// but not a log directory that isn't its own)
+	owned := append([]string{pf.Name()}, conf.DirectoryPaths()...)
+	if ownLogdir {
+		owned = append(owned, conf.Logdir)
+	}
+	if err := chownToService(conf, owned...); nil != err {
+		r.close()
+		return nil, fmt.Errorf("could not give %q's files to %q: %s", conf.Name, conf.User, err)
 	}
 
+	for i := range confs {
+		s := newSupervisor(confs[i])
+		s.cgroup = cgroups[i]
+		s.console = c
+		r.sups = append(r.sups, s)
+	}
+	go serveControl(ctl, r)
+	if "" != conf.Metrics {
+		r.metrics, err = serveMetrics(conf, r.sups)
+		if nil != err {
+			r.close()
+			return nil, err
+		}
+	}
+
+	for i := range r.sups {
+		_ = r.supervise(r.sups[i])
+	}
+	return r, nil
+}
+
+// supervise has the instance run (again), unless every instance has already stopped
+// (and so the runner is exiting). Once they all have, done is closed.
+func (r *serviceRun) supervise(s *supervisor) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.exiting {
+		return fmt.Errorf("%q's runner is exiting", r.conf.Name)
+	}
+	r.supervising++
+
+	s.mux.Lock()
+	s.supervising = true
+	s.supervised = make(chan struct{})
+	supervised := s.supervised
+	s.mux.Unlock()
 	go func() {
-		for {
-			maybeWritePidFile(pid, conf)
-			time.Sleep(1 * time.Second)
+		s.supervise()
+		s.mux.Lock()
+		s.supervising = false
+		s.mux.Unlock()
+		close(supervised)
+
+		r.mux.Lock()
+		defer r.mux.Unlock()
+		r.supervising--
+		if 0 == r.supervising {
+			r.exiting = true
+			close(r.done)
 		}
 	}()
+	return nil
+}
+
+// start starts an instance that was stopped, or has exited, again,
+// or else restarts it, as serviceman start does
+func (r *serviceRun) start(s *supervisor) error {
+	s.mux.Lock()
+	// (one that has just stopped may still be finishing up, i.e. its post-stop hooks)
+	for s.supervising && s.stopping {
+		supervised := s.supervised
+		s.mux.Unlock()
+		<-supervised
+		s.mux.Lock()
+	}
+	if s.supervising {
+		s.mux.Unlock()
+		return s.restart()
+	}
+	// (this has to happen before supervise, which reads them)
+	s.stopping = false
+	s.restarting = false
+	s.quit = make(chan struct{})
+	s.stopped = make(chan struct{})
+	s.state = StateStarting
+	s.since = time.Now()
+	s.failures = 0
+	s.backoff = 0
+	lf := s.lf
+	s.mux.Unlock()
+
+	fmt.Fprintf(lf, "[%s] Received a start request for %q\n", time.Now(), s.conf.InstanceName())
+	return r.supervise(s)
+}
+
+// stop gracefully stops each of the instances, and returns once they've stopped
+func (r *serviceRun) stop(reason string) {
+	var wg sync.WaitGroup
+	for i := range r.sups {
+		wg.Add(1)
+		go func(s *supervisor) {
+			defer wg.Done()
+			s.stop(reason)
+		}(r.sups[i])
+	}
+	wg.Wait()
+}
+
+// wait waits for each of the instances to have stopped (or exited, without restarting),
+// lets go of the control socket and the pid file, and returns how each last exited
+func (r *serviceRun) wait() []InstanceStatus {
+	<-r.done
+	r.close()
+	statuses := []InstanceStatus{}
+	for i := range r.sups {
+		statuses = append(statuses, r.sups[i].status())
+	}
+	return statuses
+}
+
+func (r *serviceRun) close() {
+	if nil != r.metrics {
+		_ = r.metrics.Close()
+	}
+	_ = r.ctl.Close()
+	releasePidFile(r.pf)
+}
+
+// supervise runs the service, and restarts it (with backoff) until it's stopped
+func (s *supervisor) supervise() {
+	conf := s.conf
+	originalBackoff := 1 * time.Second
+	maxBackoff := 1 * time.Minute
+	threshold := 5 * time.Second
+
+	backoff := originalBackoff
+	failures := 0
 
 	binpath := conf.Exec
 	args := []string{}
@@ -57,183 +286,598 @@ func Start(conf *service.Service) error {
 		binpath = shellArgs[0]
 	}
 
+	if closeNotify := s.listenNotify(); nil != closeNotify {
+		defer closeNotify()
+	}
+
 	for {
-		// setup the log
-		lf, err := os.OpenFile(logfile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
-		if nil != err {
-			fmt.Fprintf(os.Stderr, "[%s] Could not open log file %q\n", time.Now(), logfile)
-			lf = os.Stderr
-		} else {
-			defer lf.Close()
+		// (a stop while waiting to restart is a stop, before any hook runs again)
+		if s.isStopping() {
+			<-s.stopped
+			return
 		}
 
+		// setup the log
+		// each start gets its own run ID
+		runID := newRunID()
+		lf := s.openLogs(runID)
+		s.setRun(lf, runID)
+
 		start := time.Now()
-		cmd := exec.Command(binpath, args...)
-		backgroundCmd(cmd)
-		fmt.Fprintf(lf, "[%s] Starting %q %s \n", time.Now(), binpath, strings.Join(args, " "))
-
-		cmd.Stdin = nil
-		cmd.Stdout = lf
-		cmd.Stderr = lf
-		if "" != conf.Workdir {
-			cmd.Dir = conf.Workdir
-		}
-		if len(conf.Envs) > 0 {
-			for k, v := range conf.Envs {
-				cmd.Env = append(cmd.Env, k+"="+v)
-			}
+		// the secrets are read once for each run, so that changes apply on restart
+		secrets, err := loadSecrets(conf)
+		if nil == err {
+			s.mux.Lock()
+			s.secrets = secrets
+			s.mux.Unlock()
+			err = s.waitForDependencies(lf)
 		}
-		err = cmd.Start()
+		if nil == err {
+			err = s.runHooks(secrets, "pre-start", conf.Hooks.PreStart, lf)
+		}
+		var run *Run
 		if nil != err {
-			fmt.Fprintf(lf, "[%s] Could not start %q process: %s\n", time.Now(), conf.Name, err)
+			fmt.Fprintf(lf, "[%s] Not starting %q: %s\n", time.Now(), conf.InstanceName(), err)
+			run = s.newRun(runID, start)
+			// as a shell would with a failed command
+			s.failedStart(run, "not started: "+err.Error(), 1)
 		} else {
-			err = cmd.Wait()
-			if nil != err {
-				fmt.Fprintf(lf, "[%s] Process %q failed with error: %s\n", time.Now(), conf.Name, err)
-			} else {
-				fmt.Fprintf(lf, "[%s] Process %q exited cleanly\n", time.Now(), conf.Name)
-			}
+			run = s.runChild(runID, secrets, binpath, args)
+		}
+
+		// like systemd's ExecStopPost, this runs whether or not the start succeeded
+		_ = s.runHooks(secrets, "post-stop", conf.Hooks.PostStop, lf)
+
+		if s.isStopping() {
+			<-s.stopped
+			fmt.Fprintf(lf, "[%s] Stopped %q\n", time.Now(), conf.InstanceName())
+			s.record(run, false, lf)
+			lf.Close()
+			break
+		}
+
+		// a restart that was asked for starts right back up
+		if s.restartRequested() {
+			fmt.Fprintf(lf, "[%s] Restarting %q\n", time.Now(), conf.InstanceName())
+			s.record(run, false, lf)
+			backoff = originalBackoff
+			failures = 0
+			s.setBackoff(failures, 0)
+			lf.Close()
+			continue
 		}
 
 		// if this is a oneshot... so it is
 		if !conf.Restart {
-			fmt.Fprintf(lf, "Not restarting %q because `restart` set to `false`\n", conf.Name)
+			fmt.Fprintf(lf, "Not restarting %q because `restart` set to `false`\n", conf.InstanceName())
+			s.setState(StateExited)
+			s.record(run, false, lf)
+			lf.Close()
 			break
 		}
 
+		s.setState(StateRestarting)
 		end := time.Now()
 		if end.Sub(start) > threshold {
+			s.record(run, false, lf)
 			backoff = originalBackoff
 			failures = 0
+			s.setBackoff(failures, 0)
 		} else {
+			s.record(run, true, lf)
 			failures++
-			fmt.Fprintf(lf, "Waiting %s to restart %q (%d consequtive immediate exits)\n", backoff, conf.Name, failures)
-			time.Sleep(backoff)
+			s.setBackoff(failures, backoff)
+			fmt.Fprintf(lf, "Waiting %s to restart %q (%d consequtive immediate exits)\n", backoff, conf.InstanceName(), failures)
+			select {
+			case <-s.quit:
+				fmt.Fprintf(lf, "[%s] Stopped %q\n", time.Now(), conf.InstanceName())
+				lf.Close()
+				<-s.stopped
+				return
+			case <-s.wake:
+			case <-time.After(backoff):
+			}
 			backoff *= 2
 			if backoff > maxBackoff {
 				backoff = maxBackoff
 			}
 		}
+		lf.Close()
 	}
+}
 
-	return nil
+func openLog(logfile string) *os.File {
+	lf, err := os.OpenFile(logfile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if nil != err {
+		fmt.Fprintf(os.Stderr, "[%s] Could not open log file %q\n", time.Now(), logfile)
+		return os.Stderr
+	}
+	return lf
 }
 
-// Stop will find and stop another serviceman runner instance by it's PID
-func Stop(conf *service.Service) error {
-	i := 0
-	var err error
-	for {
-		if i >= 3 {
-			return err
-		}
-		i++
-		oldPid, exename, err2 := getProcess(conf)
-		err = err2
-		switch err {
-		case nil:
-			fmt.Printf("killing old process %q with pid %d\n", exename, oldPid)
-			err := kill(oldPid)
-			if nil != err {
-				return err
-			}
-			return waitForProcessToDie(oldPid)
-		case ErrNoPidFile:
-			return err
-		case ErrNoProcess:
-			return err
-		case ErrInvalidPidFile:
-			fallthrough
-		default:
-			// waiting a little bit since the PID is written every second
-			time.Sleep(400 * time.Millisecond)
+// supervisor keeps track of the running child so that it can be stopped gracefully
+type supervisor struct {
+	conf        *service.Service
+	stopSignal  os.Signal
+	stopTimeout time.Duration
+	quit        chan struct{}
+	stopped     chan struct{}
+	cgroup      *os.File
+	// for serviceman up, rather than the service's own logs
+	console *console
+
+	mux      sync.Mutex
+	lf       *serviceLog
+	cmd      *exec.Cmd
+	exited   chan struct{}
+	stopping bool
+	// whether supervise is running (it isn't, once it has stopped, or exited),
+	// and closed once it returns
+	supervising bool
+	supervised  chan struct{}
+	// restarting is set when a restart is asked for, which skips the backoff
+	restarting bool
+	wake       chan struct{}
+	// the secret values, as read for this run
+	secrets map[string]string
+
+	// for the control socket's status
+	state    string
+	runID    string
+	since    time.Time
+	restarts int
+	lastExit string
+	exitCode int
+	// when the service's process started, and of the restarts,
+	// how many in a row were immediate failures, and how long the last one waited
+	started  time.Time
+	failures int
+	backoff  time.Duration
+
+	// for a service that uses sd_notify
+	notifyPath    string
+	statusText    string
+	pinged        time.Time
+	watchdogFired bool
+
+	// once the service has been sent the stop signal, and when it'll be killed
+	signaled bool
+	killAt   time.Time
+}
+
+func newSupervisor(conf *service.Service) *supervisor {
+	s := &supervisor{
+		conf:        conf,
+		stopSignal:  syscall.SIGTERM,
+		stopTimeout: defaultStopTimeout,
+		quit:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+		wake:        make(chan struct{}, 1),
+		state:       StateStarting,
+		since:       time.Now(),
+		lf:          &serviceLog{Writer: os.Stderr, Stdout: os.Stderr, Stderr: os.Stderr},
+	}
+	if "" != conf.StopSignal {
+		sig, err := parseSignal(conf.StopSignal)
+		if nil != err {
+			fmt.Fprintf(os.Stderr, "Warning: %s, using %s instead\n", err, s.stopSignal)
+		} else {
+			s.stopSignal = sig
 		}
 	}
+	if conf.StopTimeout > 0 {
+		s.stopTimeout = time.Duration(conf.StopTimeout) * time.Second
+	}
+	return s
+}
 
-	return fmt.Errorf("unexpected error: %s", err)
+// openLogs opens the service's logs, or the console
+func (s *supervisor) openLogs(runID string) *serviceLog {
+	if nil != s.console {
+		return s.console.openLogs(s.conf)
+	}
+	return openLogs(s.conf, runID)
 }
 
-// Restart calls Stop, ignoring any failure, and then Start, returning any failure
-func Restart(conf *service.Service) error {
-	_ = Stop(conf)
-	return Start(conf)
+// setRun is the log and the run ID of the next start
+func (s *supervisor) setRun(lf *serviceLog, runID string) {
+	s.mux.Lock()
+	if s.runID != "" {
+		s.restarts++
+	}
+	s.lf = lf
+	s.runID = runID
+	s.state = StateStarting
+	s.since = time.Now()
+	s.mux.Unlock()
 }
 
-var ErrNoPidFile = fmt.Errorf("no pid file")
-var ErrInvalidPidFile = fmt.Errorf("malformed pid file")
-var ErrNoProcess = fmt.Errorf("process not found by pid")
+func (s *supervisor) setBackoff(failures int, backoff time.Duration) {
+	s.mux.Lock()
+	s.failures = failures
+	s.backoff = backoff
+	s.mux.Unlock()
+}
 
-func waitForProcessToDie(pid int) error {
-	exename := "unknown"
-	for i := 0; i < 10; i++ {
-		px, err := ps.FindProcess(pid)
-		if nil != err {
-			return nil
-		}
-		if nil == px {
-			return nil
+func (s *supervisor) setState(state string) {
+	s.mux.Lock()
+	s.state = state
+	s.since = time.Now()
+	s.mux.Unlock()
+}
+
+// status is what the control socket reports for this instance
+func (s *supervisor) status() InstanceStatus {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	st := InstanceStatus{
+		Name:     s.conf.InstanceName(),
+		State:    s.state,
+		Runner:   os.Getpid(),
+		RunID:    s.runID,
+		Since:    s.since,
+		Restarts: s.restarts,
+		LastExit: s.lastExit,
+		ExitCode: s.exitCode,
+		Status:   s.statusText,
+	}
+	if nil != s.cmd {
+		st.PID = s.cmd.Process.Pid
+	}
+	return st
+}
+
+// restartRequested is true (once) after a restart was asked for
+func (s *supervisor) restartRequested() bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	restarting := s.restarting
+	s.restarting = false
+	return restarting
+}
+
+// restart stops the service, if it's running, and has it start again
+// right away (rather than waiting out the backoff)
+func (s *supervisor) restart() error {
+	s.mux.Lock()
+	if s.stopping {
+		s.mux.Unlock()
+		return fmt.Errorf("%q is stopping", s.conf.InstanceName())
+	}
+	if StateExited == s.state || StateStopped == s.state {
+		s.mux.Unlock()
+		return fmt.Errorf("%q has exited, and won't be restarted", s.conf.InstanceName())
+	}
+	cmd := s.cmd
+	exited := s.exited
+	lf := s.lf
+	waiting := StateRestarting == s.state
+	if nil != cmd {
+		s.restarting = true
+		s.state = StateRestarting
+	}
+	s.mux.Unlock()
+
+	fmt.Fprintf(lf, "[%s] Received a restart request for %q\n", time.Now(), s.conf.InstanceName())
+	if nil != cmd {
+		s.stopChild(cmd, exited)
+		return nil
+	}
+	// it's waiting out the backoff (otherwise it's about to start anyway)
+	if waiting {
+		select {
+		case s.wake <- struct{}{}:
+		default:
 		}
-		exename = px.Executable()
-		time.Sleep(1 * time.Second)
 	}
-	return fmt.Errorf("process %q (%d) just won't die", exename, pid)
+	return nil
 }
 
-func getProcess(conf *service.Service) (int, string, error) {
-	// TODO make Pidfile() a property of conf?
-	pidFile := filepath.Join(conf.Logdir, conf.Name+".pid")
-	b, err := ioutil.ReadFile(pidFile)
-	if nil != err {
-		return 0, "", ErrNoPidFile
+// signal sends sig to the service's main process, if it's running
+func (s *supervisor) signal(sig os.Signal) error {
+	s.mux.Lock()
+	cmd := s.cmd
+	lf := s.lf
+	s.mux.Unlock()
+
+	if nil == cmd {
+		return fmt.Errorf("%q isn't running", s.conf.InstanceName())
 	}
+	fmt.Fprintf(lf, "[%s] Sending %s to %q (pid %d)\n", time.Now(), sig, s.conf.InstanceName(), cmd.Process.Pid)
+	return cmd.Process.Signal(sig)
+}
 
-	s := strings.TrimSpace(string(b))
-	oldPid, err := strconv.Atoi(s)
+func (s *supervisor) isStopping() bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.stopping
+}
+
+// runChild starts the service process, runs the post-start hooks, and waits for it to exit.
+// It returns the record of the run for the history (or nil if it was stopped before it started).
+func (s *supervisor) runChild(runID string, secrets map[string]string, binpath string, args []string) *Run {
+	conf := s.conf
+	lf := s.lf
+	run := s.newRun(runID, time.Now())
+	cmd, err := newCmd(conf, secrets, binpath, args)
+	if nil != err {
+		fmt.Fprintf(lf, "[%s] Could not start %q process: %s\n", time.Now(), conf.InstanceName(), err)
+		// as a shell would with a command that it can't run
+		s.failedStart(run, "could not start: "+err.Error(), 127)
+		return run
+	}
+	if err := limitCmd(cmd, childLimits(conf, lf)); nil != err {
+		fmt.Fprintf(lf, "Warning: %q won't have its limits: %s\n", conf.InstanceName(), err)
+	}
+	startInCgroup(cmd, s.cgroup)
+	setCapabilities(conf, cmd, lf)
+	cmd.Env = append(cmd.Env, s.notifyEnv()...)
+	out, err := setOutput(cmd, lf.Stdout, lf.Stderr)
 	if nil != err {
-		return 0, "", ErrInvalidPidFile
+		fmt.Fprintf(lf, "[%s] Could not start %q process: %s\n", time.Now(), conf.InstanceName(), err)
+		s.failedStart(run, "could not start: "+err.Error(), 127)
+		return run
 	}
+	// (it's stopped once the run is over, below, if the service gets to start)
+	defer out.stop()
 
-	px, err := ps.FindProcess(oldPid)
+	s.mux.Lock()
+	if s.stopping {
+		s.mux.Unlock()
+		return nil
+	}
+	fmt.Fprintf(lf, "[%s] Starting %q %s \n", time.Now(), binpath, mask(secrets, strings.Join(args, " ")))
+	run.Start = time.Now()
+	err = startCmd(cmd, true)
+	out.started()
 	if nil != err {
-		return 0, "", err
+		s.mux.Unlock()
+		fmt.Fprintf(lf, "[%s] Could not start %q process: %s\n", time.Now(), conf.InstanceName(), err)
+		// as a shell would with a command that it can't run
+		s.failedStart(run, "could not start: "+err.Error(), 127)
+		return run
+	}
+	run.PID = cmd.Process.Pid
+	if err := addToCgroup(cmd.Process.Pid, s.cgroup); nil != err {
+		fmt.Fprintf(lf, "Warning: %q is not in its cgroup: %s\n", conf.InstanceName(), err)
+	}
+	exited := make(chan struct{})
+	s.cmd = cmd
+	s.exited = exited
+	// with sd_notify, it's running once it says that it's ready
+	s.state = StateRunning
+	if "" != s.notifyPath {
+		s.state = StateStarting
 	}
-	if nil == px {
-		return 0, "", ErrNoProcess
+	s.since = time.Now()
+	s.started = s.since
+	s.statusText = ""
+	s.pinged = time.Now()
+	s.watchdogFired = false
+	s.signaled = false
+	s.mux.Unlock()
+
+	if "" != s.notifyPath && conf.Watchdog > 0 {
+		go s.watchdog(cmd, exited)
 	}
 
-	_, err = os.FindProcess(oldPid)
+	err = s.runHooks(secrets, "post-start", conf.Hooks.PostStart, lf)
+	if nil != err {
+		// a failed post-start means a failed start, as with systemd's ExecStartPost
+		fmt.Fprintf(lf, "[%s] Stopping %q: %s\n", time.Now(), conf.InstanceName(), err)
+		go s.stopChild(cmd, exited)
+	}
+
+	err = waitCmd(cmd, true)
+	s.mux.Lock()
+	s.cmd = nil
+	s.lastExit = "exited cleanly"
+	if nil != err {
+		s.lastExit = err.Error()
+	}
+	s.exitCode = exitCode(cmd.ProcessState)
+	if s.watchdogFired {
+		s.lastExit = "watchdog timeout (" + s.lastExit + ")"
+	}
+	run.End = time.Now()
+	run.Exit = s.lastExit
+	run.ExitCode = s.exitCode
+	run.Signal = exitSignal(cmd.ProcessState)
+	s.mux.Unlock()
 	if nil != err {
-		return 0, "", err
+		fmt.Fprintf(lf, "[%s] Process %q failed with error: %s\n", time.Now(), conf.InstanceName(), err)
+	} else {
+		fmt.Fprintf(lf, "[%s] Process %q exited cleanly\n", time.Now(), conf.InstanceName())
 	}
 
-	exename := px.Executable()
-	return oldPid, exename, nil
+	// the run isn't over until nothing that it started is left
+	s.stopLeftovers(cmd.Process.Pid, lf)
+	s.stopOrphans(lf)
+	out.stop()
+	lf.flush()
+	close(exited)
+	return run
 }
 
-// TODO error out if can't write to PID or log
-func maybeWritePidFile(pid int, conf *service.Service) bool {
-	newPid := []byte(strconv.Itoa(pid))
+// failedStart records why the service didn't start, with an exit code
+// (so that a oneshot that never ran isn't taken for a success)
+func (s *supervisor) failedStart(run *Run, why string, code int) {
+	s.mux.Lock()
+	s.lastExit = why
+	s.exitCode = code
+	s.mux.Unlock()
+	run.Exit = why
+	run.ExitCode = code
+}
 
-	// TODO use a specific PID dir? meh...
-	pidFile := filepath.Join(conf.Logdir, conf.Name+".pid")
-	b, err := ioutil.ReadFile(pidFile)
-	if nil != err {
-		ioutil.WriteFile(pidFile, newPid, 0644)
-		return true
+// stopLeftovers stops whatever is left of the service's session after it has
+// exited, which gets what's left of the stop timeout if it was being stopped
+func (s *supervisor) stopLeftovers(pid int, lf *serviceLog) {
+	defer endSession(pid)
+	if !sessionAlive(pid) {
+		return
 	}
 
-	s := strings.TrimSpace(string(b))
-	oldPid, err := strconv.Atoi(s)
-	if nil != err {
-		ioutil.WriteFile(pidFile, newPid, 0644)
-		return true
+	s.mux.Lock()
+	signaled := s.signaled
+	killAt := s.killAt
+	s.mux.Unlock()
+	if !signaled {
+		fmt.Fprintf(lf, "[%s] Stopping what's left of %q's session\n", time.Now(), s.conf.InstanceName())
+		_ = signalSession(pid, s.stopSignal)
+		killAt = time.Now().Add(s.stopTimeout)
+	}
+
+	for sessionAlive(pid) {
+		if time.Now().After(killAt) {
+			fmt.Fprintf(lf, "[%s] Killing what's left of %q's session\n", time.Now(), s.conf.InstanceName())
+			_ = killSession(pid)
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// stop keeps the service from restarting and stops it if it's running,
+// and returns once it has stopped
+func (s *supervisor) stop(reason string) {
+	s.mux.Lock()
+	if s.stopping {
+		stopped := s.stopped
+		s.mux.Unlock()
+		<-stopped
+		return
+	}
+	s.stopping = true
+	close(s.quit)
+	// (one that has already exited, and won't restart, stays that way)
+	if StateExited == s.state {
+		s.mux.Unlock()
+		close(s.stopped)
+		return
+	}
+	s.state = StateStopping
+	cmd := s.cmd
+	exited := s.exited
+	lf := s.lf
+	s.mux.Unlock()
+
+	fmt.Fprintf(lf, "[%s] Received %s, stopping %q\n", time.Now(), reason, s.conf.InstanceName())
+	if nil != cmd {
+		s.stopChild(cmd, exited)
+	}
+	s.setState(StateStopped)
+	close(s.stopped)
+}
+
+// stopChild runs the pre-stop hooks, sends the stop signal to the service's
+// session, kills the session if the service hasn't exited within the stop timeout,
+// and returns once the service (and whatever it started) is gone
+func (s *supervisor) stopChild(cmd *exec.Cmd, exited chan struct{}) {
+	s.mux.Lock()
+	lf := s.lf
+	secrets := s.secrets
+	s.mux.Unlock()
+
+	_ = s.runHooks(secrets, "pre-stop", s.conf.Hooks.PreStop, lf)
+
+	pid := cmd.Process.Pid
+	s.mux.Lock()
+	s.signaled = true
+	s.killAt = time.Now().Add(s.stopTimeout)
+	s.mux.Unlock()
+	if err := signalSession(pid, s.stopSignal); nil != err {
+		fmt.Fprintf(lf, "[%s] Could not send %s to %q (pid %d): %s\n", time.Now(), s.stopSignal, s.conf.InstanceName(), pid, err)
 	}
 
-	if oldPid != pid {
-		Stop(conf)
-		ioutil.WriteFile(pidFile, newPid, 0644)
-		return true
+	// (anything else left in the session gets whatever remains of the timeout)
+	select {
+	case <-exited:
+		return
+	case <-time.After(s.stopTimeout):
+	}
+
+	fmt.Fprintf(lf, "[%s] %q (pid %d) didn't stop within %s, killing it\n", time.Now(), s.conf.InstanceName(), pid, s.stopTimeout)
+	if err := killSession(pid); nil != err {
+		fmt.Fprintf(lf, "[%s] Could not kill %q (pid %d): %s\n", time.Now(), s.conf.InstanceName(), pid, err)
+	}
+	<-exited
+}
+
+// Stop asks the service's runner, over its control socket, to stop the service
+// (or just the one instance, if conf.Instance is set). The service has its stop
+// timeout to exit, and is killed if it doesn't. A runner from before there was
+// a control socket is found by its pid file instead.
+func Stop(conf *service.Service) error {
+	timeout := stopTimeout(conf)
+	_, err := control(conf, controlRequest{Command: "stop"}, timeout)
+	if ErrNotRunning != err {
+		if nil != err || "" != conf.Instance {
+			return err
+		}
+		// the runner exits once all of the instances have stopped
+		for deadline := time.Now().Add(5 * time.Second); Running(conf) && time.Now().Before(deadline); {
+			time.Sleep(100 * time.Millisecond)
+		}
+		return nil
+	}
+
+	oldPid, exename, err := getProcess(conf)
+	if nil != err {
+		return err
+	}
+	fmt.Printf("stopping old process %q with pid %d\n", exename, oldPid)
+	if err := terminate(oldPid); nil != err {
+		return err
 	}
+	if err := waitForProcessToDie(oldPid, timeout); nil == err {
+		return nil
+	}
+	// it's had time to exit, and for its pid to be given to something else
+	if _, _, err := getProcess(conf); nil != err {
+		if ErrNoPidFile == err {
+			return nil
+		}
+		return fmt.Errorf("not killing pid %d: %s", oldPid, err)
+	}
+	fmt.Printf("killing old process %q with pid %d\n", exename, oldPid)
+	if err := kill(oldPid); nil != err {
+		return err
+	}
+	return waitForProcessToDie(oldPid, 10*time.Second)
+}
+
+// Restart asks the service's runner to restart the service (or just the one instance),
+// which starts it again as soon as it has stopped
+func Restart(conf *service.Service) error {
+	_, err := control(conf, controlRequest{Command: "restart"}, stopTimeout(conf))
+	return err
+}
+
+// Resume asks the service's runner to start the service (or just the one instance)
+// again if it was stopped, or has exited, and to restart it if it's running
+func Resume(conf *service.Service) error {
+	_, err := control(conf, controlRequest{Command: "start"}, stopTimeout(conf))
+	return err
+}
 
-	return false
+var ErrNotRunning = fmt.Errorf("no runner is listening")
+var ErrNoPidFile = fmt.Errorf("no pid file")
+var ErrInvalidPidFile = fmt.Errorf("malformed pid file")
+var ErrNoProcess = fmt.Errorf("process not found by pid")
+
+func waitForProcessToDie(pid int, timeout time.Duration) error {
+	exename := "unknown"
+	for i := time.Duration(0); i < timeout; i += time.Second {
+		px, err := ps.FindProcess(pid)
+		if nil != err {
+			return nil
+		}
+		if nil == px {
+			return nil
+		}
+		exename = px.Executable()
+		time.Sleep(1 * time.Second)
+	}
+	return fmt.Errorf("process %q (%d) just won't die", exename, pid)
 }
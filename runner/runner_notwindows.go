@@ -3,11 +3,147 @@
 package runner
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+
+	ps "github.com/mitchellh/go-ps"
+	"golang.org/x/sys/unix"
 )
 
+var signals = map[string]syscall.Signal{
+	"SIGHUP":    syscall.SIGHUP,
+	"SIGINT":    syscall.SIGINT,
+	"SIGQUIT":   syscall.SIGQUIT,
+	"SIGABRT":   syscall.SIGABRT,
+	"SIGKILL":   syscall.SIGKILL,
+	"SIGUSR1":   syscall.SIGUSR1,
+	"SIGUSR2":   syscall.SIGUSR2,
+	"SIGPIPE":   syscall.SIGPIPE,
+	"SIGALRM":   syscall.SIGALRM,
+	"SIGTERM":   syscall.SIGTERM,
+	"SIGCONT":   syscall.SIGCONT,
+	"SIGSTOP":   syscall.SIGSTOP,
+	"SIGTSTP":   syscall.SIGTSTP,
+	"SIGWINCH":  syscall.SIGWINCH,
+	"SIGXCPU":   syscall.SIGXCPU,
+	"SIGVTALRM": syscall.SIGVTALRM,
+}
+
 func backgroundCmd(cmd *exec.Cmd) {
+	// The service gets a session (and so a process group) of its own
+	// so that stopping it also stops anything that it has started
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
+
+// closeOnExec keeps the fd from being inherited by anything that's started
+func closeOnExec(fd int) {
+	syscall.CloseOnExec(fd)
+}
+
+// parseSignal understands SIGTERM, TERM, and 15
+func parseSignal(name string) (os.Signal, error) {
+	if n, err := strconv.Atoi(name); nil == err {
+		return syscall.Signal(n), nil
+	}
+	name = strings.ToUpper(name)
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+	sig, ok := signals[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown signal %q", name)
+	}
+	return sig, nil
+}
+
+// signalSession sends sig to the process group of the session led by sid,
+// and to anything in the session that has gone into a process group of its own
+func signalSession(sid int, sig os.Signal) error {
+	err := syscall.Kill(-sid, sig.(syscall.Signal))
+	if syscall.ESRCH == err {
+		err = nil
+	}
+	for _, pid := range session(sid) {
+		if pgid, e := syscall.Getpgid(pid); nil == e && sid != pgid {
+			_ = syscall.Kill(pid, sig.(syscall.Signal))
+		}
+	}
+	return err
+}
+
+// sessionAlive is true while any process is left in the session led by sid
+func sessionAlive(sid int) bool {
+	return nil == syscall.Kill(-sid, 0) || 0 != len(session(sid))
+}
+
+func killSession(sid int) error {
+	return signalSession(sid, syscall.SIGKILL)
+}
+
+// session is each process in the session led by sid
+func session(sid int) []int {
+	procs, err := ps.Processes()
+	if nil != err {
+		return nil
+	}
+	pids := []int{}
+	for _, p := range procs {
+		if sid == sessionOf(p.Pid()) {
+			pids = append(pids, p.Pid())
+		}
+	}
+	return pids
+}
+
+func sessionOf(pid int) int {
+	sid, err := unix.Getsid(pid)
+	if nil != err {
+		return 0
+	}
+	return sid
+}
+
+// exitCode is the process's exit status, or 128 plus the signal that killed it,
+// as a shell would have it
+func exitCode(state *os.ProcessState) int {
+	if nil == state {
+		return -1
+	}
+	if ws, ok := state.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		return 128 + int(ws.Signal())
+	}
+	return state.ExitCode()
+}
+
+// exitSignal is the name of the signal that ended the process, if it was a signal
+func exitSignal(state *os.ProcessState) string {
+	if nil == state {
+		return ""
+	}
+	ws, ok := state.Sys().(syscall.WaitStatus)
+	if !ok || !ws.Signaled() {
+		return ""
+	}
+	for name, sig := range signals {
+		if sig == ws.Signal() {
+			return name
+		}
+	}
+	return ws.Signal().String()
+}
+
+// terminate asks a runner to stop gracefully
+func terminate(pid int) error {
+	p, err := os.FindProcess(pid)
+	// already died
+	if nil != err {
+		return nil
+	}
+	return p.Signal(syscall.SIGTERM)
 }
 
 func kill(pid int) error {
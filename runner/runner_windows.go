@@ -2,8 +2,10 @@ package runner
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"strconv"
+	"strings"
 	"syscall"
 )
 
@@ -11,6 +13,64 @@ func backgroundCmd(cmd *exec.Cmd) {
 	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
 }
 
+// closeOnExec has nothing to do, since a handle is only inherited if it's given
+func closeOnExec(fd int) {
+}
+
+// parseSignal only knows SIGKILL, since Windows doesn't have signals to speak of
+// (a service is stopped with taskkill, whatever its stop signal is)
+func parseSignal(name string) (os.Signal, error) {
+	switch strings.TrimPrefix(strings.ToUpper(name), "SIG") {
+	case "KILL", "9":
+		return os.Kill, nil
+	}
+	return nil, fmt.Errorf("%q is not supported on Windows (only SIGKILL is)", name)
+}
+
+// signalSession asks the whole process tree to close, without forcing it
+func signalSession(pid int, sig os.Signal) error {
+	cmd := exec.Command("taskkill", "/pid", strconv.Itoa(pid), "/T")
+	b, err := cmd.CombinedOutput()
+	if nil != err {
+		return fmt.Errorf("%s: %s", err.Error(), string(b))
+	}
+
+	return nil
+}
+
+// sessionAlive is always false, because signalSession and killSession
+// already take care of the whole process tree
+func sessionAlive(pid int) bool {
+	return false
+}
+
+func killSession(pid int) error {
+	return kill(pid)
+}
+
+// sessionOf is always 0, since there are no sessions to speak of
+func sessionOf(pid int) int {
+	return 0
+}
+
+// exitCode is the process's exit status
+func exitCode(state *os.ProcessState) int {
+	if nil == state {
+		return -1
+	}
+	return state.ExitCode()
+}
+
+// exitSignal is always empty, as nothing on Windows ends by a signal
+func exitSignal(state *os.ProcessState) string {
+	return ""
+}
+
+// terminate can't be graceful on Windows
+func terminate(pid int) error {
+	return kill(pid)
+}
+
 func kill(pid int) error {
 	// Kill the whole processes tree (all children and grandchildren)
 	cmd := exec.Command("taskkill", "/pid", strconv.Itoa(pid), "/T", "/F")
@@ -0,0 +1,50 @@
+package runner
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// loadSecrets reads the secret values from the secrets env file (written by add)
+// and from the secret files, once for each run, so that changes apply on restart
+func loadSecrets(conf *service.Service) (map[string]string, error) {
+	secrets := map[string]string{}
+	if 0 == len(conf.Secrets) {
+		return secrets, nil
+	}
+
+	vals, err := service.ReadEnvFile(conf.SecretsPath())
+	if nil != err && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for i := range conf.Secrets {
+		secret := conf.Secrets[i]
+		if "" != secret.File {
+			b, err := ioutil.ReadFile(secret.File)
+			if nil != err {
+				return nil, err
+			}
+			secrets[secret.Name] = strings.TrimRight(string(b), "\r\n")
+			continue
+		}
+		if v, ok := vals[secret.Name]; ok {
+			secrets[secret.Name] = v
+		} else if service.SecretMask != secret.Value {
+			secrets[secret.Name] = secret.Value
+		}
+	}
+	return secrets, nil
+}
+
+// mask replaces any of the secret values in s, so that they don't end up in the logs
+func mask(secrets map[string]string, s string) string {
+	for _, v := range secrets {
+		if "" != v {
+			s = strings.Replace(s, v, service.SecretMask, -1)
+		}
+	}
+	return s
+}
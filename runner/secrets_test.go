@@ -0,0 +1,58 @@
+package runner
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+func TestLoadSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "serviceman-secrets-")
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "db-password")
+	if err := ioutil.WriteFile(file, []byte("s3cret\n"), 0600); nil != err {
+		t.Fatal(err)
+	}
+	conf := &service.Service{
+		Name: "foo",
+		Home: dir,
+		Secrets: []service.Secret{
+			{Name: "DB_PASSWORD", File: file},
+			{Name: "API_TOKEN", Value: "hunter2"},
+			// (as it is in an installed config, with the value in the secrets file)
+			{Name: "OTHER_TOKEN", Value: service.SecretMask},
+		},
+	}
+	if err := os.MkdirAll(filepath.Dir(conf.SecretsPath()), 0755); nil != err {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(conf.SecretsPath(), []byte("OTHER_TOKEN='t0ken'\n"), 0600); nil != err {
+		t.Fatal(err)
+	}
+
+	secrets, err := loadSecrets(conf)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if "s3cret" != secrets["DB_PASSWORD"] || "hunter2" != secrets["API_TOKEN"] || "t0ken" != secrets["OTHER_TOKEN"] {
+		t.Fatalf("expected each secret's value, not %v", secrets)
+	}
+	if masked := mask(secrets, "--db s3cret --token t0ken"); "--db ******** --token ********" != masked {
+		t.Errorf("expected the secrets to be masked, not %q", masked)
+	}
+
+	env, err := environ(&service.Service{Name: "foo", EnvInherit: service.EnvInherit{}}, secrets)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if 3 != len(env) {
+		t.Errorf("expected just the secrets in a clean environment, not %q", env)
+	}
+}
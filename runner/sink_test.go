@@ -0,0 +1,208 @@
+// +build !windows
+
+package runner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// listenUnixgram stands in for journald or syslog
+func listenUnixgram(t *testing.T) (*net.UnixConn, string, func()) {
+	dir, err := ioutil.TempDir("", "serviceman-sink-")
+	if nil != err {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "socket")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if nil != err {
+		_ = os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return conn, path, func() {
+		_ = conn.Close()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func readDatagram(t *testing.T, conn net.Conn) []byte {
+	b := make([]byte, 64*1024)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, err := conn.Read(b)
+	if nil != err {
+		t.Fatal(err)
+	}
+	return b[:n]
+}
+
+func TestJournalSink(t *testing.T) {
+	conn, path, cleanup := listenUnixgram(t)
+	defer cleanup()
+
+	orig := journalSocket
+	journalSocket = path
+	defer func() { journalSocket = orig }()
+
+	conf := &service.Service{
+		Name:    "foo",
+		System:  true,
+		Logging: service.Logging{Destination: service.LogJournal, SyslogIdentifier: "foo-app"},
+	}
+	lf := openLogs(conf, "abcd1234")
+	defer lf.Close()
+
+	fmt.Fprintf(lf.Stderr, "oops\nsecond line")
+	lf.flush()
+
+	for _, want := range []string{"oops", "second line"} {
+		got := string(readDatagram(t, conn))
+		for _, field := range []string{
+			"MESSAGE=" + want + "\n",
+			"PRIORITY=3\n",
+			"SYSLOG_IDENTIFIER=foo-app\n",
+			"SERVICEMAN_SERVICE=foo\n",
+			"SERVICEMAN_STREAM=err\n",
+			"SERVICEMAN_RUN=abcd1234\n",
+		} {
+			if !strings.Contains(got, field) {
+				t.Errorf("journal entry %q should have %q", got, field)
+			}
+		}
+	}
+}
+
+func TestJournalFieldNewline(t *testing.T) {
+	b := &bytes.Buffer{}
+	journalField(b, "MESSAGE", "one\ntwo")
+
+	want := &bytes.Buffer{}
+	want.WriteString("MESSAGE\n")
+	_ = binary.Write(want, binary.LittleEndian, uint64(7))
+	want.WriteString("one\ntwo\n")
+	if !bytes.Equal(want.Bytes(), b.Bytes()) {
+		t.Fatalf("expected %q, got %q", want.Bytes(), b.Bytes())
+	}
+}
+
+func TestSyslogSink(t *testing.T) {
+	conn, path, cleanup := listenUnixgram(t)
+	defer cleanup()
+
+	conf := &service.Service{
+		Name:    "foo",
+		System:  false,
+		Logging: service.Logging{Destination: service.LogSyslog, SyslogAddress: "unix://" + path},
+	}
+	lf := openLogs(conf, "abcd1234")
+	defer lf.Close()
+
+	fmt.Fprintf(lf.Stdout, "hello world\n")
+	fmt.Fprintf(lf, "[%s] Starting %q\n", time.Now(), "foo")
+
+	// <user.info>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID - MSG
+	fields := strings.SplitN(string(readDatagram(t, conn)), " ", 8)
+	if 8 != len(fields) {
+		t.Fatalf("expected an RFC 5424 message, got %q", fields)
+	}
+	if "<14>1" != fields[0] || "foo" != fields[3] || "abcd1234" != fields[4] ||
+		"out" != fields[5] || "-" != fields[6] || "hello world" != fields[7] {
+		t.Errorf("unexpected RFC 5424 message %q", fields)
+	}
+	if _, err := time.Parse(time.RFC3339, fields[1]); nil != err {
+		t.Errorf("bad timestamp: %s", err)
+	}
+
+	// the runner's own timestamp is left out
+	fields = strings.SplitN(string(readDatagram(t, conn)), " ", 8)
+	if "runner" != fields[5] || `Starting "foo"` != fields[7] {
+		t.Errorf("unexpected runner message %q", fields)
+	}
+}
+
+func TestSyslogUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	conf := &service.Service{
+		Name:    "foo",
+		System:  true,
+		Logging: service.Logging{Destination: service.LogSyslog, SyslogAddress: "udp://" + conn.LocalAddr().String()},
+	}
+	sink, err := openSyslog(conf, "abcd1234")
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	if err := sink.send("err", "oops"); nil != err {
+		t.Fatal(err)
+	}
+	b := make([]byte, 1024)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFrom(b)
+	if nil != err {
+		t.Fatal(err)
+	}
+	// daemon.err is 3*8 + 3
+	if msg := string(b[:n]); !strings.HasPrefix(msg, "<27>1 ") || !strings.HasSuffix(msg, " foo abcd1234 err - oops") {
+		t.Errorf("unexpected RFC 5424 message %q", msg)
+	}
+}
+
+func TestSinkFallback(t *testing.T) {
+	conn, path, cleanup := listenUnixgram(t)
+	defer cleanup()
+	dir, cleanupDir := tempLogdir(t)
+	defer cleanupDir()
+
+	orig := journalSocket
+	journalSocket = path
+	defer func() { journalSocket = orig }()
+
+	conf := &service.Service{
+		Name:    "foo",
+		Logdir:  dir,
+		Logging: service.Logging{Destination: service.LogJournal},
+	}
+	lf := openLogs(conf, "abcd1234")
+	defer lf.Close()
+
+	fmt.Fprintf(lf.Stdout, "to the journal\n")
+	if got := string(readDatagram(t, conn)); !strings.Contains(got, "MESSAGE=to the journal\n") {
+		t.Fatalf("expected the first line in the journal, not %q", got)
+	}
+
+	// journald is gone (and isn't back), so the lines go to the log file instead
+	cleanup()
+	done := make(chan struct{})
+	go func() {
+		fmt.Fprintf(lf.Stdout, "to the file\n")
+		fmt.Fprintf(lf.Stderr, "and this\n")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the output not to wait on the journal")
+	}
+
+	b, _ := ioutil.ReadFile(filepath.Join(dir, "foo.log"))
+	for _, want := range []string{"runner abcd1234 Could not log to journal", " out abcd1234 to the file\n", " err abcd1234 and this\n"} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected the log file to have %q, not %q", want, b)
+		}
+	}
+}
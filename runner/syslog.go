@@ -0,0 +1,141 @@
+package runner
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"git.rootprojects.org/root/serviceman/service"
+)
+
+// the usual places for the local syslog socket (Linux, macOS, BSD)
+var syslogSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// syslogSink sends each line as an RFC 5424 message,
+// to the local syslog socket, or to a unix or UDP socket of your choosing
+type syslogSink struct {
+	conn     *sinkConn
+	stream   bool
+	ident    string
+	hostname string
+	runID    string
+	facility int
+}
+
+// openSyslog connects to Logging.SyslogAddress, which may be
+// udp://host:port, unix:///path/to/socket, or just a path or a host:port,
+// or to the local syslog if that's empty
+func openSyslog(conf *service.Service, runID string) (*syslogSink, error) {
+	addr := conf.Logging.SyslogAddress
+	conn, stream, err := dialSyslog(addr)
+	if nil != err {
+		return nil, err
+	}
+	// (the messages end with a newline, or don't, for the kind of socket it was)
+	redial := func() (net.Conn, error) {
+		conn, s, err := dialSyslog(addr)
+		if nil == err && stream != s {
+			_ = conn.Close()
+			err = fmt.Errorf("%s is no longer the same kind of socket", addr)
+		}
+		return conn, err
+	}
+
+	hostname, _ := os.Hostname()
+	if "" == hostname {
+		hostname = "-"
+	}
+	facility := 3 // daemon
+	if !conf.System {
+		facility = 1 // user
+	}
+	return &syslogSink{
+		conn:     &sinkConn{conn: conn, dial: redial},
+		stream:   stream,
+		ident:    syslogName(conf.SyslogIdent(), 48),
+		hostname: syslogName(hostname, 255),
+		runID:    runID,
+		facility: facility,
+	}, nil
+}
+
+// dialSyslog connects to the syslog address, and says whether it's a stream
+// (rather than a datagram) socket, in which case messages end with a newline
+func dialSyslog(addr string) (net.Conn, bool, error) {
+	switch {
+	case "" == addr:
+		var err error
+		for _, path := range syslogSockets {
+			var conn net.Conn
+			var stream bool
+			conn, stream, err = dialUnix(path)
+			if nil == err {
+				return conn, stream, nil
+			}
+		}
+		return nil, false, err
+	case strings.HasPrefix(addr, "udp://"):
+		conn, err := net.Dial("udp", strings.TrimPrefix(addr, "udp://"))
+		return conn, false, err
+	case strings.HasPrefix(addr, "unix://"):
+		return dialUnix(strings.TrimPrefix(addr, "unix://"))
+	case strings.HasPrefix(addr, "/"):
+		return dialUnix(addr)
+	}
+	conn, err := net.Dial("udp", addr)
+	return conn, false, err
+}
+
+func dialUnix(path string) (net.Conn, bool, error) {
+	conn, err := net.Dial("unixgram", path)
+	if nil == err {
+		return conn, false, nil
+	}
+	conn, err = net.Dial("unix", path)
+	return conn, true, err
+}
+
+// send writes <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID SD MSG,
+// with the run as the PROCID (which RFC 5424 says is for telling restarts apart),
+// and the stream as the MSGID
+func (s *syslogSink) send(stream string, msg string) error {
+	line := fmt.Sprintf(
+		"<%d>1 %s %s %s %s %s - %s",
+		s.facility*8+priority(stream),
+		time.Now().Format("2006-01-02T15:04:05.000000Z07:00"),
+		s.hostname,
+		s.ident,
+		s.runID,
+		stream,
+		msg,
+	)
+	if s.stream {
+		line += "\n"
+	}
+
+	return s.conn.write([]byte(line))
+}
+
+func (s *syslogSink) Close() error {
+	return s.conn.Close()
+}
+
+// syslogName keeps a header field to printable ASCII without spaces,
+// as RFC 5424 requires
+func syslogName(name string, max int) string {
+	b := []byte{}
+	for _, c := range []byte(name) {
+		if c > 32 && c < 127 {
+			b = append(b, c)
+		}
+	}
+	if len(b) > max {
+		b = b[:max]
+	}
+	if 0 == len(b) {
+		return "-"
+	}
+	return string(b)
+}
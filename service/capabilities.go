@@ -0,0 +1,111 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// capabilities are the Linux capabilities, in the kernel's order
+// (see /usr/include/linux/capability.h)
+var capabilities = []string{
+	"CAP_CHOWN",
+	"CAP_DAC_OVERRIDE",
+	"CAP_DAC_READ_SEARCH",
+	"CAP_FOWNER",
+	"CAP_FSETID",
+	"CAP_KILL",
+	"CAP_SETGID",
+	"CAP_SETUID",
+	"CAP_SETPCAP",
+	"CAP_LINUX_IMMUTABLE",
+	"CAP_NET_BIND_SERVICE",
+	"CAP_NET_BROADCAST",
+	"CAP_NET_ADMIN",
+	"CAP_NET_RAW",
+	"CAP_IPC_LOCK",
+	"CAP_IPC_OWNER",
+	"CAP_SYS_MODULE",
+	"CAP_SYS_RAWIO",
+	"CAP_SYS_CHROOT",
+	"CAP_SYS_PTRACE",
+	"CAP_SYS_PACCT",
+	"CAP_SYS_ADMIN",
+	"CAP_SYS_BOOT",
+	"CAP_SYS_NICE",
+	"CAP_SYS_RESOURCE",
+	"CAP_SYS_TIME",
+	"CAP_SYS_TTY_CONFIG",
+	"CAP_MKNOD",
+	"CAP_LEASE",
+	"CAP_AUDIT_WRITE",
+	"CAP_AUDIT_CONTROL",
+	"CAP_SETFCAP",
+	"CAP_MAC_OVERRIDE",
+	"CAP_MAC_ADMIN",
+	"CAP_SYSLOG",
+	"CAP_WAKE_ALARM",
+	"CAP_BLOCK_SUSPEND",
+	"CAP_AUDIT_READ",
+	"CAP_PERFMON",
+	"CAP_BPF",
+	"CAP_CHECKPOINT_RESTORE",
+}
+
+// ParseCapability turns net_bind_service, NET_BIND_SERVICE, or CAP_NET_BIND_SERVICE
+// into CAP_NET_BIND_SERVICE, and its number. It's an error if the running kernel
+// (when it can be asked) doesn't know about the capability.
+func ParseCapability(name string) (string, int, error) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if !strings.HasPrefix(name, "CAP_") {
+		name = "CAP_" + name
+	}
+
+	for i := range capabilities {
+		if name != capabilities[i] {
+			continue
+		}
+		if last := lastCap(); i > last {
+			return "", 0, fmt.Errorf("%s is not supported by this kernel (which stops at %s)", name, capabilities[last])
+		}
+		return name, i, nil
+	}
+
+	return "", 0, fmt.Errorf("unknown capability %q", name)
+}
+
+// Caps are the capabilities that the service should have,
+// which includes CAP_NET_BIND_SERVICE for PrivilegedPorts
+func (s *Service) Caps() []string {
+	caps := []string{}
+	seen := map[string]bool{}
+	names := s.Capabilities
+	if s.PrivilegedPorts {
+		names = append([]string{"CAP_NET_BIND_SERVICE"}, names...)
+	}
+	for i := range names {
+		name := strings.ToUpper(names[i])
+		if !strings.HasPrefix(name, "CAP_") {
+			name = "CAP_" + name
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		caps = append(caps, name)
+	}
+	return caps
+}
+
+// ValidateCapabilities checks each of the Capabilities as ParseCapability does,
+// and gives each its full name (i.e. net_raw becomes CAP_NET_RAW), as a config
+// file may have them any which way
+func (s *Service) ValidateCapabilities() error {
+	for i := range s.Capabilities {
+		name, _, err := ParseCapability(s.Capabilities[i])
+		if nil != err {
+			return err
+		}
+		s.Capabilities[i] = name
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// lastCap is the number of the kernel's last capability, or of the last one we know of
+func lastCap() int {
+	last := len(capabilities) - 1
+	b, err := ioutil.ReadFile("/proc/sys/kernel/cap_last_cap")
+	if nil != err {
+		return last
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if nil != err || n > last {
+		return last
+	}
+	return n
+}
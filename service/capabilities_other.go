@@ -0,0 +1,8 @@
+// +build !linux
+
+package service
+
+// lastCap is the last capability we know of, since only Linux has capabilities
+func lastCap() int {
+	return len(capabilities) - 1
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCapability(t *testing.T) {
+	tests := []struct {
+		s    string
+		name string
+		n    int
+	}{
+		{"CAP_CHOWN", "CAP_CHOWN", 0},
+		{"net_bind_service", "CAP_NET_BIND_SERVICE", 10},
+		{" NET_RAW ", "CAP_NET_RAW", 13},
+		{"cap_sys_admin", "CAP_SYS_ADMIN", 21},
+	}
+	for _, tt := range tests {
+		name, n, err := ParseCapability(tt.s)
+		if nil != err {
+			t.Errorf("expected %q to parse: %s", tt.s, err)
+			continue
+		}
+		if tt.name != name || tt.n != n {
+			t.Errorf("expected %q to be %s (%d), not %s (%d)", tt.s, tt.name, tt.n, name, n)
+		}
+	}
+
+	for _, s := range []string{"", "CAP_", "net-raw", "CAP_FLY"} {
+		if _, _, err := ParseCapability(s); nil == err {
+			t.Errorf("expected %q not to parse", s)
+		}
+	}
+}
+
+func TestValidateCapabilities(t *testing.T) {
+	s := &Service{Capabilities: []string{"net_raw", "CAP_LEASE", "Net_Admin"}}
+	if err := s.ValidateCapabilities(); nil != err {
+		t.Fatal(err)
+	}
+	expected := []string{"CAP_NET_RAW", "CAP_LEASE", "CAP_NET_ADMIN"}
+	if !reflect.DeepEqual(expected, s.Capabilities) {
+		t.Errorf("expected %q, not %q", expected, s.Capabilities)
+	}
+
+	s = &Service{Capabilities: []string{"CAP_LEASE", "CAP_FLY"}}
+	if err := s.ValidateCapabilities(); nil == err {
+		t.Errorf("expected %q not to validate", s.Capabilities)
+	}
+}
+
+func TestCaps(t *testing.T) {
+	s := &Service{Capabilities: []string{"net_raw", "CAP_NET_BIND_SERVICE"}, PrivilegedPorts: true}
+	expected := []string{"CAP_NET_BIND_SERVICE", "CAP_NET_RAW"}
+	if caps := s.Caps(); !reflect.DeepEqual(expected, caps) {
+		t.Errorf("expected %q, not %q", expected, caps)
+	}
+}
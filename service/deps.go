@@ -0,0 +1,98 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// unitSuffixes are the systemd unit types that a dependency may name
+var unitSuffixes = []string{
+	".service", ".target", ".socket", ".mount", ".automount",
+	".swap", ".path", ".timer", ".device", ".slice", ".scope",
+}
+
+// UnitName is the systemd unit for a dependency, adding .service if it has no unit type
+// (i.e. postgresql => postgresql.service, network-online.target => network-online.target)
+func UnitName(name string) string {
+	for _, suffix := range unitSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return name
+		}
+	}
+	return name + ".service"
+}
+
+// ServiceName is the service for a dependency, without .service,
+// or "" if it names some other kind of unit (such as a .target)
+func ServiceName(name string) string {
+	unit := UnitName(name)
+	if ".service" != filepath.Ext(unit) {
+		return ""
+	}
+	return strings.TrimSuffix(unit, ".service")
+}
+
+// Dependencies are all of the services and units named in After, Requires, and Wants.
+// Services in Requires and Wants are also started after the ones they depend on.
+func (s *Service) Dependencies() []string {
+	deps := []string{}
+	seen := map[string]bool{}
+	for _, names := range [][]string{s.Requires, s.Wants, s.After} {
+		for i := range names {
+			unit := UnitName(names[i])
+			if seen[unit] {
+				continue
+			}
+			seen[unit] = true
+			deps = append(deps, unit)
+		}
+	}
+	return deps
+}
+
+// Order sorts services so that each comes after the services that it depends on
+// (stop them in reverse). Dependencies that aren't in the list are ignored.
+// The order is otherwise kept as given.
+func Order(services []*Service) ([]*Service, error) {
+	// instances share a name, and so are ordered together
+	byName := map[string][]*Service{}
+	for i := range services {
+		byName[services[i].Name] = append(byName[services[i].Name], services[i])
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	ordered := []*Service{}
+
+	var visit func(s *Service, path []string) error
+	visit = func(s *Service, path []string) error {
+		switch state[s.InstanceName()] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle: %s", strings.Join(append(path, s.Name), " -> "))
+		}
+		state[s.InstanceName()] = visiting
+		for _, dep := range s.Dependencies() {
+			for _, d := range byName[ServiceName(dep)] {
+				if err := visit(d, append(path, s.Name)); nil != err {
+					return err
+				}
+			}
+		}
+		state[s.InstanceName()] = visited
+		ordered = append(ordered, s)
+		return nil
+	}
+
+	for i := range services {
+		if err := visit(services[i], nil); nil != err {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
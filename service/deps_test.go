@@ -0,0 +1,60 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func names(services []*Service) string {
+	ns := []string{}
+	for i := range services {
+		ns = append(ns, services[i].InstanceName())
+	}
+	return strings.Join(ns, " ")
+}
+
+func TestOrder(t *testing.T) {
+	web := &Service{Name: "web", Requires: []string{"db"}, After: []string{"network-online.target"}}
+	worker := &Service{Name: "worker", Wants: []string{"queue.service"}}
+	db := &Service{Name: "db"}
+	queue := &Service{Name: "queue", After: []string{"db"}}
+	// (not in the list, and so ignored)
+	cache := &Service{Name: "cache", After: []string{"redis"}}
+
+	ordered, err := Order([]*Service{web, worker, cache, queue, db})
+	if nil != err {
+		t.Fatal(err)
+	}
+	if "db web queue worker cache" != names(ordered) {
+		t.Fatalf("expected each service after what it depends on, and otherwise as given, not %q", names(ordered))
+	}
+}
+
+func TestOrderCycle(t *testing.T) {
+	a := &Service{Name: "a", After: []string{"b"}}
+	b := &Service{Name: "b", Requires: []string{"c.service"}}
+	c := &Service{Name: "c", Wants: []string{"a"}}
+
+	_, err := Order([]*Service{a, b, c})
+	if nil == err {
+		t.Fatal("expected a dependency cycle to be an error")
+	}
+	if "dependency cycle: a -> b -> c -> a" != err.Error() {
+		t.Errorf("expected the cycle to be spelled out, not %q", err)
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	s := &Service{
+		Name:     "web",
+		Requires: []string{"db", "network-online.target"},
+		Wants:    []string{"db.service", "cache"},
+		After:    []string{"cache.service"},
+	}
+	if deps := strings.Join(s.Dependencies(), " "); "db.service network-online.target cache.service" != deps {
+		t.Errorf("expected each unit once, in order, not %q", deps)
+	}
+	if "" != ServiceName("network-online.target") || "db" != ServiceName("db.service") || "db" != ServiceName("db") {
+		t.Errorf("expected only services to have service names")
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// directoryKinds are StateDirectory, CacheDirectory, RuntimeDirectory, and LogsDirectory,
+// in that order, with the environment variable that systemd sets for each
+var directoryKinds = []struct {
+	env string
+	get func(s *Service) string
+}{
+	{"STATE_DIRECTORY", func(s *Service) string { return s.StateDirectory }},
+	{"CACHE_DIRECTORY", func(s *Service) string { return s.CacheDirectory }},
+	{"RUNTIME_DIRECTORY", func(s *Service) string { return s.RuntimeDirectory }},
+	{"LOGS_DIRECTORY", func(s *Service) string { return s.LogsDirectory }},
+}
+
+// Provisioned is true when a system service runs as its own (non-root) user,
+// which must then be created, along with the directories that it writes to.
+// With DynamicUser, systemd makes up the user as it goes.
+func (s *Service) Provisioned() bool {
+	if !s.System || "" == s.User || "root" == s.User {
+		return false
+	}
+	if dynamic := s.Sandboxing().DynamicUser; nil != dynamic && *dynamic {
+		return false
+	}
+	return true
+}
+
+// HasDirectories is true when the service asks for any of its own directories
+func (s *Service) HasDirectories() bool {
+	for _, kind := range directoryKinds {
+		if "" != kind.get(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateDirectories checks that each directory is relative, as systemd requires
+// (i.e. foo, or foo/bar, but not /var/lib/foo or ../foo)
+func (s *Service) ValidateDirectories() error {
+	for _, kind := range directoryKinds {
+		for _, dir := range strings.Fields(kind.get(s)) {
+			if filepath.IsAbs(dir) || strings.HasPrefix(filepath.Clean(dir), "..") {
+				return fmt.Errorf("%s %q must be a relative path, such as %s", kind.env, dir, s.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// DirectoryEnvs are the absolute paths of the directories, by the environment variable
+// that holds them (i.e. STATE_DIRECTORY=/var/lib/foo), just as systemd would set them
+func (s *Service) DirectoryEnvs() map[string]string {
+	envs := map[string]string{}
+	for i, kind := range directoryKinds {
+		paths := []string{}
+		for _, dir := range strings.Fields(kind.get(s)) {
+			paths = append(paths, filepath.Join(s.directoryBase(i), dir))
+		}
+		if len(paths) > 0 {
+			envs[kind.env] = strings.Join(paths, ":")
+		}
+	}
+	return envs
+}
+
+// DirectoryPaths are the absolute paths of all of the directories
+func (s *Service) DirectoryPaths() []string {
+	paths := []string{}
+	envs := s.DirectoryEnvs()
+	for _, kind := range directoryKinds {
+		if v, ok := envs[kind.env]; ok {
+			paths = append(paths, strings.Split(v, ":")...)
+		}
+	}
+	return paths
+}
+
+// directoryBase is where systemd puts each kind of directory,
+// i.e. /var/lib for system services, and ~/.local/state for user services
+func (s *Service) directoryBase(kind int) string {
+	if s.System {
+		run := "/run"
+		if "linux" != runtime.GOOS {
+			run = "/var/run"
+		}
+		return []string{"/var/lib", "/var/cache", run, "/var/log"}[kind]
+	}
+
+	state := filepath.Join(s.Home, ".local", "state")
+	run := os.Getenv("XDG_RUNTIME_DIR")
+	if "" == run {
+		run = filepath.Join(s.Home, ".local", "run")
+	}
+	return []string{state, filepath.Join(s.Home, ".cache"), run, filepath.Join(state, "log")}[kind]
+}
+
+// HistoryPath is where the runner keeps a record of each of the service's runs,
+// in serviceman's own state directory (i.e. /var/lib/serviceman/foo.history)
+func (s *Service) HistoryPath() string {
+	return filepath.Join(s.directoryBase(0), "serviceman", s.Name+".history")
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Environment inheritance policies
+const (
+	EnvInheritAll   = "inherit"
+	EnvInheritClean = "clean"
+)
+
+// EnvInherit is which of the runner's own environment variables the service gets.
+// In JSON it is either "inherit" (the default), "clean", or an allowlist of names:
+//
+// 	EnvInherit: EnvInherit{"PATH", "HOME", "LANG"},
+//
+// A nil EnvInherit inherits everything, and an empty (non-nil) one inherits nothing.
+// (That's why it's always written out, rather than omitted when it's empty.)
+type EnvInherit []string
+
+// ParseEnvInherit understands "inherit", "clean", or a comma-separated allowlist
+func ParseEnvInherit(s string) EnvInherit {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", EnvInheritAll:
+		return nil
+	case EnvInheritClean:
+		return EnvInherit{}
+	}
+	allow := EnvInherit{}
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if "" != name {
+			allow = append(allow, name)
+		}
+	}
+	return allow
+}
+
+// All is true when every variable is inherited
+func (e EnvInherit) All() bool {
+	return nil == e
+}
+
+// Allows is true when the named variable should be inherited
+func (e EnvInherit) Allows(name string) bool {
+	if e.All() {
+		return true
+	}
+	for i := range e {
+		if name == e[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON writes "inherit", "clean", or the allowlist
+func (e EnvInherit) MarshalJSON() ([]byte, error) {
+	switch {
+	case e.All():
+		return json.Marshal(EnvInheritAll)
+	case 0 == len(e):
+		return json.Marshal(EnvInheritClean)
+	}
+	return json.Marshal([]string(e))
+}
+
+// UnmarshalJSON reads "inherit", "clean", or an allowlist
+func (e *EnvInherit) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); nil == err {
+		switch s {
+		case "", EnvInheritAll:
+			// (as is null)
+			*e = nil
+		case EnvInheritClean:
+			*e = EnvInherit{}
+		default:
+			return fmt.Errorf("env_inherit should be %q, %q, or a list of names, not %q", EnvInheritAll, EnvInheritClean, s)
+		}
+		return nil
+	}
+
+	allow := []string{}
+	if err := json.Unmarshal(b, &allow); nil != err {
+		return fmt.Errorf("env_inherit should be %q, %q, or a list of names", EnvInheritAll, EnvInheritClean)
+	}
+	*e = EnvInherit(allow)
+	return nil
+}
+
+// EnvFile splits a path from env_files into the path itself and whether it is optional.
+// As with systemd's EnvironmentFile, a leading '-' means that a missing file is ignored.
+func EnvFile(path string) (string, bool) {
+	if strings.HasPrefix(path, "-") {
+		return path[1:], true
+	}
+	return path, false
+}
+
+// ReadEnvFile reads the variables from a dotenv file
+func ReadEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if nil != err {
+		return nil, err
+	}
+	defer f.Close()
+
+	envs, err := ParseEnvFile(f)
+	if nil != err {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	return envs, nil
+}
+
+// ParseEnvFile parses dotenv-style KEY=value lines, which may start with "export ".
+// Blank lines and lines starting with '#' are skipped.
+// Single-quoted values are taken literally, double-quoted values understand
+// \n, \t, \", \$, \` and \\, and unquoted values end at " #".
+func ParseEnvFile(r io.Reader) (map[string]string, error) {
+	envs := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := strings.TrimSpace(scanner.Text())
+		if "" == line || '#' == line[0] {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		kv := strings.SplitN(line, "=", 2)
+		key := strings.TrimSpace(kv[0])
+		if 2 != len(kv) || "" == key || strings.ContainsAny(key, " \t\"'") {
+			return nil, fmt.Errorf("line %d is not KEY=value", n)
+		}
+
+		value, err := parseEnvValue(strings.TrimSpace(kv[1]))
+		if nil != err {
+			return nil, fmt.Errorf("line %d: %s", n, err)
+		}
+		envs[key] = value
+	}
+
+	return envs, scanner.Err()
+}
+
+func parseEnvValue(v string) (string, error) {
+	if "" == v {
+		return "", nil
+	}
+
+	switch v[0] {
+	case '\'':
+		end := strings.IndexByte(v[1:], '\'')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated single quote")
+		}
+		return v[1 : 1+end], nil
+	case '"':
+		var b strings.Builder
+		for i := 1; i < len(v); i++ {
+			c := v[i]
+			if '"' == c {
+				return b.String(), nil
+			}
+			if '\\' == c && i+1 < len(v) {
+				i++
+				switch v[i] {
+				case 'n':
+					c = '\n'
+				case 't':
+					c = '\t'
+				case '"', '\\', '$', '`':
+					c = v[i]
+				default:
+					b.WriteByte('\\')
+					c = v[i]
+				}
+			}
+			b.WriteByte(c)
+		}
+		return "", fmt.Errorf("unterminated double quote")
+	}
+
+	if i := strings.Index(v, " #"); i >= 0 {
+		v = strings.TrimSpace(v[:i])
+	}
+	return v, nil
+}
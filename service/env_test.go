@@ -0,0 +1,103 @@
+package service
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEnvInheritRoundTrip(t *testing.T) {
+	tests := []struct {
+		policy EnvInherit
+		json   string
+	}{
+		{nil, `"inherit"`},
+		{EnvInherit{"PATH", "HOME"}, `["PATH","HOME"]`},
+		{EnvInherit{}, `"clean"`},
+	}
+	for _, tt := range tests {
+		b, err := json.Marshal(&Service{Name: "foo", EnvInherit: tt.policy})
+		if nil != err {
+			t.Fatal(err)
+		}
+		conf := &Service{}
+		if err := json.Unmarshal(b, conf); nil != err {
+			t.Fatal(err)
+		}
+		if tt.policy.All() != conf.EnvInherit.All() || !reflect.DeepEqual(tt.policy, conf.EnvInherit) {
+			t.Errorf("expected %#v to survive a round trip, not %#v (%s)", tt.policy, conf.EnvInherit, b)
+		}
+
+		b, _ = json.Marshal(tt.policy)
+		if tt.json != string(b) {
+			t.Errorf("expected %#v to be written as %s, not %s", tt.policy, tt.json, b)
+		}
+	}
+
+	// a config without it inherits everything
+	conf := &Service{}
+	if err := json.Unmarshal([]byte(`{"name":"foo"}`), conf); nil != err {
+		t.Fatal(err)
+	}
+	if !conf.EnvInherit.All() {
+		t.Errorf("expected a missing env_inherit to inherit everything")
+	}
+	if err := json.Unmarshal([]byte(`{"env_inherit":"some"}`), conf); nil == err {
+		t.Errorf("expected an unknown policy to be an error")
+	}
+}
+
+func TestParseEnvFile(t *testing.T) {
+	envs, err := ParseEnvFile(strings.NewReader(`# a comment
+export A=plain value # a trailing comment
+
+B='single $HOME "x" \n'
+C="dq \"quoted\" \$HOME \` + "`tick`" + ` back\\slash \n \t \q"
+  D = spaced  
+E=
+F=has'apostrophe
+G="it's"
+H=a#b
+`))
+	if nil != err {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"A": "plain value",
+		"B": `single $HOME "x" \n`,
+		"C": "dq \"quoted\" $HOME `tick` back\\slash \n \t \\q",
+		"D": "spaced",
+		"E": "",
+		"F": "has'apostrophe",
+		"G": "it's",
+		"H": "a#b",
+	}
+	if !reflect.DeepEqual(expected, envs) {
+		t.Errorf("expected %q, not %q", expected, envs)
+	}
+
+	bads := []string{
+		"just words",
+		"=value",
+		"A B=value",
+		"A='not closed",
+		`A="not closed`,
+	}
+	for _, bad := range bads {
+		if _, err := ParseEnvFile(strings.NewReader("OK=1\n" + bad + "\n")); nil == err {
+			t.Errorf("expected %q not to parse", bad)
+		} else if !strings.Contains(err.Error(), "line 2") {
+			t.Errorf("expected the error for %q to be on line 2, not %q", bad, err)
+		}
+	}
+}
+
+func TestEnvFile(t *testing.T) {
+	if path, optional := EnvFile("/etc/foo/.env"); "/etc/foo/.env" != path || optional {
+		t.Errorf("expected /etc/foo/.env to be required")
+	}
+	if path, optional := EnvFile("-/etc/foo/.env"); "/etc/foo/.env" != path || !optional {
+		t.Errorf("expected -/etc/foo/.env to be optional")
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"strings"
+)
+
+// Hooks are commands that run around the service's main process.
+//
+// 	Hooks: Hooks{
+// 		// Run in order before each start (ExecStartPre)
+// 		PreStart: []Hook{
+// 			Hook{Exec: "/opt/foobar-app/migrate", Argv: []string{"up"}},
+// 		},
+// 		// Run in order after each start (ExecStartPost)
+// 		PostStart: []Hook{},
+// 		// Run in order before the service is asked to stop (ExecStop)
+// 		PreStop: []Hook{},
+// 		// Run in order after the service has stopped (ExecStopPost)
+// 		PostStop: []Hook{
+// 			Hook{Exec: "/opt/foobar-app/flush-cache", IgnoreFailure: true},
+// 		},
+// 	}
+type Hooks struct {
+	PreStart  []Hook `json:"pre_start,omitempty"`
+	PostStart []Hook `json:"post_start,omitempty"`
+	PreStop   []Hook `json:"pre_stop,omitempty"`
+	PostStop  []Hook `json:"post_stop,omitempty"`
+}
+
+// Empty is true when there are no hooks at all
+func (h Hooks) Empty() bool {
+	return 0 == len(h.PreStart) && 0 == len(h.PostStart) &&
+		0 == len(h.PreStop) && 0 == len(h.PostStop)
+}
+
+// Hook is a single command to run at some point in the service's lifecycle.
+// By default a failing hook fails the lifecycle step it belongs to
+// (i.e. a failed pre-start hook means the service isn't started),
+// unless IgnoreFailure is set.
+type Hook struct {
+	Exec          string   `json:"exec"`
+	Argv          []string `json:"argv,omitempty"`
+	IgnoreFailure bool     `json:"ignore_failure,omitempty"`
+}
+
+// ParseHook turns a command line such as "-/usr/bin/foo --bar 'a b'"
+// into a Hook. As with systemd, a leading '-' means that failure is ignored.
+// Arguments are split on whitespace, except within single or double quotes
+// (within double quotes, a backslash escapes a '"' or a '\'),
+// and a quote that isn't closed runs to the end of the line.
+func ParseHook(s string) Hook {
+	h := Hook{}
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "-") {
+		h.IgnoreFailure = true
+		s = s[1:]
+	}
+	args := splitArgs(s)
+	if 0 == len(args) {
+		return h
+	}
+	h.Exec = args[0]
+	h.Argv = args[1:]
+	return h
+}
+
+// splitArgs splits a command line as a shell would (but without any expansion)
+func splitArgs(s string) []string {
+	args := []string{}
+	var arg strings.Builder
+	inArg := false
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 0 != quote:
+			if quote == c {
+				quote = 0
+				continue
+			}
+			if '"' == quote && '\\' == c && i+1 < len(s) && ('"' == s[i+1] || '\\' == s[i+1]) {
+				i++
+				c = s[i]
+			}
+		case '\'' == c || '"' == c:
+			quote = c
+			inArg = true
+			continue
+		case ' ' == c || '\t' == c:
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+			continue
+		}
+		arg.WriteByte(c)
+		inArg = true
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args
+}
+
+// String is the command line as systemd would want it, prefixed with '-' if failure is ignored
+// (with any argument that has whitespace, or quotes, in double quotes)
+func (h Hook) String() string {
+	args := []string{}
+	for _, arg := range append([]string{h.Exec}, h.Argv...) {
+		if "" == arg || strings.ContainsAny(arg, " \t\"'\\") {
+			arg = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+		}
+		args = append(args, arg)
+	}
+	s := strings.Join(args, " ")
+	if h.IgnoreFailure {
+		s = "-" + s
+	}
+	return s
+}
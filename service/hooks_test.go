@@ -0,0 +1,43 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseHook(t *testing.T) {
+	tests := []struct {
+		s    string
+		hook Hook
+	}{
+		{"/usr/bin/foo --bar", Hook{Exec: "/usr/bin/foo", Argv: []string{"--bar"}}},
+		{"  -/usr/bin/foo   --bar  baz ", Hook{Exec: "/usr/bin/foo", Argv: []string{"--bar", "baz"}, IgnoreFailure: true}},
+		{"/usr/bin/foo", Hook{Exec: "/usr/bin/foo", Argv: []string{}}},
+		{"/usr/bin/foo --msg 'hello world' \"it's \\\"here\\\"\"", Hook{Exec: "/usr/bin/foo", Argv: []string{"--msg", "hello world", `it's "here"`}}},
+		{"/usr/bin/foo --name=\"a b\"c ''", Hook{Exec: "/usr/bin/foo", Argv: []string{"--name=a bc", ""}}},
+		{"/usr/bin/foo 'not closed", Hook{Exec: "/usr/bin/foo", Argv: []string{"not closed"}}},
+		{"-", Hook{IgnoreFailure: true}},
+		{"", Hook{}},
+	}
+	for _, tt := range tests {
+		hook := ParseHook(tt.s)
+		if !reflect.DeepEqual(tt.hook, hook) {
+			t.Errorf("expected %q to be %#v, not %#v", tt.s, tt.hook, hook)
+		}
+	}
+}
+
+func TestHookString(t *testing.T) {
+	hooks := []Hook{
+		{Exec: "/usr/bin/foo", Argv: []string{"--bar"}},
+		{Exec: "/usr/bin/foo", Argv: []string{"hello world", `it's "here"`, `back\slash`}, IgnoreFailure: true},
+	}
+	for _, hook := range hooks {
+		if parsed := ParseHook(hook.String()); !reflect.DeepEqual(hook, parsed) {
+			t.Errorf("expected %q to parse back to %#v, not %#v", hook.String(), hook, parsed)
+		}
+	}
+	if s := hooks[1].String(); `-/usr/bin/foo "hello world" "it's \"here\"" "back\\slash"` != s {
+		t.Errorf("expected the arguments to be quoted for systemd, not %s", s)
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Instances run the same service more than once, either a number of times
+// (named 1, 2, 3...) or once for each name. Each instance can have its own
+// environment variables, which are templates:
+//
+// 	Instances: Instances{
+// 		Count: 4,
+// 		Envs: map[string]string{
+// 			"PORT": "{{ .Instance | add 8000 }}",
+// 		},
+// 	}
+//
+// The template has .Instance, .Name, and an add function.
+type Instances struct {
+	Count int               `json:"count,omitempty"`
+	Names []string          `json:"names,omitempty"`
+	Envs  map[string]string `json:"envs,omitempty"`
+}
+
+// Multi is true when the service runs as instances
+func (s *Service) Multi() bool {
+	return s.Instances.Count > 0 || len(s.Instances.Names) > 0
+}
+
+// InstanceNames are the names of each instance, if any
+func (s *Service) InstanceNames() []string {
+	if len(s.Instances.Names) > 0 {
+		return s.Instances.Names
+	}
+	names := []string{}
+	for i := 1; i <= s.Instances.Count; i++ {
+		names = append(names, strconv.Itoa(i))
+	}
+	return names
+}
+
+// an instance's name ends up in unit, plist, log, and cgroup names
+var instanceName = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// ValidateInstance checks that the instance's name is only letters, numbers, _, ., and -
+func ValidateInstance(instance string) error {
+	if !instanceName.MatchString(instance) {
+		return fmt.Errorf("instance %q must be only letters, numbers, _, ., and -", instance)
+	}
+	return nil
+}
+
+// ValidateInstances checks the name of each instance, as a config file may have anything
+func (s *Service) ValidateInstances() error {
+	for _, instance := range s.InstanceNames() {
+		if err := ValidateInstance(instance); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+// InstanceName is the name of the service, or of the instance (i.e. foo@1)
+func (s *Service) InstanceName() string {
+	if "" == s.Instance {
+		return s.Name
+	}
+	return s.Name + "@" + s.Instance
+}
+
+// ParseInstance splits foo@1 into foo and 1
+func ParseInstance(name string) (string, string) {
+	parts := strings.SplitN(name, "@", 2)
+	if 2 != len(parts) {
+		return name, ""
+	}
+	return parts[0], parts[1]
+}
+
+// ForInstance is a copy of the service for one of its instances,
+// with its own ReverseDNS (i.e. com.example.foo@1) and its environment filled in
+func (s *Service) ForInstance(instance string) (*Service, error) {
+	if err := ValidateInstance(instance); nil != err {
+		return nil, err
+	}
+	c := *s
+	c.Instance = instance
+	c.Instances = Instances{}
+	c.ReverseDNS = s.ReverseDNS + "@" + instance
+
+	c.Envs = map[string]string{}
+	for k, v := range s.Envs {
+		c.Envs[k] = v
+	}
+	for k, v := range s.Instances.Envs {
+		val, err := s.instanceEnv(instance, k, v)
+		if nil != err {
+			return nil, err
+		}
+		c.Envs[k] = val
+	}
+	return &c, nil
+}
+
+func (s *Service) instanceEnv(instance, key, value string) (string, error) {
+	tmpl, err := template.New(key).Funcs(template.FuncMap{
+		"add": addInstance,
+	}).Parse(value)
+	if nil != err {
+		return "", fmt.Errorf("instance env %s: %s", key, err)
+	}
+
+	b := &bytes.Buffer{}
+	err = tmpl.Execute(b, struct {
+		Name     string
+		Instance string
+	}{s.Name, instance})
+	if nil != err {
+		return "", fmt.Errorf("instance env %s: %s", key, err)
+	}
+	return b.String(), nil
+}
+
+// addInstance adds numbers, which may be strings (such as .Instance)
+func addInstance(nums ...interface{}) (int, error) {
+	sum := 0
+	for _, n := range nums {
+		switch v := n.(type) {
+		case int:
+			sum += v
+		case string:
+			i, err := strconv.Atoi(v)
+			if nil != err {
+				return 0, fmt.Errorf("can't add %q, which isn't a number", v)
+			}
+			sum += i
+		default:
+			return 0, fmt.Errorf("can't add %v", n)
+		}
+	}
+	return sum, nil
+}
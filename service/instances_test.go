@@ -0,0 +1,108 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestForInstance(t *testing.T) {
+	s := &Service{
+		Name:       "web",
+		ReverseDNS: "com.example.web",
+		Envs:       map[string]string{"MODE": "prod"},
+		Instances: Instances{
+			Count: 2,
+			Envs: map[string]string{
+				"PORT": "{{ .Instance | add 8000 }}",
+				"ID":   "{{ .Name }}-{{ .Instance }}",
+			},
+		},
+	}
+	if names := s.InstanceNames(); 2 != len(names) || "1" != names[0] || "2" != names[1] {
+		t.Fatalf("expected instances 1 and 2, not %q", names)
+	}
+
+	c, err := s.ForInstance("2")
+	if nil != err {
+		t.Fatal(err)
+	}
+	if "web@2" != c.InstanceName() || "com.example.web@2" != c.ReverseDNS || c.Multi() {
+		t.Errorf("expected a single instance, web@2, not %#v", c)
+	}
+	if "8002" != c.Envs["PORT"] || "web-2" != c.Envs["ID"] || "prod" != c.Envs["MODE"] {
+		t.Errorf("expected the instance's envs to be filled in, not %v", c.Envs)
+	}
+	if _, ok := s.Envs["PORT"]; ok {
+		t.Errorf("expected the service's own envs to be left alone, not %v", s.Envs)
+	}
+
+	named := &Service{Name: "worker", Instances: Instances{Names: []string{"a", "b"}}}
+	if names := named.InstanceNames(); 2 != len(names) || "a" != names[0] {
+		t.Errorf("expected the named instances, not %q", names)
+	}
+	named.Instances.Envs = map[string]string{"PORT": "{{ .Instance | add 8000 }}"}
+	if _, err := named.ForInstance("a"); nil == err {
+		t.Errorf("expected adding to a name that isn't a number to be an error")
+	}
+	named.Instances.Envs = map[string]string{"PORT": "{{ .Instance"}
+	if _, err := named.ForInstance("a"); nil == err {
+		t.Errorf("expected a bad template to be an error")
+	}
+}
+
+func TestParseInstance(t *testing.T) {
+	if name, instance := ParseInstance("web@2"); "web" != name || "2" != instance {
+		t.Errorf("expected web and 2, not %q and %q", name, instance)
+	}
+	if name, instance := ParseInstance("web"); "web" != name || "" != instance {
+		t.Errorf("expected web and no instance, not %q and %q", name, instance)
+	}
+}
+
+func TestOrderInstances(t *testing.T) {
+	web := &Service{Name: "web", After: []string{"db"}, Instances: Instances{Count: 2}}
+	db := &Service{Name: "db", Instances: Instances{Names: []string{"primary", "replica"}}}
+
+	services := []*Service{}
+	for _, s := range []*Service{web, db} {
+		for _, instance := range s.InstanceNames() {
+			c, err := s.ForInstance(instance)
+			if nil != err {
+				t.Fatal(err)
+			}
+			services = append(services, c)
+		}
+	}
+
+	ordered, err := Order(services)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if "db@primary db@replica web@1 web@2" != names(ordered) {
+		t.Fatalf("expected each of web's instances after all of db's, not %q", names(ordered))
+	}
+}
+
+func TestValidateInstances(t *testing.T) {
+	for _, name := range []string{"1", "a", "eu-west_2.b"} {
+		if err := ValidateInstance(name); nil != err {
+			t.Errorf("expected %q to be a valid instance: %s", name, err)
+		}
+	}
+	for _, name := range []string{"", "a b", "a/b", "a@b", "a:b", "$(id)", "a\nb", "é"} {
+		if err := ValidateInstance(name); nil == err {
+			t.Errorf("expected %q not to be a valid instance", name)
+		}
+	}
+
+	s := &Service{Name: "worker", Instances: Instances{Names: []string{"a", "../b"}}}
+	if err := s.ValidateInstances(); nil == err {
+		t.Errorf("expected ../b not to be a valid instance")
+	}
+	if _, err := s.ForInstance("../b"); nil == err {
+		t.Errorf("expected no instance ../b")
+	}
+	s.Instances = Instances{Count: 3}
+	if err := s.ValidateInstances(); nil != err {
+		t.Errorf("expected numbered instances to be valid: %s", err)
+	}
+}
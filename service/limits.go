@@ -0,0 +1,113 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Infinity is what ParseBytes returns for "infinity"
+const Infinity = ^uint64(0)
+
+// Limits are resource limits for the service (and anything it starts).
+// A zero value means that the limit is left alone.
+//
+// 	Limits: Limits{
+// 		// LimitNOFILE
+// 		OpenFiles: 65536,
+// 		// LimitNPROC
+// 		Processes: 64,
+// 		// MemoryMax, with an optional K, M, G, or T suffix
+// 		MemoryMax: "512M",
+// 		// CPUQuota, as a percentage of one CPU
+// 		CPUQuota: 150,
+// 		// LimitCORE, with an optional K, M, G, or T suffix (or "infinity")
+// 		CoreSize: "0",
+// 		// TasksMax (threads as well as processes)
+// 		Tasks: 512,
+// 	}
+type Limits struct {
+	OpenFiles uint64 `json:"open_files,omitempty"`
+	Processes uint64 `json:"processes,omitempty"`
+	MemoryMax string `json:"memory_max,omitempty"`
+	CPUQuota  int    `json:"cpu_quota,omitempty"`
+	CoreSize  string `json:"core_size,omitempty"`
+	Tasks     uint64 `json:"tasks,omitempty"`
+}
+
+// Empty is true when no limits are set
+func (l Limits) Empty() bool {
+	return Limits{} == l
+}
+
+// MemoryMaxBytes is MemoryMax as a number of bytes
+func (l Limits) MemoryMaxBytes() (uint64, error) {
+	return ParseBytes(l.MemoryMax)
+}
+
+// CoreSizeBytes is CoreSize as a number of bytes
+func (l Limits) CoreSizeBytes() (uint64, error) {
+	return ParseBytes(l.CoreSize)
+}
+
+// Set sets a limit by its JSON name, as given to --limit (i.e. memory_max=512M)
+func (l *Limits) Set(key, value string) error {
+	var err error
+	switch strings.Replace(strings.ToLower(key), "-", "_", -1) {
+	case "open_files", "nofile":
+		l.OpenFiles, err = strconv.ParseUint(value, 10, 64)
+	case "processes", "nproc":
+		l.Processes, err = strconv.ParseUint(value, 10, 64)
+	case "memory_max":
+		_, err = ParseBytes(value)
+		l.MemoryMax = value
+	case "cpu_quota":
+		l.CPUQuota, err = strconv.Atoi(strings.TrimSuffix(value, "%"))
+	case "core_size", "core":
+		_, err = ParseBytes(value)
+		l.CoreSize = value
+	case "tasks", "tasks_max":
+		l.Tasks, err = strconv.ParseUint(value, 10, 64)
+	default:
+		return fmt.Errorf("unknown limit %q", key)
+	}
+	if nil != err {
+		return fmt.Errorf("invalid value for limit %q: %q", key, value)
+	}
+	return nil
+}
+
+// ParseBytes understands sizes in the same way that systemd does,
+// with an optional K, M, G, or T suffix (multiples of 1024), or "infinity".
+// An empty string is 0.
+func ParseBytes(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	if "" == s {
+		return 0, nil
+	}
+	if "infinity" == strings.ToLower(s) {
+		return Infinity, nil
+	}
+
+	size := s
+	mult := uint64(1)
+	switch strings.ToUpper(s[len(s)-1:]) {
+	case "K":
+		mult = 1 << 10
+	case "M":
+		mult = 1 << 20
+	case "G":
+		mult = 1 << 30
+	case "T":
+		mult = 1 << 40
+	}
+	if 1 != mult {
+		s = s[:len(s)-1]
+	}
+
+	n, err := strconv.ParseUint(s, 10, 64)
+	if nil != err {
+		return 0, fmt.Errorf("%q is not a size such as 512, 64K, 512M, or 2G", size)
+	}
+	return n * mult, nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		s string
+		n uint64
+	}{
+		{"", 0},
+		{"0", 0},
+		{"512", 512},
+		{"64K", 64 << 10},
+		{"64k", 64 << 10},
+		{" 512M ", 512 << 20},
+		{"2G", 2 << 30},
+		{"1T", 1 << 40},
+		{"infinity", Infinity},
+		{"Infinity", Infinity},
+	}
+	for _, tt := range tests {
+		n, err := ParseBytes(tt.s)
+		if nil != err {
+			t.Errorf("expected %q to parse: %s", tt.s, err)
+			continue
+		}
+		if tt.n != n {
+			t.Errorf("expected %q to be %d, not %d", tt.s, tt.n, n)
+		}
+	}
+
+	for _, s := range []string{"M", "-1", "1.5G", "512MB", "lots"} {
+		if _, err := ParseBytes(s); nil == err {
+			t.Errorf("expected %q not to parse", s)
+		}
+	}
+}
+
+func TestLimitsSet(t *testing.T) {
+	l := Limits{}
+	sets := [][2]string{
+		{"open_files", "65536"},
+		{"NPROC", "64"},
+		{"memory-max", "512M"},
+		{"cpu_quota", "150%"},
+		{"core", "infinity"},
+		{"tasks_max", "512"},
+	}
+	for _, kv := range sets {
+		if err := l.Set(kv[0], kv[1]); nil != err {
+			t.Fatalf("expected %s=%s to be set: %s", kv[0], kv[1], err)
+		}
+	}
+	expected := Limits{OpenFiles: 65536, Processes: 64, MemoryMax: "512M", CPUQuota: 150, CoreSize: "infinity", Tasks: 512}
+	if expected != l {
+		t.Errorf("expected %#v, not %#v", expected, l)
+	}
+	if l.Empty() || !(Limits{}).Empty() {
+		t.Errorf("expected only the zero value to be empty")
+	}
+
+	bads := [][2]string{
+		{"open_files", "lots"},
+		{"processes", "-1"},
+		{"memory_max", "512MB"},
+		{"cpu_quota", "1.5"},
+		{"core_size", "big"},
+		{"tasks", ""},
+		{"swap", "1G"},
+	}
+	for _, kv := range bads {
+		if err := (&Limits{}).Set(kv[0], kv[1]); nil == err {
+			t.Errorf("expected %s=%s not to be set", kv[0], kv[1])
+		}
+	}
+}
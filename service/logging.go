@@ -0,0 +1,179 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// How the runner frames each line of output
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// DefaultKeep is how many rotated logs are kept, if neither Keep nor KeepDays is set
+const DefaultKeep = 7
+
+// Where the service's output goes
+const (
+	LogJournal = "journal"
+	LogFile    = "file"
+	LogSyslog  = "syslog"
+	LogNone    = "none"
+)
+
+// Logging is where the service's stdout and stderr go.
+// Left empty, each backend does what it always has:
+// systemd logs to the journal, and launchd and the runner log to Logdir.
+//
+// 	Logging: Logging{
+// 		// journal, file, syslog, or none
+// 		Destination: "file",
+// 		// Where stdout goes (default: {{ .Logdir }}/{{ .Name }}.log)
+// 		Stdout: "/var/log/foo-app/access.log",
+// 		// Where stderr goes, if not with stdout
+// 		Stderr: "/var/log/foo-app/error.log",
+// 		// The syslog (and journal) tag (default: the name)
+// 		SyslogIdentifier: "foo-app",
+// 		// Where the runner sends syslog: udp://host:port, unix:///path, or the local syslog
+// 		SyslogAddress: "udp://127.0.0.1:514",
+// 		// Put a timestamp, the stream, and the run on each line (text or json)
+// 		Format: "text",
+// 		// Rotate log files that get bigger than this, or older than this
+// 		MaxSize: "10M",
+// 		MaxAge: "1d",
+// 		// And keep this many old (gzipped) logs, or keep them this many days
+// 		Keep: 7,
+// 		KeepDays: 30,
+// 	}
+type Logging struct {
+	Destination      string `json:"destination,omitempty"`
+	Stdout           string `json:"stdout,omitempty"`
+	Stderr           string `json:"stderr,omitempty"`
+	SyslogIdentifier string `json:"syslog_identifier,omitempty"`
+	SyslogAddress    string `json:"syslog_address,omitempty"`
+	Format           string `json:"format,omitempty"`
+	MaxSize          string `json:"max_size,omitempty"`
+	MaxAge           string `json:"max_age,omitempty"`
+	Keep             int    `json:"keep,omitempty"`
+	KeepDays         int    `json:"keep_days,omitempty"`
+}
+
+// Validate checks the destination, and that the log files are absolute paths
+func (l Logging) Validate() error {
+	switch l.Destination {
+	case "", LogJournal, LogFile, LogSyslog, LogNone:
+	default:
+		return fmt.Errorf("log destination %q should be one of journal, file, syslog, or none", l.Destination)
+	}
+	switch l.Format {
+	case "", LogFormatText, LogFormatJSON:
+	default:
+		return fmt.Errorf("log format %q should be text or json", l.Format)
+	}
+	if ("" != l.Stdout || "" != l.Stderr) && "" != l.Destination && LogFile != l.Destination {
+		return fmt.Errorf("stdout and stderr files need the %q log destination, not %q", LogFile, l.Destination)
+	}
+	if "" != l.SyslogAddress && LogSyslog != l.Destination {
+		return fmt.Errorf("a syslog address needs the %q log destination, not %q", LogSyslog, l.Destination)
+	}
+	for _, path := range []string{l.Stdout, l.Stderr} {
+		if "" != path && !filepath.IsAbs(path) {
+			return fmt.Errorf("log file %q must be an absolute path", path)
+		}
+	}
+	if _, err := l.MaxSizeBytes(); nil != err {
+		return err
+	}
+	if _, err := l.MaxAgeDuration(); nil != err {
+		return err
+	}
+	if l.Keep < 0 || l.KeepDays < 0 {
+		return fmt.Errorf("keep and keep_days can't be negative")
+	}
+	return nil
+}
+
+// Rotates is true when log files should be rotated by size or by age
+func (l Logging) Rotates() bool {
+	return "" != l.MaxSize || "" != l.MaxAge
+}
+
+// MaxSizeBytes is MaxSize as a number of bytes (0 for no limit)
+func (l Logging) MaxSizeBytes() (uint64, error) {
+	n, err := ParseBytes(l.MaxSize)
+	if Infinity == n {
+		return 0, err
+	}
+	return n, err
+}
+
+// MaxAgeDuration is MaxAge as a duration (0 for no limit)
+func (l Logging) MaxAgeDuration() (time.Duration, error) {
+	return ParseAge(l.MaxAge)
+}
+
+// Retention is how many rotated logs to keep, and for how many days
+// (0 for no limit), which is DefaultKeep logs when neither is set
+func (l Logging) Retention() (int, int) {
+	if 0 == l.Keep && 0 == l.KeepDays {
+		return DefaultKeep, 0
+	}
+	return l.Keep, l.KeepDays
+}
+
+// ParseAge parses a duration such as 12h or 90m, or a number of days such as 7d
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if "" == s {
+		return 0, nil
+	}
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if nil != err || days < 0 {
+			return 0, fmt.Errorf("%q is not an age such as 12h or 7d", s)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if nil != err || d < 0 {
+		return 0, fmt.Errorf("%q is not an age such as 12h or 7d", s)
+	}
+	return d, nil
+}
+
+// LogDestination is the destination that was asked for, which is a file
+// if there's a file for stdout or stderr, or empty for the backend's default
+func (s *Service) LogDestination() string {
+	if "" == s.Logging.Destination && ("" != s.Logging.Stdout || "" != s.Logging.Stderr) {
+		return LogFile
+	}
+	return s.Logging.Destination
+}
+
+// StdoutPath is the file for stdout, which is {{ .Logdir }}/{{ .InstanceName }}.log by default
+func (s *Service) StdoutPath() string {
+	if "" != s.Logging.Stdout {
+		return s.Logging.Stdout
+	}
+	return filepath.Join(s.Logdir, s.InstanceName()+".log")
+}
+
+// StderrPath is the file for stderr, which is the same file as stdout by default
+func (s *Service) StderrPath() string {
+	if "" != s.Logging.Stderr {
+		return s.Logging.Stderr
+	}
+	return s.StdoutPath()
+}
+
+// SyslogIdent is what the service's messages are tagged with in syslog and the journal
+func (s *Service) SyslogIdent() string {
+	if "" != s.Logging.SyslogIdentifier {
+		return s.Logging.SyslogIdentifier
+	}
+	return s.Name
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		s   string
+		age time.Duration
+	}{
+		{"", 0},
+		{"12h", 12 * time.Hour},
+		{" 90m ", 90 * time.Minute},
+		{"1h30m", 90 * time.Minute},
+		{"7d", 7 * 24 * time.Hour},
+		{"0d", 0},
+	}
+	for _, tt := range tests {
+		age, err := ParseAge(tt.s)
+		if nil != err {
+			t.Errorf("expected %q to parse: %s", tt.s, err)
+			continue
+		}
+		if tt.age != age {
+			t.Errorf("expected %q to be %s, not %s", tt.s, tt.age, age)
+		}
+	}
+
+	for _, s := range []string{"7", "d", "1.5d", "-1d", "-12h", "a week"} {
+		if _, err := ParseAge(s); nil == err {
+			t.Errorf("expected %q not to parse", s)
+		}
+	}
+}
+
+func TestRetention(t *testing.T) {
+	tests := []struct {
+		logging Logging
+		keep    int
+		days    int
+	}{
+		{Logging{}, DefaultKeep, 0},
+		{Logging{Keep: 3}, 3, 0},
+		{Logging{KeepDays: 30}, 0, 30},
+		{Logging{Keep: 3, KeepDays: 30}, 3, 30},
+	}
+	for _, tt := range tests {
+		keep, days := tt.logging.Retention()
+		if tt.keep != keep || tt.days != days {
+			t.Errorf("expected %#v to keep %d logs for %d days, not %d for %d", tt.logging, tt.keep, tt.days, keep, days)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// MetricsListener is the network and address that the runner serves the service's
+// metrics on, from Metrics: a loopback address (i.e. 127.0.0.1:9100 or localhost:9100),
+// or a unix socket (i.e. unix:///run/foo-app/metrics.sock).
+// Only the runner serves metrics, so a service run by systemd or launchd has none.
+func (s *Service) MetricsListener() (string, string, error) {
+	if strings.HasPrefix(s.Metrics, "unix://") {
+		path := strings.TrimPrefix(s.Metrics, "unix://")
+		if !strings.HasPrefix(path, "/") {
+			return "", "", fmt.Errorf("the metrics socket %q should be an absolute path", path)
+		}
+		return "unix", path, nil
+	}
+
+	host, _, err := net.SplitHostPort(s.Metrics)
+	if nil != err {
+		return "", "", fmt.Errorf("the metrics address %q should be host:port, or unix:///path: %s", s.Metrics, err)
+	}
+	// metrics aren't for the whole network to see
+	if ip := net.ParseIP(host); "localhost" != host && (nil == ip || !ip.IsLoopback()) {
+		return "", "", fmt.Errorf("the metrics address %q should be a loopback address, such as 127.0.0.1:9100", s.Metrics)
+	}
+	return "tcp", s.Metrics, nil
+}
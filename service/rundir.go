@@ -0,0 +1,38 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// defaultRundir is where the runner keeps its control socket:
+// /run/serviceman for system services, or $XDG_RUNTIME_DIR/serviceman
+// (or ~/.local/share/{{ .Name }}/var/run) for user services
+func (s *Service) defaultRundir() string {
+	if s.System {
+		if "linux" == runtime.GOOS {
+			return "/run/serviceman"
+		}
+		return "/var/run/serviceman"
+	}
+	if xdg := os.Getenv("XDG_RUNTIME_DIR"); "" != xdg && "windows" != runtime.GOOS {
+		return filepath.Join(xdg, "serviceman")
+	}
+	return filepath.Join(s.Home, ".local", "share", s.Name, "var", "run")
+}
+
+// ControlSocket is where the runner listens for status, stop, restart, reload,
+// and signal requests: a unix socket in Rundir, or a named pipe on Windows
+// (named for the user, as each user's services have the same names)
+func (s *Service) ControlSocket() string {
+	if "windows" == runtime.GOOS {
+		owner := strings.Replace(os.Getenv("USERNAME"), `\`, ".", -1)
+		if s.System || "" == owner {
+			owner = "system"
+		}
+		return `\\.\pipe\serviceman.` + owner + "." + s.Name
+	}
+	return filepath.Join(s.Rundir, s.Name+".sock")
+}
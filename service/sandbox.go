@@ -0,0 +1,159 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Sandbox profiles, from least to most restrictive
+const (
+	SandboxNone   = "none"
+	SandboxBasic  = "basic"
+	SandboxStrict = "strict"
+)
+
+// Sandbox restricts what the service can see and do (systemd only).
+// A profile sets reasonable defaults, and any other field overrides them.
+//
+// 	Sandbox: Sandbox{
+// 		// none, basic, or strict
+// 		Profile: "strict",
+// 		// Where the service may still write, in addition to /opt/<name> and its logs
+// 		ReadWritePaths: []string{"/srv/foobar-app"},
+// 		// Turn off something that the profile would turn on
+// 		PrivateDevices: &no,
+// 	}
+type Sandbox struct {
+	Profile string `json:"profile,omitempty"`
+
+	// ProtectSystem is one of true, full, or strict.
+	ProtectSystem string `json:"protect_system,omitempty"`
+	// ProtectHome is one of true, read-only, or tmpfs.
+	ProtectHome    string   `json:"protect_home,omitempty"`
+	ReadWritePaths []string `json:"read_write_paths,omitempty"`
+	ReadOnlyPaths  []string `json:"read_only_paths,omitempty"`
+
+	PrivateTmp            *bool `json:"private_tmp,omitempty"`
+	PrivateDevices        *bool `json:"private_devices,omitempty"`
+	NoNewPrivileges       *bool `json:"no_new_privileges,omitempty"`
+	DynamicUser           *bool `json:"dynamic_user,omitempty"`
+	ProtectKernelTunables *bool `json:"protect_kernel_tunables,omitempty"`
+	ProtectKernelModules  *bool `json:"protect_kernel_modules,omitempty"`
+	ProtectControlGroups  *bool `json:"protect_control_groups,omitempty"`
+	RestrictNamespaces    *bool `json:"restrict_namespaces,omitempty"`
+	RestrictRealtime      *bool `json:"restrict_realtime,omitempty"`
+	RestrictSUIDSGID      *bool `json:"restrict_suid_sgid,omitempty"`
+	LockPersonality       *bool `json:"lock_personality,omitempty"`
+
+	// RestrictAddressFamilies is a list such as AF_UNIX AF_INET AF_INET6.
+	RestrictAddressFamilies []string `json:"restrict_address_families,omitempty"`
+	// SystemCallFilter is a list such as @system-service.
+	SystemCallFilter []string `json:"system_call_filter,omitempty"`
+	IPAddressAllow   []string `json:"ip_address_allow,omitempty"`
+	IPAddressDeny    []string `json:"ip_address_deny,omitempty"`
+}
+
+// Empty is true when the sandbox wouldn't restrict anything at all
+func (sb Sandbox) Empty() bool {
+	sb.Profile = ""
+	return reflect.DeepEqual(Sandbox{}, sb)
+}
+
+// ValidateProfile returns an error for any profile other than none, basic, or strict
+func (sb Sandbox) ValidateProfile() error {
+	switch sb.Profile {
+	case "", SandboxNone, SandboxBasic, SandboxStrict:
+		return nil
+	default:
+		return fmt.Errorf("unknown sandbox profile %q (expected none, basic, or strict)", sb.Profile)
+	}
+}
+
+// Sandboxing is the sandbox with its profile filled in and its overrides applied.
+// MultiuserProtection, which predates profiles, means the basic profile.
+func (s *Service) Sandboxing() Sandbox {
+	profile := s.Sandbox.Profile
+	if "" == profile && s.MultiuserProtection {
+		profile = SandboxBasic
+	}
+
+	yes := true
+	sb := Sandbox{Profile: profile}
+	switch profile {
+	case SandboxBasic, SandboxStrict:
+		// Use private /tmp and /var/tmp, which are discarded after the service stops.
+		sb.PrivateTmp = &yes
+		// Use a minimal /dev
+		sb.PrivateDevices = &yes
+		// Hide /home, /root, and /run/user. Nobody will steal your SSH-keys.
+		sb.ProtectHome = "true"
+		// Make /usr, /boot, /etc and possibly some more folders read-only.
+		sb.ProtectSystem = "full"
+		// ... except /opt/<name> because we want a place for the database
+		// and the log directory because we want a place where logs can go.
+		sb.ReadWritePaths = []string{"/opt/" + s.Name, s.Logdir}
+		sb.NoNewPrivileges = &yes
+	}
+	if SandboxStrict == profile {
+		// Make the entire file system read-only, except for ReadWritePaths
+		sb.ProtectSystem = "strict"
+		sb.ProtectKernelTunables = &yes
+		sb.ProtectKernelModules = &yes
+		sb.ProtectControlGroups = &yes
+		sb.RestrictNamespaces = &yes
+		sb.RestrictRealtime = &yes
+		sb.RestrictSUIDSGID = &yes
+		sb.LockPersonality = &yes
+		sb.RestrictAddressFamilies = []string{"AF_UNIX", "AF_INET", "AF_INET6"}
+		sb.SystemCallFilter = []string{"@system-service"}
+	}
+	if len(s.Caps()) > 0 {
+		// the capabilities it's given are all the service gets
+		sb.NoNewPrivileges = &yes
+	}
+
+	o := s.Sandbox
+	if "" != o.ProtectSystem {
+		sb.ProtectSystem = o.ProtectSystem
+	}
+	if "" != o.ProtectHome {
+		sb.ProtectHome = o.ProtectHome
+	}
+	// paths add to the profile, rather than replace it
+	sb.ReadWritePaths = append(sb.ReadWritePaths, o.ReadWritePaths...)
+	sb.ReadOnlyPaths = append(sb.ReadOnlyPaths, o.ReadOnlyPaths...)
+	for _, b := range []struct {
+		dst **bool
+		src *bool
+	}{
+		{&sb.PrivateTmp, o.PrivateTmp},
+		{&sb.PrivateDevices, o.PrivateDevices},
+		{&sb.NoNewPrivileges, o.NoNewPrivileges},
+		{&sb.DynamicUser, o.DynamicUser},
+		{&sb.ProtectKernelTunables, o.ProtectKernelTunables},
+		{&sb.ProtectKernelModules, o.ProtectKernelModules},
+		{&sb.ProtectControlGroups, o.ProtectControlGroups},
+		{&sb.RestrictNamespaces, o.RestrictNamespaces},
+		{&sb.RestrictRealtime, o.RestrictRealtime},
+		{&sb.RestrictSUIDSGID, o.RestrictSUIDSGID},
+		{&sb.LockPersonality, o.LockPersonality},
+	} {
+		if nil != b.src {
+			*b.dst = b.src
+		}
+	}
+	if nil != o.RestrictAddressFamilies {
+		sb.RestrictAddressFamilies = o.RestrictAddressFamilies
+	}
+	if nil != o.SystemCallFilter {
+		sb.SystemCallFilter = o.SystemCallFilter
+	}
+	if nil != o.IPAddressAllow {
+		sb.IPAddressAllow = o.IPAddressAllow
+	}
+	if nil != o.IPAddressDeny {
+		sb.IPAddressDeny = o.IPAddressDeny
+	}
+
+	return sb
+}
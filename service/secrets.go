@@ -0,0 +1,96 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// SecretMask is what a secret value looks like in rendered or logged output
+const SecretMask = "********"
+
+// Secret is a credential that the service needs, which is kept out of the
+// (world-readable) service file. It comes either from a file, or from a value.
+//
+// 	Secrets: []Secret{
+// 		Secret{Name: "DB_PASSWORD", File: "/etc/foobar-app/db-password"},
+// 		Secret{Name: "API_TOKEN", Value: "xxxx"},
+// 	}
+//
+// With systemd these are credentials, read from files in $CREDENTIALS_DIRECTORY.
+// Otherwise they are environment variables, with values kept in a 0600 env file
+// (see SecretsPath) and files read each time the service starts.
+type Secret struct {
+	Name  string `json:"name"`
+	File  string `json:"file,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// ParseSecret turns NAME=/path/to/file (or, with value, NAME=value) into a Secret
+func ParseSecret(s string, value bool) (Secret, error) {
+	kv := strings.SplitN(s, "=", 2)
+	if 2 != len(kv) || "" == kv[0] || "" == kv[1] {
+		if value {
+			return Secret{}, fmt.Errorf("a secret value must look like NAME=value")
+		}
+		return Secret{}, fmt.Errorf("a secret must look like NAME=/path/to/file, not %q", s)
+	}
+	if strings.ContainsAny(kv[0], " \t=:/") {
+		return Secret{}, fmt.Errorf("%q is not a valid secret name", kv[0])
+	}
+
+	if !value {
+		return Secret{Name: kv[0], File: kv[1]}, nil
+	}
+	if strings.ContainsAny(kv[1], "\r\n") {
+		return Secret{}, fmt.Errorf("the value for secret %q has more than one line (use a file instead)", kv[0])
+	}
+	return Secret{Name: kv[0], Value: kv[1]}, nil
+}
+
+// HasSecretValues is true when any secret is a value (rather than a file)
+func (s *Service) HasSecretValues() bool {
+	for i := range s.Secrets {
+		if "" != s.Secrets[i].Value {
+			return true
+		}
+	}
+	return false
+}
+
+// SecretsPath is the 0600 env file that holds secret values, where there's no systemd
+func (s *Service) SecretsPath() string {
+	dir := "/opt/serviceman/etc"
+	if !s.System {
+		dir = filepath.Join(s.Home, ".local/opt/serviceman/etc")
+	}
+	return filepath.Join(dir, s.Name+".secrets.env")
+}
+
+// Mask replaces any secret values in str
+func (s *Service) Mask(str string) string {
+	for i := range s.Secrets {
+		v := s.Secrets[i].Value
+		if "" != v {
+			str = strings.Replace(str, v, SecretMask, -1)
+		}
+	}
+	return str
+}
+
+// Masked is a copy of the service with its secret values masked,
+// which is safe to render for display
+func (s *Service) Masked() *Service {
+	c := *s
+	c.Secrets = make([]Secret, len(s.Secrets))
+	for i := range s.Secrets {
+		c.Secrets[i] = s.Secrets[i]
+		if "" != c.Secrets[i].Value {
+			c.Secrets[i].Value = SecretMask
+		}
+	}
+	if nil == s.Secrets {
+		c.Secrets = nil
+	}
+	return &c
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestParseSecret(t *testing.T) {
+	tests := []struct {
+		s      string
+		value  bool
+		secret Secret
+		bad    bool
+	}{
+		{"DB_PASSWORD=/etc/foo/db-password", false, Secret{Name: "DB_PASSWORD", File: "/etc/foo/db-password"}, false},
+		{"API_TOKEN=xx=yy", true, Secret{Name: "API_TOKEN", Value: "xx=yy"}, false},
+		{"API_TOKEN", true, Secret{}, true},
+		{"=/etc/foo", false, Secret{}, true},
+		{"API_TOKEN=", true, Secret{}, true},
+		{"API TOKEN=xx", true, Secret{}, true},
+		{"a/b=xx", true, Secret{}, true},
+		{"API_TOKEN=xx\nyy", true, Secret{}, true},
+	}
+	for _, tt := range tests {
+		secret, err := ParseSecret(tt.s, tt.value)
+		if tt.bad {
+			if nil == err {
+				t.Errorf("expected %q to be an error", tt.s)
+			}
+			continue
+		}
+		if nil != err {
+			t.Errorf("%q: %s", tt.s, err)
+			continue
+		}
+		if tt.secret != secret {
+			t.Errorf("expected %q to be %#v, not %#v", tt.s, tt.secret, secret)
+		}
+	}
+}
+
+func TestMasked(t *testing.T) {
+	s := &Service{
+		Name: "foo",
+		Secrets: []Secret{
+			{Name: "DB_PASSWORD", File: "/etc/foo/db-password"},
+			{Name: "API_TOKEN", Value: "hunter2"},
+		},
+	}
+	if !s.HasSecretValues() {
+		t.Errorf("expected API_TOKEN to be a secret value")
+	}
+
+	masked := s.Masked()
+	if SecretMask != masked.Secrets[1].Value || "/etc/foo/db-password" != masked.Secrets[0].File {
+		t.Errorf("expected only the value to be masked, not %#v", masked.Secrets)
+	}
+	if "hunter2" != s.Secrets[1].Value {
+		t.Errorf("expected the service's own secrets to be left alone, not %#v", s.Secrets)
+	}
+	if "--token ********" != s.Mask("--token hunter2") {
+		t.Errorf("expected the value to be masked, not %q", s.Mask("--token hunter2"))
+	}
+
+	if nil != (&Service{Name: "bar"}).Masked().Secrets {
+		t.Errorf("expected no secrets to stay nil")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -32,16 +33,62 @@ import (
 // 			PORT: "8080",
 // 			ENV: "development",
 // 		},
+// 		// dotenv files to load before Envs (a leading '-' means it may be missing)
+// 		EnvFiles: []string{"/opt/foobar-app/.env"},
+// 		// "inherit" (the default), "clean", or a list of names to inherit
+// 		EnvInherit: EnvInherit{"PATH", "HOME"},
+// 		// Credentials, which are kept out of the service file
+// 		Secrets: []Secret{Secret{Name: "DB_PASSWORD", File: "/etc/foobar-app/db-password"}},
 // 		// The user (Linux & Mac only).
 // 		// This does not apply to userspace services.
 // 		// There may be special considerations
 // 		User: "www-data",
 // 		// If different from User
 // 		Group: "",
+// 		// Directories that are created for (and owned by) the service,
+// 		// i.e. /var/lib/foobar-app, as StateDirectory= and friends are for systemd
+// 		StateDirectory: "foobar-app",
+// 		// Where stdout and stderr go (journal, file, syslog, or none)
+// 		Logging: Logging{Destination: "file"},
 // 		// Whether to install as a system or user service
 // 		System: false,
+// 		// Run more than once, with a PORT for each (see Instances)
+// 		Instances: Instances{Count: 4, Envs: map[string]string{"PORT": "{{ .Instance | add 8000 }}"}},
+// 		// boot, login, or manual (and, for systemd, the targets that want it)
+// 		StartMode: "boot",
+// 		WantedBy: []string{"multi-user.target"},
+// 		// Other services (or systemd units) to start first, and whether they're required
+// 		After: []string{"postgresql"},
+// 		Requires: []string{"postgresql"},
+// 		Wants: []string{"redis"},
+// 		// Resource limits, such as open files and memory
+// 		Limits: Limits{
+// 			OpenFiles: 65536,
+// 			MemoryMax: "512M",
+// 		},
+// 		// What the service may see and do (none, basic, or strict, and overrides)
+// 		Sandbox: Sandbox{
+// 			Profile: "basic",
+// 		},
 // 		// Whether or not the service may need privileged ports
 // 		PrivilegedPorts: false,
+// 		// Any other Linux capabilities that the service needs
+// 		Capabilities: []string{"CAP_LEASE"},
+// 		// The signal that asks the service to stop, and how many seconds
+// 		// it has to exit before it is killed
+// 		StopSignal: "SIGTERM",
+// 		StopTimeout: 10,
+// 		// The service says when it's ready with sd_notify (Type=notify),
+// 		// and is restarted if it doesn't ping the watchdog every so many seconds
+// 		Notify: true,
+// 		Watchdog: 30,
+// 		// Where the runner serves metrics (in Prometheus' format) about the service:
+// 		// a loopback address, or unix:///path/to/metrics.sock
+// 		Metrics: "127.0.0.1:9100",
+// 		// Commands to run before and after start and stop
+// 		Hooks: Hooks{
+// 			PreStart: []Hook{Hook{Exec: "/opt/foobar-app/migrate"}},
+// 		},
 // 	}
 //
 // Note that some fields are exported for templating,
@@ -59,16 +106,41 @@ type Service struct {
 	Argv                []string          `json:"argv,omitempty"`
 	Workdir             string            `json:"workdir,omitempty"`
 	Envs                map[string]string `json:"envs,omitempty"`
+	EnvFiles            []string          `json:"env_files,omitempty"`
+	EnvInherit          EnvInherit        `json:"env_inherit"`
+	Secrets             []Secret          `json:"secrets,omitempty"`
 	User                string            `json:"user,omitempty"`
 	Group               string            `json:"group,omitempty"`
 	Home                string            `json:"-"`
 	Local               string            `json:"-"`
 	Logdir              string            `json:"logdir"`
+	Rundir              string            `json:"-"`
+	StateDirectory      string            `json:"state_directory,omitempty"`   // i.e. foo, for /var/lib/foo
+	CacheDirectory      string            `json:"cache_directory,omitempty"`   // i.e. foo, for /var/cache/foo
+	RuntimeDirectory    string            `json:"runtime_directory,omitempty"` // i.e. foo, for /run/foo
+	LogsDirectory       string            `json:"logs_directory,omitempty"`    // i.e. foo, for /var/log/foo
+	Logging             Logging           `json:"logging,omitempty"`
 	System              bool              `json:"system"`
 	Restart             bool              `json:"restart"`
 	Production          bool              `json:"production,omitempty"`
 	PrivilegedPorts     bool              `json:"privileged_ports,omitempty"`
 	MultiuserProtection bool              `json:"multiuser_protection,omitempty"`
+	Hooks               Hooks             `json:"hooks,omitempty"`
+	StopSignal          string            `json:"stop_signal,omitempty"`  // i.e. SIGTERM, SIGINT, SIGQUIT
+	StopTimeout         int               `json:"stop_timeout,omitempty"` // in seconds
+	Notify              bool              `json:"notify,omitempty"`       // i.e. Type=notify
+	Watchdog            int               `json:"watchdog,omitempty"`     // in seconds, i.e. WatchdogSec
+	Metrics             string            `json:"metrics,omitempty"`      // i.e. 127.0.0.1:9100 (the runner only)
+	Limits              Limits            `json:"limits,omitempty"`
+	Sandbox             Sandbox           `json:"sandbox,omitempty"`
+	Capabilities        []string          `json:"capabilities,omitempty"` // i.e. CAP_NET_RAW
+	After               []string          `json:"after,omitempty"`
+	Requires            []string          `json:"requires,omitempty"`
+	Wants               []string          `json:"wants,omitempty"`
+	StartMode           string            `json:"start_mode,omitempty"` // i.e. boot, login, manual
+	WantedBy            []string          `json:"wanted_by,omitempty"`
+	Instances           Instances         `json:"instances,omitempty"`
+	Instance            string            `json:"-"`
 }
 
 func (s *Service) NormalizeWithoutPath() {
@@ -85,6 +157,13 @@ func (s *Service) NormalizeWithoutPath() {
 		// but whatever
 		s.ReverseDNS = s.Name
 	}
+	if "" != s.StopSignal {
+		// TERM, term, and SIGTERM all mean SIGTERM
+		s.StopSignal = strings.ToUpper(s.StopSignal)
+		if _, err := strconv.Atoi(s.StopSignal); nil != err && !strings.HasPrefix(s.StopSignal, "SIG") {
+			s.StopSignal = "SIG" + s.StopSignal
+		}
+	}
 
 	if !s.System {
 		home, err := os.UserHomeDir()
@@ -99,6 +178,7 @@ func (s *Service) NormalizeWithoutPath() {
 	} else {
 		s.Logdir = "/var/log/" + s.Name
 	}
+	s.Rundir = s.defaultRundir()
 }
 
 func (s *Service) Normalize(force bool) {
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"fmt"
+)
+
+// Start modes
+const (
+	// StartBoot starts a system service on boot
+	// (or a user service when the user's service manager starts)
+	StartBoot = "boot"
+	// StartLogin starts a user service when the user logs in
+	StartLogin = "login"
+	// StartManual installs the service, but only starts it when asked
+	StartManual = "manual"
+)
+
+// Mode is the start mode, which defaults to boot for system services
+// and login for user services
+func (s *Service) Mode() string {
+	if "" != s.StartMode {
+		return s.StartMode
+	}
+	if s.System {
+		return StartBoot
+	}
+	return StartLogin
+}
+
+// ValidateStartMode returns an error for an unknown start mode,
+// or for a system service that should start on login
+func (s *Service) ValidateStartMode() error {
+	switch s.StartMode {
+	case "", StartBoot, StartManual:
+		return nil
+	case StartLogin:
+		if s.System {
+			return fmt.Errorf("only user services (not system services) can start on login")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown start mode %q (expected boot, login, or manual)", s.StartMode)
+	}
+}
+
+// Targets are the systemd targets that want the service (its WantedBy=),
+// which are none at all for manual services
+func (s *Service) Targets() []string {
+	if StartManual == s.Mode() {
+		return nil
+	}
+	if len(s.WantedBy) > 0 {
+		targets := []string{}
+		for i := range s.WantedBy {
+			targets = append(targets, UnitName(s.WantedBy[i]))
+		}
+		return targets
+	}
+	if s.System {
+		return []string{"multi-user.target"}
+	}
+	// (default.target is reached when the user's service manager starts, which may be
+	// on boot, with lingering, whereas the graphical session is only ever on login)
+	if StartLogin == s.Mode() {
+		return []string{"graphical-session.target"}
+	}
+	return []string{"default.target"}
+}
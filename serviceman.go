@@ -39,9 +39,15 @@ func usage() {
 	fmt.Println("\tserviceman <command> --help")
 	fmt.Println("\tserviceman add ./foo-app -- --foo-arg")
 	fmt.Println("\tserviceman run --config ./foo-app.json")
+	fmt.Println("\tserviceman init --config ./foo-app.json [--config ./bar-app.json | ./services/]")
+	fmt.Println("\tserviceman up [-f ./services.json | ./services/]")
 	fmt.Println("\tserviceman list --all")
-	fmt.Println("\tserviceman start <name>")
-	fmt.Println("\tserviceman stop <name>")
+	fmt.Println("\tserviceman start <name>[@instance] [name...]")
+	fmt.Println("\tserviceman stop <name>[@instance] [name...]")
+	fmt.Println("\tserviceman status <name>[@instance] [name...]")
+	fmt.Println("\tserviceman history <name>[@instance]")
+	fmt.Println("\tserviceman security <name>")
+	fmt.Println("\tserviceman logs [--rotate] <name>")
 }
 
 func main() {
@@ -66,14 +72,26 @@ func main() {
 		fmt.Println(ver())
 	case "run":
 		run()
+	case "init":
+		containerInit()
+	case "up":
+		up()
 	case "add":
 		add()
 	case "start":
 		start()
 	case "stop":
 		stop()
+	case "status":
+		status()
+	case "history":
+		history()
 	case "list":
 		list()
+	case "security":
+		security()
+	case "logs":
+		logs()
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown argument %s\n", top)
 		usage()
@@ -101,10 +119,58 @@ func add() {
 	flag.BoolVar(&forUser, "user", false, "add user space / user mode service even when admin/root/sudo/elevated")
 	flag.BoolVar(&force, "force", false, "if the interpreter or executable doesn't exist, or things don't make sense, try anyway")
 	flag.StringVar(&pathEnv, "path", "", "set the path for the resulting systemd service")
+	var envs, envFiles stringsFlag
+	flag.Var(&envs, "env", "an environment variable for the service, as KEY=value (repeatable)")
+	flag.Var(&envFiles, "env-file", "a dotenv file to load, re-read on each restart (repeatable, prefix with '-' if it may be missing)")
+	var secrets, secretValues stringsFlag
+	flag.Var(&secrets, "secret", "a secret for the service, as NAME=/path/to/file (repeatable)")
+	flag.Var(&secretValues, "secret-value", "a secret for the service, as NAME=value, kept out of the service file (repeatable)")
+	envInherit := ""
+	flag.StringVar(&envInherit, "env-inherit", "", "which of the runner's environment variables to pass along: inherit (default), clean, or a list such as PATH,HOME")
 	flag.StringVar(&conf.User, "username", "", "run the service as this user")
 	flag.StringVar(&conf.Group, "groupname", "", "run the service as this group")
+	flag.StringVar(&conf.StateDirectory, "state-dir", "", "a directory for the service's data, relative to /var/lib (ex: foo-app)")
+	flag.StringVar(&conf.CacheDirectory, "cache-dir", "", "a directory for the service's cache, relative to /var/cache (ex: foo-app)")
+	flag.StringVar(&conf.RuntimeDirectory, "runtime-dir", "", "a directory for the service's sockets and such, relative to /run (ex: foo-app)")
+	flag.StringVar(&conf.LogsDirectory, "logs-dir", "", "a directory for the service's own logs, relative to /var/log (ex: foo-app)")
+	flag.StringVar(&conf.Logging.Destination, "log", "", "where the service's output goes: journal (systemd default), file (default otherwise), syslog, or none")
+	flag.StringVar(&conf.Logging.Stdout, "log-stdout", "", "the file for the service's stdout (default: <logdir>/<name>.log)")
+	flag.StringVar(&conf.Logging.Stderr, "log-stderr", "", "the file for the service's stderr, if not the same as stdout")
+	flag.StringVar(&conf.Logging.Format, "log-format", "", "put a timestamp, the stream, and the run ID on each line of output: text or json (runner only)")
+	flag.StringVar(&conf.Logging.SyslogAddress, "syslog-address", "", "where to send syslog: udp://host:port or unix:///path/to/socket (default: the local syslog) (runner only)")
+	flag.StringVar(&conf.Logging.MaxSize, "log-max-size", "", "rotate the log files when they get bigger than this (ex: 10M) (runner only)")
+	flag.StringVar(&conf.Logging.MaxAge, "log-max-age", "", "rotate the log files when they get older than this (ex: 24h or 7d) (runner only)")
+	flag.IntVar(&conf.Logging.Keep, "log-keep", 0, fmt.Sprintf("how many rotated logs to keep (default %d, unless --log-keep-days is set)", service.DefaultKeep))
+	flag.IntVar(&conf.Logging.KeepDays, "log-keep-days", 0, "how many days to keep rotated logs")
+	flag.StringVar(&conf.Logging.SyslogIdentifier, "syslog-identifier", "", "what the service's logs are tagged with in syslog or the journal (default: the name)")
 	flag.BoolVar(&conf.PrivilegedPorts, "cap-net-bind", false, "this service should have access to privileged ports")
+	var caps stringsFlag
+	flag.Var(&caps, "cap", "a Linux capability that the service needs, such as net_raw or CAP_LEASE (repeatable)")
 	flag.BoolVar(&dryrun, "dryrun", false, "output the service file without modifying anything on disk")
+	flag.StringVar(&conf.Sandbox.Profile, "sandbox", "", "how much to sandbox the service: none, basic, or strict (systemd only)")
+	flag.StringVar(&conf.StopSignal, "stop-signal", "", "the signal that asks the service to stop (ex: SIGINT, default: SIGTERM)")
+	flag.IntVar(&conf.StopTimeout, "stop-timeout", 0, "how many seconds the service has to stop before it is killed")
+	flag.BoolVar(&conf.Notify, "notify", false, "the service says when it's ready with sd_notify, i.e. Type=notify (systemd and the runner)")
+	flag.IntVar(&conf.Watchdog, "watchdog", 0, "restart the service if it doesn't send WATCHDOG=1 every so many seconds (needs --notify)")
+	flag.StringVar(&conf.Metrics, "metrics", "", "where the runner serves Prometheus metrics about the service: a loopback address (ex: 127.0.0.1:9100) or unix:///path (the runner only)")
+	flag.StringVar(&conf.StartMode, "start-mode", "", "when the service starts: boot (system default), login (user default), or manual")
+	var wantedBy stringsFlag
+	flag.Var(&wantedBy, "wanted-by", "a systemd target that should start the service, in place of the start mode's (repeatable)")
+	flag.IntVar(&conf.Instances.Count, "instances", 0, "run this many instances of the service (named 1, 2, 3...)")
+	var instanceNames, instanceEnvs stringsFlag
+	flag.Var(&instanceNames, "instance", "run an instance of the service with this name (repeatable)")
+	flag.Var(&instanceEnvs, "instance-env", "an environment variable for each instance, as KEY=template (ex: PORT={{ .Instance | add 8000 }}) (repeatable)")
+	var after, requires, wants stringsFlag
+	flag.Var(&after, "after", "a service (or systemd unit) that should be started before this one (repeatable)")
+	flag.Var(&requires, "requires", "a service (or systemd unit) that this one can't run without (repeatable)")
+	flag.Var(&wants, "wants", "a service (or systemd unit) that this one would like to have running (repeatable)")
+	var limits stringsFlag
+	flag.Var(&limits, "limit", "a resource limit, such as open_files=65536 or memory_max=512M (repeatable)")
+	var preStart, postStart, preStop, postStop stringsFlag
+	flag.Var(&preStart, "pre-start", "a command to run before the service starts (repeatable, prefix with '-' to ignore failure, and quote arguments that have spaces)")
+	flag.Var(&postStart, "post-start", "a command to run after the service starts (repeatable)")
+	flag.Var(&preStop, "pre-stop", "a command to run before the service is stopped (repeatable)")
+	flag.Var(&postStop, "post-stop", "a command to run after the service has stopped (repeatable)")
 	flag.Parse()
 	flagargs := flag.Args()
 
@@ -122,6 +188,39 @@ func add() {
 		return
 	}
 
+	if err := conf.Sandbox.ValidateProfile(); nil != err {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+		return
+	}
+	for _, path := range []*string{&conf.Logging.Stdout, &conf.Logging.Stderr} {
+		if "" != *path {
+			*path, _ = filepath.Abs(*path)
+		}
+	}
+	if err := conf.Logging.Validate(); nil != err {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+		return
+	}
+	if err := conf.ValidateDirectories(); nil != err {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+		return
+	}
+	if conf.Watchdog > 0 && !conf.Notify {
+		fmt.Fprintf(os.Stderr, "--watchdog needs --notify, as the watchdog is pinged with sd_notify\n")
+		os.Exit(1)
+		return
+	}
+	if "" != conf.Metrics {
+		if _, _, err := conf.MetricsListener(); nil != err {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(1)
+			return
+		}
+	}
+
 	// There are three groups of flags
 	// serviceman --flag1 arg1 non-flag-arg --child1 -- --raw1 -- --raw2
 	//  serviceman --flag1 arg1   // these belong to serviceman
@@ -178,10 +277,147 @@ func add() {
 		ass = append(ass, fmt.Sprintf("  --name %s", conf.Name))
 		ass = append(ass, "")
 	}
+	for i := range envs {
+		kv := strings.SplitN(envs[i], "=", 2)
+		if 2 != len(kv) || "" == kv[0] {
+			fmt.Fprintf(os.Stderr, "--env must look like KEY=value, not %q\n", envs[i])
+			os.Exit(1)
+			return
+		}
+		if nil == conf.Envs {
+			conf.Envs = make(map[string]string)
+		}
+		conf.Envs[kv[0]] = kv[1]
+	}
 	if "" != pathEnv {
-		conf.Envs = make(map[string]string)
+		if nil == conf.Envs {
+			conf.Envs = make(map[string]string)
+		}
 		conf.Envs["PATH"] = pathEnv
 	}
+	for i := range envFiles {
+		path, optional := service.EnvFile(envFiles[i])
+		path, _ = filepath.Abs(path)
+		if _, err := os.Stat(path); nil != err && !optional && !force {
+			fmt.Fprintf(os.Stderr, "could not read env file %q (prefix it with '-' if that's expected)\n", path)
+			os.Exit(1)
+			return
+		}
+		if optional {
+			path = "-" + path
+		}
+		conf.EnvFiles = append(conf.EnvFiles, path)
+	}
+	conf.EnvInherit = service.ParseEnvInherit(envInherit)
+
+	for _, secretFlags := range []struct {
+		secrets stringsFlag
+		value   bool
+	}{
+		{secrets, false},
+		{secretValues, true},
+	} {
+		for i := range secretFlags.secrets {
+			secret, err := service.ParseSecret(secretFlags.secrets[i], secretFlags.value)
+			if nil != err {
+				fmt.Fprintf(os.Stderr, "%s\n", err)
+				os.Exit(1)
+				return
+			}
+			if "" != secret.File {
+				secret.File, _ = filepath.Abs(secret.File)
+				if _, err := os.Stat(secret.File); nil != err && !force {
+					fmt.Fprintf(os.Stderr, "could not read secret file %q\n", secret.File)
+					os.Exit(1)
+					return
+				}
+			}
+			conf.Secrets = append(conf.Secrets, secret)
+		}
+	}
+
+	for i := range caps {
+		capname, _, err := service.ParseCapability(caps[i])
+		if nil != err {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			if !force {
+				os.Exit(1)
+				return
+			}
+			continue
+		}
+		conf.Capabilities = append(conf.Capabilities, capname)
+	}
+
+	conf.WantedBy = wantedBy
+	conf.Instances.Names = instanceNames
+	if err := conf.ValidateInstances(); nil != err {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+		return
+	}
+	for i := range instanceEnvs {
+		kv := strings.SplitN(instanceEnvs[i], "=", 2)
+		if 2 != len(kv) || "" == kv[0] {
+			fmt.Fprintf(os.Stderr, "--instance-env must look like KEY=template, not %q\n", instanceEnvs[i])
+			os.Exit(1)
+			return
+		}
+		if nil == conf.Instances.Envs {
+			conf.Instances.Envs = make(map[string]string)
+		}
+		conf.Instances.Envs[kv[0]] = kv[1]
+	}
+	// check that the templates are good before installing anything
+	for _, instance := range conf.InstanceNames() {
+		if _, err := conf.ForInstance(instance); nil != err {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(1)
+			return
+		}
+	}
+	conf.After = after
+	conf.Requires = requires
+	conf.Wants = wants
+
+	for i := range limits {
+		kv := strings.SplitN(limits[i], "=", 2)
+		if 2 != len(kv) {
+			fmt.Fprintf(os.Stderr, "--limit must look like name=value, not %q\n", limits[i])
+			os.Exit(1)
+			return
+		}
+		if err := conf.Limits.Set(kv[0], kv[1]); nil != err {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(1)
+			return
+		}
+	}
+
+	for _, hooks := range []struct {
+		cmds  []string
+		hooks *[]service.Hook
+	}{
+		{preStart, &conf.Hooks.PreStart},
+		{postStart, &conf.Hooks.PostStart},
+		{preStop, &conf.Hooks.PreStop},
+		{postStop, &conf.Hooks.PostStop},
+	} {
+		for i := range hooks.cmds {
+			hook := service.ParseHook(hooks.cmds[i])
+			if "" == hook.Exec {
+				continue
+			}
+			hookpath, err := findExec(hook.Exec, force)
+			if nil != err {
+				fmt.Fprintf(os.Stderr, "%s\n", err)
+				os.Exit(3)
+				return
+			}
+			hook.Exec = hookpath
+			*hooks.hooks = append(*hooks.hooks, hook)
+		}
+	}
 
 	exepath, err := findExec(flagargs[0], force)
 	if nil != err {
@@ -246,6 +482,20 @@ func add() {
 		fmt.Fprintf(os.Stderr, "Warning: You may need to use 'sudo' to add %q as a privileged system service.\n", conf.Name)
 	}
 
+	if err := conf.ValidateStartMode(); nil != err {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+		return
+	}
+
+	if missing := manager.MissingDependencies(conf); len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: %q depends on services that aren't installed: %s\n", conf.Name, strings.Join(missing, ", "))
+		if !force && !dryrun {
+			os.Exit(1)
+			return
+		}
+	}
+
 	if len(ass) > 0 {
 		fmt.Printf("OPTIONS: Making some assumptions...\n\n")
 		for i := range ass {
@@ -262,14 +512,22 @@ func add() {
 		if "" == runAs {
 			runAs = "root"
 		}
-		fmt.Printf("\t# Starts on system boot, as %q\n", runAs)
+		if service.StartManual == conf.Mode() {
+			fmt.Printf("\t# Starts only when asked (serviceman start %s), as %q\n", conf.Name, runAs)
+		} else {
+			fmt.Printf("\t# Starts on system boot, as %q\n", runAs)
+		}
 	} else {
 		u, _ := user.Current()
 		runAs = u.Name
 		if "" == runAs {
 			runAs = u.Username
 		}
-		fmt.Printf("\t# Starts as %q, when %q logs in\n", runAs, u.Username)
+		if service.StartManual == conf.Mode() {
+			fmt.Printf("\t# Starts as %q, only when asked (serviceman start %s)\n", runAs, conf.Name)
+		} else {
+			fmt.Printf("\t# Starts as %q, when %q logs in\n", runAs, u.Username)
+		}
 	}
 	//fmt.Printf("\tpushd %s\n", conf.Workdir)
 	fmt.Printf("\t%s\n", conf.Exec)
@@ -322,6 +580,18 @@ func add() {
 	if conf.System {
 		servicemode = "SYSTEM"
 	}
+	if service.StartManual == conf.Mode() {
+		fmt.Printf(
+			"SUCCESS:\n\n\t%q installed as a %s %s service, to run as %q\n\n\tStart it with: serviceman start %s\n",
+			conf.Name,
+			servicetype,
+			servicemode,
+			runAs,
+			conf.Name,
+		)
+		fmt.Println()
+		return
+	}
 	fmt.Printf(
 		"SUCCESS:\n\n\t%q started as a %s %s service, running as %q\n",
 		conf.Name,
@@ -369,7 +639,17 @@ func list() {
 
 	fmt.Printf("serviceman-managed services:\n\n")
 	for i := range managed {
-		fmt.Println("\t" + managed[i])
+		// foo@ is the template for foo's instances
+		name := strings.TrimSuffix(managed[i], "@")
+		c := &service.Service{Name: name, ReverseDNS: name, System: conf.System, Home: conf.Home}
+		mode, err := manager.StartMode(c)
+		if nil != err {
+			mode = "unknown"
+		}
+		if instances := manager.Instances(c); len(instances) > 0 {
+			mode += ", instances: " + strings.Join(instances, " ")
+		}
+		fmt.Printf("\t%s (%s)\n", name, mode)
 	}
 	if 0 == len(managed) {
 		fmt.Println("\t(none)")
@@ -388,6 +668,35 @@ func list() {
 	}
 }
 
+// printLogFiles shows where the logs are, when they're in files
+func printLogFiles(conf *service.Service) {
+	if service.LogNone == conf.LogDestination() {
+		fmt.Printf("The output of %q is thrown away (logging to none)\n", conf.Name)
+		return
+	}
+	if conf.Multi() && "" == conf.Logging.Stdout {
+		// one for each instance, i.e. foo@1.log
+		fmt.Printf("If all went well the logs should have been created at:\n\n\t%s\n", conf.Logdir)
+		return
+	}
+	fmt.Printf("If all went well the logs should have been created at:\n\n\t%s\n", conf.StdoutPath())
+	if conf.StderrPath() != conf.StdoutPath() {
+		fmt.Printf("\t%s\n", conf.StderrPath())
+	}
+}
+
+// stringsFlag collects a flag that may be given more than once
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ", ")
+}
+
+func (f *stringsFlag) Set(s string) error {
+	*f = append(*f, s)
+	return nil
+}
+
 func findExec(exe string, force bool) (string, error) {
 	// ex: node => /usr/local/bin/node
 	// ex: ./demo.js => /Users/aj/project/demo.js
@@ -499,9 +808,162 @@ func start() {
 	flag.BoolVar(&forUser, "user", false, "add user space / user mode service even when admin/root/sudo/elevated")
 	flag.Parse()
 
+	args := flag.Args()
+	if 0 == len(args) {
+		fmt.Println("Usage: serviceman start <name>[@instance] [name...]")
+		os.Exit(1)
+	}
+
+	if forUser && forSystem {
+		fmt.Println("Pfff! You can't --user AND --system! What are you trying to pull?")
+		os.Exit(1)
+		return
+	}
+
+	system := manager.IsPrivileged()
+	if forUser {
+		system = false
+	} else if forSystem {
+		system = true
+	}
+	confs, err := orderServices(args, system)
+	if nil != err {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+		return
+	}
+
+	for _, conf := range confs {
+		err := manager.Start(conf)
+		if nil != err {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(500)
+			return
+		}
+	}
+}
+
+func stop() {
+	forUser := false
+	forSystem := false
+	flag.BoolVar(&forSystem, "system", false, "attempt to add system service as an unprivileged/unelevated user")
+	flag.BoolVar(&forUser, "user", false, "add user space / user mode service even when admin/root/sudo/elevated")
+	flag.Parse()
+
+	args := flag.Args()
+	if 0 == len(args) {
+		fmt.Println("Usage: serviceman stop <name>[@instance] [name...]")
+		os.Exit(1)
+	}
+
+	if forUser && forSystem {
+		fmt.Println("Pfff! You can't --user AND --system! What are you trying to pull?")
+		os.Exit(1)
+		return
+	}
+
+	system := manager.IsPrivileged()
+	if forUser {
+		system = false
+	} else if forSystem {
+		system = true
+	}
+	confs, err := orderServices(args, system)
+	if nil != err {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+		return
+	}
+
+	// stop dependents before the services they depend on
+	for i := len(confs) - 1; i >= 0; i-- {
+		if err := manager.Stop(confs[i]); nil != err {
+			fmt.Println(err)
+			os.Exit(127)
+		}
+	}
+}
+
+func status() {
+	forUser := false
+	forSystem := false
+	flag.BoolVar(&forSystem, "system", false, "show the status of the system service, even as an unprivileged/unelevated user")
+	flag.BoolVar(&forUser, "user", false, "show the status of the user mode service, even when admin/root/sudo/elevated")
+	flag.Parse()
+
+	args := flag.Args()
+	if 0 == len(args) {
+		fmt.Println("Usage: serviceman status <name>[@instance] [name...]")
+		os.Exit(1)
+	}
+
+	if forUser && forSystem {
+		fmt.Println("Pfff! You can't --user AND --system! What are you trying to pull?")
+		os.Exit(1)
+		return
+	}
+
+	system := manager.IsPrivileged()
+	if forUser {
+		system = false
+	} else if forSystem {
+		system = true
+	}
+	confs, err := orderServices(args, system)
+	if nil != err {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+		return
+	}
+
+	// like systemctl is-active, anything that isn't running is a failure
+	code := 0
+	for _, conf := range confs {
+		statuses, err := manager.Status(conf)
+		if nil != err {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", conf.InstanceName(), err)
+			code = 3
+			continue
+		}
+		for _, st := range statuses {
+			fmt.Printf("%s: %s", st.Name, st.State)
+			if 0 != st.PID {
+				fmt.Printf(" (pid %d)", st.PID)
+			}
+			if !st.Since.IsZero() {
+				fmt.Printf(" since %s", st.Since.Format(time.RFC3339))
+			}
+			fmt.Println()
+			if 0 != st.Runner {
+				fmt.Printf("\trunner: pid %d, run %s\n", st.Runner, st.RunID)
+			}
+			if 0 != st.Restarts {
+				fmt.Printf("\trestarts: %d\n", st.Restarts)
+			}
+			if "" != st.Status {
+				fmt.Printf("\tstatus: %s\n", st.Status)
+			}
+			if "" != st.LastExit {
+				fmt.Printf("\tlast exit: %s\n", st.LastExit)
+			}
+			if runner.StateRunning != st.State {
+				code = 3
+			}
+		}
+	}
+	os.Exit(code)
+}
+
+func history() {
+	forUser := false
+	forSystem := false
+	flag.BoolVar(&forSystem, "system", false, "show the history of the system service, even as an unprivileged/unelevated user")
+	flag.BoolVar(&forUser, "user", false, "show the history of the user mode service, even when admin/root/sudo/elevated")
+	flag.Parse()
+
 	args := flag.Args()
 	if 1 != len(args) {
-		fmt.Println("Usage: serviceman start <name>")
+		fmt.Println("Usage: serviceman history <name>[@instance]")
 		os.Exit(1)
 	}
 
@@ -511,9 +973,9 @@ func start() {
 		return
 	}
 
+	name, instance := service.ParseInstance(args[0])
 	conf := &service.Service{
-		Name:    args[0],
-		Restart: false,
+		Name: name,
 	}
 	if forUser {
 		conf.System = false
@@ -523,25 +985,136 @@ func start() {
 		conf.System = manager.IsPrivileged()
 	}
 	conf.NormalizeWithoutPath()
+	if "" != instance {
+		if err := service.ValidateInstance(instance); nil != err {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(1)
+			return
+		}
+		conf.Instance = instance
+		conf.ReverseDNS += "@" + instance
+	}
 
-	err := manager.Start(conf)
+	runs, err := manager.History(conf)
 	if nil != err {
 		fmt.Fprintf(os.Stderr, "%s\n", err)
 		os.Exit(500)
 		return
 	}
+	if 0 == len(runs) {
+		fmt.Printf("%q hasn't run yet\n", args[0])
+		return
+	}
+
+	for _, run := range runs {
+		d := time.Duration(run.Duration * float64(time.Second)).Round(time.Millisecond)
+		fmt.Printf("%s  %s  %10s  %s", run.Service, run.Start.Format(time.RFC3339), d, run.Exit)
+		if 0 != run.PID {
+			fmt.Printf(" (pid %d)", run.PID)
+		}
+		if run.Backoff {
+			fmt.Printf(", then backed off")
+		}
+		fmt.Println()
+	}
 }
 
-func stop() {
+// orderServices puts services after the ones they depend on,
+// according to their installed service files
+func orderServices(names []string, system bool) ([]*service.Service, error) {
+	confs := []*service.Service{}
+	for i := range names {
+		name, instance := service.ParseInstance(names[i])
+		conf := &service.Service{
+			Name:    name,
+			Restart: false,
+			System:  system,
+		}
+		conf.NormalizeWithoutPath()
+		// if it isn't installed, starting or stopping it will say so
+		conf.After, _ = manager.Dependencies(conf)
+
+		// foo means all of foo's instances, and foo@1 means just the one
+		instances := manager.Instances(conf)
+		if "" != instance {
+			found := false
+			for j := range instances {
+				found = found || instance == instances[j]
+			}
+			if !found {
+				return nil, fmt.Errorf("%q is not an installed instance of %q", instance, name)
+			}
+			instances = []string{instance}
+		}
+		if 0 == len(instances) {
+			confs = append(confs, conf)
+			continue
+		}
+		for j := range instances {
+			ic := *conf
+			ic.Instance = instances[j]
+			ic.ReverseDNS = conf.ReverseDNS + "@" + instances[j]
+			confs = append(confs, &ic)
+		}
+	}
+	return service.Order(confs)
+}
+
+func security() {
 	forUser := false
 	forSystem := false
-	flag.BoolVar(&forSystem, "system", false, "attempt to add system service as an unprivileged/unelevated user")
-	flag.BoolVar(&forUser, "user", false, "add user space / user mode service even when admin/root/sudo/elevated")
+	flag.BoolVar(&forSystem, "system", false, "score the sandboxing of the system service, even as an unprivileged/unelevated user")
+	flag.BoolVar(&forUser, "user", false, "score the sandboxing of the user mode service, even when admin/root/sudo/elevated")
+	flag.Parse()
+
+	args := flag.Args()
+	if 1 != len(args) {
+		fmt.Println("Usage: serviceman security <name>")
+		os.Exit(1)
+	}
+
+	if forUser && forSystem {
+		fmt.Println("Pfff! You can't --user AND --system! What are you trying to pull?")
+		os.Exit(1)
+		return
+	}
+
+	conf := &service.Service{
+		Name: args[0],
+	}
+	if forUser {
+		conf.System = false
+	} else if forSystem {
+		conf.System = true
+	} else {
+		conf.System = manager.IsPrivileged()
+	}
+	conf.NormalizeWithoutPath()
+
+	report, err := manager.Security(conf)
+	if nil != err {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(500)
+		return
+	}
+	fmt.Print(report)
+}
+
+func logs() {
+	forUser := false
+	forSystem := false
+	rotate := false
+	logging := service.Logging{}
+	flag.BoolVar(&forSystem, "system", false, "find (or rotate) the logs of the system service, even as an unprivileged/unelevated user")
+	flag.BoolVar(&forUser, "user", false, "find (or rotate) the logs of the user mode service, even when admin/root/sudo/elevated")
+	flag.BoolVar(&rotate, "rotate", false, "rotate the log files now, by copying (and gzipping) them, and then truncating them")
+	flag.IntVar(&logging.Keep, "keep", 0, fmt.Sprintf("how many rotated logs to keep (default %d, unless --keep-days is set)", service.DefaultKeep))
+	flag.IntVar(&logging.KeepDays, "keep-days", 0, "how many days to keep rotated logs")
 	flag.Parse()
 
 	args := flag.Args()
 	if 1 != len(args) {
-		fmt.Println("Usage: serviceman stop <name>")
+		fmt.Println("Usage: serviceman logs [--rotate] <name>")
 		os.Exit(1)
 	}
 
@@ -552,8 +1125,7 @@ func stop() {
 	}
 
 	conf := &service.Service{
-		Name:    args[0],
-		Restart: false,
+		Name: args[0],
 	}
 	if forUser {
 		conf.System = false
@@ -564,9 +1136,29 @@ func stop() {
 	}
 	conf.NormalizeWithoutPath()
 
-	if err := manager.Stop(conf); nil != err {
-		fmt.Println(err)
-		os.Exit(127)
+	files, err := manager.LogFiles(conf)
+	if nil != err {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(500)
+		return
+	}
+	if 0 == len(files) {
+		fmt.Printf("%q doesn't log to files (the journal, or syslog, rotates its own logs)\n", conf.Name)
+		return
+	}
+
+	for _, path := range files {
+		if !rotate {
+			fmt.Println(path)
+			continue
+		}
+		// copy and truncate, as the file is open in launchd (or the runner, or systemd)
+		if err := runner.RotateLog(path, logging); nil != err {
+			fmt.Fprintf(os.Stderr, "could not rotate %q: %s\n", path, err)
+			os.Exit(500)
+			return
+		}
+		fmt.Printf("Rotated %s\n", path)
 	}
 }
 
@@ -574,7 +1166,7 @@ func run() {
 	var confpath string
 	var daemonize bool
 	flag.StringVar(&confpath, "config", "", "path to a config file to run")
-	flag.BoolVar(&daemonize, "daemon", false, "spawn a child process that lives in the background, and exit")
+	flag.BoolVar(&daemonize, "daemon", false, "spawn a runner that lives in the background, and exit once it has started")
 	flag.Parse()
 
 	if "" == confpath {
@@ -584,24 +1176,64 @@ func run() {
 		os.Exit(1)
 	}
 
-	b, err := ioutil.ReadFile(confpath)
+	s, err := readConfig(confpath)
 	if nil != err {
-		fmt.Fprintf(os.Stderr, "Couldn't read config file: %s\n", err)
+		fmt.Fprintf(os.Stderr, "%s\n", err)
 		os.Exit(400)
 	}
 
+	switch s.LogDestination() {
+	case service.LogSyslog, service.LogJournal:
+		fmt.Printf("All output will be directed to %s, tagged %q\n", s.LogDestination(), s.SyslogIdent())
+	default:
+		fmt.Printf("All output will be directed to the logs at:\n\t%s\n", s.Logdir)
+	}
+	// (the runner creates the log directory)
+	if !daemonize {
+		//fmt.Fprintf(os.Stdout, "Running %s %s %s\n", s.Interpreter, s.Exec, strings.Join(s.Argv, " "))
+		if err := runner.Start(s); nil != err {
+			fmt.Println("Error:", err)
+		}
+		return
+	}
+
+	// the runner starts out in / (or the workdir), so it needs the full paths
+	exe, err := os.Executable()
+	if nil == err {
+		confpath, err = filepath.Abs(confpath)
+	}
+	if nil != err {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(500)
+	}
+	pid, err := manager.Daemon(s, exe, "run", "--config", confpath)
+	if nil != err {
+		fmt.Fprintf(os.Stderr, "Error: could not start %q: %s\n", s.Name, err)
+		os.Exit(500)
+	}
+	fmt.Printf("Started %q (runner pid %d)\n", s.Name, pid)
+}
+
+// readConfig reads a service's config file, as for run
+func readConfig(confpath string) (*service.Service, error) {
+	b, err := ioutil.ReadFile(confpath)
+	if nil != err {
+		return nil, fmt.Errorf("Couldn't read config file: %s", err)
+	}
+	return parseConfig(confpath, b)
+}
+
+func parseConfig(confpath string, b []byte) (*service.Service, error) {
 	s := &service.Service{}
-	err = json.Unmarshal(b, s)
+	err := json.Unmarshal(b, s)
 	if nil != err {
-		fmt.Fprintf(os.Stderr, "Couldn't JSON parse config file: %s\n", err)
-		os.Exit(400)
+		return nil, fmt.Errorf("Couldn't JSON parse config file %q: %s", confpath, err)
 	}
 
 	m := map[string]interface{}{}
 	err = json.Unmarshal(b, &m)
 	if nil != err {
-		fmt.Fprintf(os.Stderr, "Couldn't JSON parse config file: %s\n", err)
-		os.Exit(400)
+		return nil, fmt.Errorf("Couldn't JSON parse config file %q: %s", confpath, err)
 	}
 
 	// default Restart to true
@@ -610,26 +1242,134 @@ func run() {
 	}
 
 	if "" == s.Exec {
-		fmt.Fprintf(os.Stderr, "Missing exec\n")
-		os.Exit(400)
+		return nil, fmt.Errorf("Missing exec in %q", confpath)
+	}
+	// (add checks these as it's given them, but a config file may have anything)
+	if err := s.ValidateCapabilities(); nil != err {
+		return nil, fmt.Errorf("Bad capabilities in %q: %s", confpath, err)
+	}
+	if err := s.ValidateInstances(); nil != err {
+		return nil, fmt.Errorf("Bad instances in %q: %s", confpath, err)
 	}
 
 	force := false
 	s.Normalize(force)
-	fmt.Printf("All output will be directed to the logs at:\n\t%s\n", s.Logdir)
-	err = os.MkdirAll(s.Logdir, 0755)
+	return s, nil
+}
+
+// readConfigs reads each config file (which may have a list of configs),
+// and each *.json in each directory
+func readConfigs(paths []string) ([]*service.Service, error) {
+	confpaths := []string{}
+	for _, path := range paths {
+		fi, err := os.Stat(path)
+		if nil != err {
+			return nil, fmt.Errorf("Couldn't read config file: %s", err)
+		}
+		if !fi.IsDir() {
+			confpaths = append(confpaths, path)
+			continue
+		}
+		// (in order, by name)
+		matches, err := filepath.Glob(filepath.Join(path, "*.json"))
+		if nil != err {
+			return nil, err
+		}
+		confpaths = append(confpaths, matches...)
+	}
+
+	confs := []*service.Service{}
+	for _, confpath := range confpaths {
+		b, err := ioutil.ReadFile(confpath)
+		if nil != err {
+			return nil, fmt.Errorf("Couldn't read config file: %s", err)
+		}
+		list := []json.RawMessage{b}
+		if strings.HasPrefix(strings.TrimSpace(string(b)), "[") {
+			if err := json.Unmarshal(b, &list); nil != err {
+				return nil, fmt.Errorf("Couldn't JSON parse config file %q: %s", confpath, err)
+			}
+		}
+		for i := range list {
+			s, err := parseConfig(confpath, list[i])
+			if nil != err {
+				return nil, err
+			}
+			confs = append(confs, s)
+		}
+	}
+	return confs, nil
+}
+
+// up is serviceman up, which runs a project's services together in the foreground,
+// with their output all in one place, until Ctrl-C
+func up() {
+	var confpaths stringsFlag
+	flag.Var(&confpaths, "f", "path to a config file (which may be a list of them), or a directory of them, to run (default ./services.json)")
+	flag.Parse()
+	confpaths = append(confpaths, flag.Args()...)
+	if 0 == len(confpaths) {
+		confpaths = append(confpaths, "services.json")
+	}
+
+	confs, err := readConfigs(confpaths)
+	if nil == err && 0 == len(confs) {
+		err = fmt.Errorf("no config files in %s", strings.Join(confpaths, ", "))
+	}
 	if nil != err {
 		fmt.Fprintf(os.Stderr, "%s\n", err)
-		return
+		os.Exit(1)
 	}
 
-	if !daemonize {
-		//fmt.Fprintf(os.Stdout, "Running %s %s %s\n", s.Interpreter, s.Exec, strings.Join(s.Argv, " "))
-		if err := runner.Start(s); nil != err {
-			fmt.Println("Error:", err)
-		}
-		return
+	statuses, err := runner.Up(confs, os.Stdout)
+	if nil != err {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
 	}
+	os.Exit(runner.ExitCode(statuses))
+}
+
+// containerInit is serviceman init, which runs the services in the foreground
+// (as a container's init, pid 1), and exits once they've all stopped
+func containerInit() {
+	var confpaths stringsFlag
+	flag.Var(&confpaths, "config", "path to a config file, or a directory of them, to run (may be given more than once)")
+	flag.Parse()
+	confpaths = append(confpaths, flag.Args()...)
 
-	manager.Run(os.Args[0], "run", "--config", confpath)
+	// (as pid 1, the exit code is the container's)
+	if 0 == len(confpaths) {
+		fmt.Fprintf(os.Stderr, "--config /path/to/config.json is required\n")
+		usage()
+		os.Exit(1)
+	}
+
+	confs, err := readConfigs(confpaths)
+	if nil == err && 0 == len(confs) {
+		err = fmt.Errorf("no config files in %s", strings.Join(confpaths, ", "))
+	}
+	if nil != err {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+	}
+
+	names := []string{}
+	for i := range confs {
+		names = append(names, confs[i].Name)
+	}
+	fmt.Printf("Starting %s (init pid %d)\n", strings.Join(names, ", "), os.Getpid())
+
+	statuses, err := runner.StartAll(confs)
+	if nil != err {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+	for _, st := range statuses {
+		if "" == st.LastExit {
+			fmt.Printf("%s: %s\n", st.Name, st.State)
+			continue
+		}
+		fmt.Printf("%s: %s (%s)\n", st.Name, st.State, st.LastExit)
+	}
+	os.Exit(runner.ExitCode(statuses))
 }
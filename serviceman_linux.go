@@ -8,6 +8,12 @@ import (
 )
 
 func printLogMessage(conf *service.Service) {
+	switch conf.LogDestination() {
+	case service.LogFile, service.LogNone:
+		printLogFiles(conf)
+		return
+	}
+
 	sudo := ""
 	unit := "--unit"
 	if conf.System {
@@ -17,8 +23,11 @@ func printLogMessage(conf *service.Service) {
 	} else {
 		unit = "--user-unit"
 	}
-	fmt.Println("If all went well you should be able to see some goodies in the logs:\n")
+	fmt.Printf("If all went well you should be able to see some goodies in the logs:\n\n")
 	fmt.Printf("\t%sjournalctl -xe %s %s.service\n", sudo, unit, conf.Name)
+	if service.LogSyslog == conf.LogDestination() {
+		fmt.Printf("\n(and in syslog, tagged %q)\n", conf.SyslogIdent())
+	}
 	if !conf.System {
 		fmt.Println("\nIf that's not the case, see https://unix.stackexchange.com/a/486566/45554.")
 		fmt.Println("(you may need to run `systemctl restart systemd-journald`)")